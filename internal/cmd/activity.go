@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+	"github.com/radutopala/ticket/internal/storage"
+)
+
+var activityFlags struct {
+	since    time.Time
+	assignee []string
+	limit    int
+}
+
+var activityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "Show a feed of recent ticket activity",
+	Long: `Show journal events newest first: tickets created, started, closed, reopened,
+noted and otherwise changed, with who did it.
+
+Examples:
+  tk activity --since 24h          # What happened today
+  tk activity --since 2w -a @me    # Changes to my tickets while I was away`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		events, err := store.ReadJournal()
+		if err != nil {
+			return err
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+		archived, err := store.Archive().List()
+		if err != nil {
+			return err
+		}
+		ticketMap := make(map[string]*domain.Ticket)
+		for _, t := range append(tickets, archived...) {
+			ticketMap[t.ID] = t
+		}
+
+		var feed []storage.Event
+		for i := len(events) - 1; i >= 0; i-- {
+			ev := events[i]
+			if !activityFlags.since.IsZero() && ev.Time.Before(activityFlags.since) {
+				break
+			}
+			if len(activityFlags.assignee) > 0 {
+				t, ok := ticketMap[ev.Ticket]
+				if !ok || !slices.Contains(activityFlags.assignee, t.Assignee) {
+					continue
+				}
+			}
+			feed = append(feed, ev)
+			if activityFlags.limit > 0 && len(feed) == activityFlags.limit {
+				break
+			}
+		}
+
+		return runWithPager(func(w io.Writer) error {
+			for _, ev := range feed {
+				line := fmt.Sprintf("%s  %-10s %s", ev.Time.In(displayLocation).Format("2006-01-02 15:04"), describeEvent(ev), ev.Ticket)
+				if t, ok := ticketMap[ev.Ticket]; ok && t.Title != "" {
+					line += "  " + t.Title
+				}
+				if ev.Actor != "" {
+					line += "  (" + ev.Actor + ")"
+				}
+				if _, err := fmt.Fprintln(w, line); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+// describeEvent returns a past-tense verb for a journal event.
+func describeEvent(ev storage.Event) string {
+	switch ev.Action {
+	case storage.ActionCreate:
+		return tr(msgEventCreated, nil)
+	case storage.ActionStatus:
+		switch ev.Changes["status"].To {
+		case string(domain.StatusInProgress):
+			return tr(msgEventStarted, nil)
+		case string(domain.StatusClosed):
+			return tr(msgEventClosed, nil)
+		default:
+			return tr(msgEventReopened, nil)
+		}
+	case storage.ActionNote:
+		return tr(msgEventNoted, nil)
+	case storage.ActionReview:
+		return tr(msgEventReviewed, nil)
+	case storage.ActionWatch:
+		return tr(msgEventWatchers, nil)
+	case storage.ActionDep:
+		return tr(msgEventDeps, nil)
+	case storage.ActionLink:
+		return tr(msgEventLinked, nil)
+	case storage.ActionDelete:
+		return tr(msgEventDeleted, nil)
+	case storage.ActionArchive:
+		return tr(msgEventArchived, nil)
+	case storage.ActionUndo:
+		return tr(msgEventUndone, nil)
+	default:
+		fields := make([]string, 0, len(ev.Changes))
+		for name := range ev.Changes {
+			fields = append(fields, name)
+		}
+		slices.Sort(fields)
+		if len(fields) == 0 {
+			return tr(msgEventUpdated, nil)
+		}
+		return tr(msgEventUpdatedFields, map[string]any{"Fields": strings.Join(fields, ",")})
+	}
+}
+
+func init() {
+	activityCmd.Flags().Var(timeValue{&activityFlags.since}, "since", "Only events within duration or after time (e.g. 24h, 7d, 2025-01-31)")
+	activityCmd.Flags().StringSliceVarP(&activityFlags.assignee, "assignee", "a", nil, "Only events on tickets assigned to assignee (accepts @me)")
+	activityCmd.Flags().IntVar(&activityFlags.limit, "limit", 50, "Limit number of events (0 for no limit)")
+}
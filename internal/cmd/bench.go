@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+	"github.com/radutopala/ticket/internal/storage"
+)
+
+// benchWords is the vocabulary of synthetic ticket titles and descriptions.
+var benchWords = strings.Fields(`api auth billing cache client config database deploy
+error export frontend handler import index invoice latency login migration
+monitor parser payment queue render report retry schema search session
+storage sync timeout token upload user webhook worker`)
+
+// benchQuery is the search term timed by tk bench.
+const benchQuery = "timeout"
+
+// benchResult holds the timings of one benchmarked operation.
+type benchResult struct {
+	name  string
+	times []time.Duration
+}
+
+var benchFlags struct {
+	tickets    int
+	iterations int
+	current    bool
+}
+
+var benchCmd = &cobra.Command{
+	Use:    "bench",
+	Short:  "Time list, search and query on synthetic tickets",
+	Hidden: true,
+	Long: `Generate synthetic tickets in a temporary directory and time the list,
+search and query code paths, to measure performance work on the storage layer.
+With --current, time the current tickets directory instead (read-only).
+
+Combine with the global --cpuprofile and --memprofile flags to profile a run:
+
+  tk bench -n 5000 --cpuprofile cpu.out
+  go tool pprof cpu.out`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if benchFlags.tickets < 1 || benchFlags.iterations < 1 {
+			return fmt.Errorf("-n and --iterations must be positive")
+		}
+
+		target := store
+		var results []benchResult
+		if !benchFlags.current {
+			dir, err := os.MkdirTemp("", "tk-bench-*")
+			if err != nil {
+				return fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			target = storage.New(dir)
+			start := time.Now()
+			if err := generateBenchTickets(target, benchFlags.tickets); err != nil {
+				return err
+			}
+			results = append(results, benchResult{name: "write", times: []time.Duration{time.Since(start)}})
+		}
+
+		tickets, err := target.List()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%d tickets, %d iterations\n\n", len(tickets), benchFlags.iterations)
+
+		ops, err := runBenchOps(target, benchFlags.iterations)
+		if err != nil {
+			return err
+		}
+		return outputBenchText(cmd.OutOrStdout(), append(results, ops...))
+	},
+}
+
+// generateBenchTickets writes n reproducible synthetic tickets to s.
+func generateBenchTickets(s *storage.Storage, n int) error {
+	if err := s.EnsureDir(); err != nil {
+		return fmt.Errorf("failed to create tickets directory: %w", err)
+	}
+
+	rng := rand.New(rand.NewPCG(1, uint64(n)))
+	words := func(count int) string {
+		picked := make([]string, count)
+		for i := range picked {
+			picked[i] = benchWords[rng.IntN(len(benchWords))]
+		}
+		return strings.Join(picked, " ")
+	}
+
+	created := time.Now().UTC().Add(-time.Duration(n) * time.Hour)
+	for i := range n {
+		t := &domain.Ticket{
+			ID:          fmt.Sprintf("bench-%05d", i),
+			Status:      domain.ValidStatuses[rng.IntN(len(domain.ValidStatuses))],
+			Type:        domain.ValidTypes[rng.IntN(len(domain.ValidTypes))],
+			Priority:    rng.IntN(5),
+			Tags:        []string{benchWords[rng.IntN(len(benchWords))]},
+			Created:     created.Add(time.Duration(i) * time.Hour),
+			Title:       words(5),
+			Description: words(60),
+		}
+		if i > 0 && rng.IntN(4) == 0 {
+			t.Deps = []string{fmt.Sprintf("bench-%05d", rng.IntN(i))}
+		}
+		if t.Status == domain.StatusClosed {
+			t.ClosedAt = t.Created.Add(time.Hour)
+		}
+		if err := s.Restore(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runBenchOps times the read paths of list, search and query on s.
+func runBenchOps(s *storage.Storage, iterations int) ([]benchResult, error) {
+	ops := []struct {
+		name string
+		run  func(tickets []*domain.Ticket) error
+	}{
+		{"list", func(tickets []*domain.Ticket) error {
+			sortTickets(filterTickets(tickets, FilterOptions{Status: []string{string(domain.StatusOpen)}}), SortOptions{})
+			return nil
+		}},
+		{"search", func(tickets []*domain.Ticket) error {
+			searchTickets(tickets, benchQuery, false, "")
+			return nil
+		}},
+		{"query", func(tickets []*domain.Ticket) error {
+			_, err := json.Marshal(tickets)
+			return err
+		}},
+	}
+
+	results := []benchResult{{name: "read"}}
+	for _, op := range ops {
+		results = append(results, benchResult{name: op.name})
+	}
+	for range iterations {
+		start := time.Now()
+		tickets, err := s.List()
+		if err != nil {
+			return nil, err
+		}
+		results[0].times = append(results[0].times, time.Since(start))
+
+		// Each operation gets its own copy so sorting does not help the next
+		for i, op := range ops {
+			start := time.Now()
+			if err := op.run(slices.Clone(tickets)); err != nil {
+				return nil, err
+			}
+			results[i+1].times = append(results[i+1].times, time.Since(start))
+		}
+	}
+	return results, nil
+}
+
+func outputBenchText(w io.Writer, results []benchResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	if _, err := fmt.Fprintln(tw, "OP\tMIN\tMEAN\tMAX\t"); err != nil {
+		return err
+	}
+	for _, r := range results {
+		var total time.Duration
+		for _, d := range r.times {
+			total += d
+		}
+		mean := total / time.Duration(len(r.times))
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t\n", r.name,
+			slices.Min(r.times).Round(time.Microsecond), mean.Round(time.Microsecond),
+			slices.Max(r.times).Round(time.Microsecond)); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func init() {
+	benchCmd.Flags().IntVarP(&benchFlags.tickets, "tickets", "n", 1000, "Number of synthetic tickets to generate")
+	benchCmd.Flags().IntVar(&benchFlags.iterations, "iterations", 5, "Number of timed runs of each operation")
+	benchCmd.Flags().BoolVar(&benchFlags.current, "current", false, "Time the current tickets directory instead of synthetic tickets")
+}
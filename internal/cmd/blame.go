@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// uncommittedSHA is the commit git blame reports for lines not yet committed.
+const uncommittedSHA = "0000000000000000000000000000000000000000"
+
+// blameLine is one line of git blame output.
+type blameLine struct {
+	SHA     string
+	Author  string
+	Time    time.Time
+	Summary string
+	Text    string
+}
+
+// sectionBlame attributes a frontmatter field or body section to the most
+// recent commit that changed one of its lines.
+type sectionBlame struct {
+	Name string
+	Last blameLine
+}
+
+var blameCmd = &cobra.Command{
+	Use:   "blame <id>",
+	Short: "Show who last changed each field and section of a ticket",
+	Long: `Use git blame to attribute each frontmatter field and body section (title,
+description, design, acceptance, each note) to the last commit and author that
+changed it. Uncommitted changes are shown as "Not Committed Yet".`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := resolveID(store, args[0])
+		if err != nil {
+			return err
+		}
+
+		dir := store.TicketsDir()
+		if err := checkGitWorkTree(dir); err != nil {
+			return err
+		}
+
+		blame := exec.Command("git", "blame", "--line-porcelain", "--", id+".md")
+		blame.Dir = dir
+		var stderr bytes.Buffer
+		blame.Stderr = &stderr
+		output, err := blame.Output()
+		if err != nil {
+			return fmt.Errorf("git blame failed for %s (is it committed?): %s", id, strings.TrimSpace(stderr.String()))
+		}
+
+		lines, err := parseBlamePorcelain(output)
+		if err != nil {
+			return err
+		}
+
+		return runWithPager(func(w io.Writer) error {
+			return outputBlame(w, blameSections(lines))
+		})
+	},
+}
+
+// parseBlamePorcelain parses the output of git blame --line-porcelain.
+func parseBlamePorcelain(data []byte) ([]blameLine, error) {
+	var lines []blameLine
+	var cur blameLine
+	header := true
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if header {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("unexpected git blame output: %q", line)
+			}
+			cur = blameLine{SHA: fields[0]}
+			header = false
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			cur.Text = line[1:]
+			lines = append(lines, cur)
+			header = true
+		case key == "author":
+			cur.Author = value
+		case key == "author-time":
+			secs, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid author-time %q in git blame output", value)
+			}
+			cur.Time = time.Unix(secs, 0)
+		case key == "summary":
+			cur.Summary = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git blame output: %w", err)
+	}
+	return lines, nil
+}
+
+// blameSections groups blamed lines of a ticket file into frontmatter fields
+// and body sections, keeping the latest change of each. Blank lines and
+// delimiters are ignored.
+func blameSections(lines []blameLine) []sectionBlame {
+	var sections []sectionBlame
+	index := make(map[string]int)
+	attribute := func(name string, line blameLine) {
+		i, ok := index[name]
+		if !ok {
+			index[name] = len(sections)
+			sections = append(sections, sectionBlame{Name: name, Last: line})
+			return
+		}
+		if line.Time.After(sections[i].Last.Time) {
+			sections[i].Last = line
+		}
+	}
+
+	inFrontmatter := false
+	section, notes := "", 0
+	for i, line := range lines {
+		text := line.Text
+		switch {
+		case i == 0 && text == "---":
+			inFrontmatter = true
+			continue
+		case inFrontmatter && text == "---":
+			inFrontmatter = false
+			section = ""
+			continue
+		case strings.TrimSpace(text) == "":
+			continue
+		}
+
+		if inFrontmatter {
+			// Indented lines continue the previous key's value
+			if key, _, ok := strings.Cut(text, ":"); ok && !strings.HasPrefix(text, " ") && !strings.HasPrefix(text, "-") {
+				section = key
+			}
+			attribute(section, line)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "# "):
+			section = "title"
+		case text == "## Design":
+			section = "design"
+		case text == "## Acceptance Criteria":
+			section = "acceptance"
+		case text == "## Notes":
+			section = "notes"
+		case strings.HasPrefix(text, "### ") && strings.HasPrefix(section, "note"):
+			notes++
+			section = fmt.Sprintf("note #%d", notes)
+		case strings.HasPrefix(text, "## "):
+			section = strings.ToLower(strings.TrimPrefix(text, "## "))
+		case section == "title" || section == "":
+			section = "description"
+		}
+		attribute(section, line)
+	}
+	return sections
+}
+
+// outputBlame prints one line per section: name, commit, author, date and summary.
+func outputBlame(w io.Writer, sections []sectionBlame) error {
+	width := 0
+	for _, s := range sections {
+		width = max(width, len(s.Name))
+	}
+
+	for _, s := range sections {
+		sha, author, date, summary := s.Last.SHA, s.Last.Author, s.Last.Time.In(displayLocation).Format(time.DateOnly), s.Last.Summary
+		if sha == uncommittedSHA {
+			sha, date, summary = "-------", "", ""
+		}
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		line := fmt.Sprintf("%-*s  %s  %s", width, s.Name, sha, author)
+		if date != "" {
+			line += "  " + date
+		}
+		if summary != "" {
+			line += "  " + summary
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
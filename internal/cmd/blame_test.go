@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BlameSuite struct {
+	suite.Suite
+}
+
+func TestBlameSuite(t *testing.T) {
+	suite.Run(t, new(BlameSuite))
+}
+
+func (s *BlameSuite) TestParseBlamePorcelain() {
+	output := "1111111111111111111111111111111111111111 1 1 2\n" +
+		"author Alice\n" +
+		"author-mail <alice@example.com>\n" +
+		"author-time 1700000000\n" +
+		"author-tz +0000\n" +
+		"summary Create ticket\n" +
+		"filename tic-1.md\n" +
+		"\t---\n" +
+		"2222222222222222222222222222222222222222 2 2\n" +
+		"author Bob\n" +
+		"author-time 1700100000\n" +
+		"summary Close it\n" +
+		"filename tic-1.md\n" +
+		"\tstatus: closed\n"
+
+	lines, err := parseBlamePorcelain([]byte(output))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []blameLine{
+		{SHA: "1111111111111111111111111111111111111111", Author: "Alice", Time: time.Unix(1700000000, 0), Summary: "Create ticket", Text: "---"},
+		{SHA: "2222222222222222222222222222222222222222", Author: "Bob", Time: time.Unix(1700100000, 0), Summary: "Close it", Text: "status: closed"},
+	}, lines)
+}
+
+func (s *BlameSuite) TestBlameSections() {
+	old := blameLine{SHA: "old", Author: "Alice", Time: time.Unix(100, 0)}
+	recent := blameLine{SHA: "new", Author: "Bob", Time: time.Unix(200, 0)}
+	at := func(b blameLine, text string) blameLine {
+		b.Text = text
+		return b
+	}
+
+	lines := []blameLine{
+		at(old, "---"),
+		at(old, "id: tic-1"),
+		at(recent, "status: closed"),
+		at(old, "tags:"),
+		at(recent, "  - urgent"),
+		at(old, "---"),
+		at(old, "# Title"),
+		at(old, ""),
+		at(old, "Description text"),
+		at(old, "## Acceptance Criteria"),
+		at(recent, "- [ ] changed"),
+		at(old, "## Notes"),
+		at(old, "### 2025-01-01T00:00:00Z"),
+		at(old, "first"),
+		at(recent, "### 2025-01-02T00:00:00Z"),
+		at(recent, "second"),
+	}
+
+	var got []string
+	for _, section := range blameSections(lines) {
+		got = append(got, section.Name+"="+section.Last.SHA)
+	}
+	require.Equal(s.T(), []string{
+		"id=old", "status=new", "tags=new", "title=old", "description=old",
+		"acceptance=new", "notes=old", "note #1=old", "note #2=new",
+	}, got)
+}
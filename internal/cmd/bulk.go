@@ -2,16 +2,18 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/spf13/cobra"
 
 	"github.com/radutopala/ticket/internal/domain"
 )
 
 var bulkFlags struct {
-	tag      string
-	status   string
-	assignee string
+	tag      []string
+	status   []string
+	assignee []string
 	dryRun   bool
 }
 
@@ -30,14 +32,17 @@ Examples:
   tk bulk close --tag=sprint-1           # Close all tickets with tag sprint-1
   tk bulk start --assignee=alice         # Start all tickets assigned to alice
   tk bulk reopen --status=closed         # Reopen all closed tickets
-  tk bulk close --tag=bug --dry-run      # Preview what would be closed`,
+  tk bulk close --tag=bug --dry-run      # Preview what would be closed
+
+Policies are checked for every matching ticket before any is written, and a
+failed write rolls back the others, so a batch is applied all or nothing.`,
 }
 
 var bulkCloseCmd = &cobra.Command{
 	Use:   "close",
 	Short: "Close multiple tickets",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runBulkAction(domain.StatusClosed, "closed")
+		return runBulkAction(domain.StatusClosed, msgBulkVerbClosed)
 	},
 }
 
@@ -45,7 +50,7 @@ var bulkReopenCmd = &cobra.Command{
 	Use:   "reopen",
 	Short: "Reopen multiple tickets",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runBulkAction(domain.StatusOpen, "reopened")
+		return runBulkAction(domain.StatusOpen, msgBulkVerbReopened)
 	},
 }
 
@@ -53,11 +58,12 @@ var bulkStartCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start multiple tickets",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runBulkAction(domain.StatusInProgress, "started")
+		return runBulkAction(domain.StatusInProgress, msgBulkVerbStarted)
 	},
 }
 
-func runBulkAction(newStatus domain.Status, actionVerb string) error {
+func runBulkAction(newStatus domain.Status, verbMsg *i18n.Message) error {
+	actionVerb := tr(verbMsg, nil)
 	tickets, err := store.List()
 	if err != nil {
 		return err
@@ -74,35 +80,59 @@ func runBulkAction(newStatus domain.Status, actionVerb string) error {
 	filtered := filterTickets(tickets, filterOpts)
 
 	if len(filtered) == 0 {
-		fmt.Println("No tickets match the specified filters")
+		fmt.Println(tr(msgBulkNoMatch, nil))
 		return nil
 	}
 
 	if bulkFlags.dryRun {
-		fmt.Printf("Dry run: would %s %d ticket(s):\n", actionVerb, len(filtered))
+		fmt.Println(tr(msgBulkDryRun, map[string]any{"Verb": actionVerb, "Count": len(filtered)}))
 		for _, t := range filtered {
 			fmt.Printf("  %s [%s] - %s\n", t.ID, t.Status, t.Title)
 		}
 		return nil
 	}
 
-	var updated int
+	// Check every ticket before writing any, so a policy violation leaves
+	// the batch untouched
+	now := time.Now().UTC()
+	var changed []*domain.Ticket
 	for _, t := range filtered {
 		if t.Status == newStatus {
 			continue // Skip tickets already in target status
 		}
-		t.Status = newStatus
-		if err := store.Write(t); err != nil {
-			return fmt.Errorf("failed to update %s: %w", t.ID, err)
+		t.SetStatus(newStatus, now)
+		if err := enforcePolicy(t); err != nil {
+			return trWrap(msgCannotUpdate, map[string]any{"ID": t.ID}, err)
+		}
+		changed = append(changed, t)
+	}
+
+	ids := make([]string, len(changed))
+	for i, t := range changed {
+		ids[i] = t.ID
+	}
+	// A failed write rolls back the tickets already written
+	err = withTicketLocks(ids, func() error {
+		for _, t := range changed {
+			if err := store.Write(t); err != nil {
+				return fmt.Errorf("failed to update %s: %w", t.ID, err)
+			}
 		}
-		updated++
-		fmt.Printf("%s %s\n", actionVerb, t.ID)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	updated := len(changed)
+	for _, t := range changed {
+		fmt.Println(tr(msgBulkUpdated, map[string]any{"Verb": actionVerb, "ID": t.ID}))
 	}
 
 	if updated == 0 {
-		fmt.Printf("No tickets needed updating (all already %s)\n", newStatus)
+		fmt.Println(tr(msgBulkNoneNeeded, map[string]any{"Status": newStatus}))
 	} else {
-		fmt.Printf("Successfully %s %d ticket(s)\n", actionVerb, updated)
+		fmt.Println(tr(msgBulkSucceeded, map[string]any{"Verb": actionVerb, "Count": updated}))
 	}
 
 	return nil
@@ -110,9 +140,9 @@ func runBulkAction(newStatus domain.Status, actionVerb string) error {
 
 func init() {
 	// Add flags to parent bulk command (inherited by subcommands)
-	bulkCmd.PersistentFlags().StringVarP(&bulkFlags.tag, "tag", "T", "", "Filter by tag")
-	bulkCmd.PersistentFlags().StringVar(&bulkFlags.status, "status", "", "Filter by status (open|in_progress|closed)")
-	bulkCmd.PersistentFlags().StringVarP(&bulkFlags.assignee, "assignee", "a", "", "Filter by assignee")
+	bulkCmd.PersistentFlags().StringSliceVarP(&bulkFlags.tag, "tag", "T", nil, "Filter by tag, comma-separated or repeated")
+	bulkCmd.PersistentFlags().StringSliceVar(&bulkFlags.status, "status", nil, "Filter by status, comma-separated or repeated (open|in_progress|closed)")
+	bulkCmd.PersistentFlags().StringSliceVarP(&bulkFlags.assignee, "assignee", "a", nil, "Filter by assignee, comma-separated or repeated")
 	bulkCmd.PersistentFlags().BoolVar(&bulkFlags.dryRun, "dry-run", false, "Preview changes without applying them")
 
 	// Add subcommands
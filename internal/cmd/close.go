@@ -1,17 +1,77 @@
 package cmd
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/radutopala/ticket/internal/domain"
 )
 
+var closeFlags struct {
+	reason  string
+	comment string
+}
+
 var closeCmd = &cobra.Command{
 	Use:   "close <id>",
 	Short: "Set ticket status to closed",
-	Long:  `Set the ticket status to closed. Supports partial ID matching.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Set the ticket status to closed. Supports partial ID matching.
+
+--reason records why the work ended as the ticket's resolution: done, wontfix
+or duplicate. Tickets closed without one count as done. tk stats counts closed
+tickets by resolution, and tk query and tk export include it as Resolution.
+--comment adds a note saying why, e.g. which ticket this one duplicates.
+Reopening a ticket clears its resolution.
+
+Examples:
+  tk close abc1
+  tk close abc1 --reason wontfix --comment "Superseded by the new importer"
+  tk close abc1 --reason duplicate --comment "Same as def2"`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return updateTicketStatus(args[0], domain.StatusClosed)
+		var resolution domain.Resolution
+		if closeFlags.reason != "" {
+			var err error
+			if resolution, err = domain.ParseResolution(closeFlags.reason); err != nil {
+				return trError(msgInvalidReason, map[string]any{"Reason": strconv.Quote(closeFlags.reason), "Valid": strings.Join(resolutionStrings(domain.ValidResolutions), ", ")}, nil)
+			}
+		}
+
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		ticket.SetStatus(domain.StatusClosed, now)
+		if resolution != "" {
+			ticket.Resolution = resolution
+		}
+		if comment := strings.TrimSpace(closeFlags.comment); comment != "" {
+			content := "Closed: " + comment
+			if resolution != "" {
+				content = fmt.Sprintf("Closed as %s: %s", resolution, comment)
+			}
+			ticket.Notes = append(ticket.Notes, domain.Note{Timestamp: now, Content: content})
+		}
+
+		if err := enforcePolicy(ticket); err != nil {
+			return trWrap(msgCannotUpdate, map[string]any{"ID": ticket.ID}, err)
+		}
+		if err := store.Write(ticket); err != nil {
+			return fmt.Errorf("failed to update ticket: %w", err)
+		}
+
+		fmt.Println(tr(msgUpdatedStatus, map[string]any{"ID": ticket.ID, "Status": domain.StatusClosed}))
+		return nil
 	},
 }
+
+func init() {
+	closeCmd.Flags().StringVar(&closeFlags.reason, "reason", "", "Why the ticket is closed (done|wontfix|duplicate)")
+	closeCmd.Flags().StringVar(&closeFlags.comment, "comment", "", "Note explaining the resolution")
+}
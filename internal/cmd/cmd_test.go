@@ -2,14 +2,23 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
 
 	"github.com/radutopala/ticket/internal/domain"
 	"github.com/radutopala/ticket/internal/storage"
@@ -38,32 +47,134 @@ func (s *CmdSuite) SetupTest() {
 	require.NoError(s.T(), store.EnsureDir())
 
 	// Reset all command flags to their default values
-	listFlags.Status = ""
-	listFlags.Assignee = ""
-	listFlags.Tag = ""
+	resetChangedFlags(rootCmd)
+	listFlags.Status = nil
+	listFlags.Assignee = nil
+	listFlags.Tag = nil
+	listFlags.TagMode = TagModeAll
+	listFlags.Type = nil
+	listFlags.NotStatus = nil
+	listFlags.NotAssignee = nil
+	listFlags.NotTag = nil
+	listFlags.Unassigned = false
+	listFlags.Untagged = false
+	listFlags.NeedsReview = false
+	listFlags.CreatedAfter = time.Time{}
+	listFlags.CreatedBefore = time.Time{}
+	listFlags.ClosedAfter = time.Time{}
+	listFlags.ClosedBefore = time.Time{}
+	sortFlags.SortBy = ""
+	sortFlags.Reverse = false
+	lineFormatFlag = ""
+	countFlag = false
+	archiveFlags.archived = false
+	archiveFlags.all = false
 	closedFlags.limit = 20
+	closeFlags.reason = ""
+	closeFlags.comment = ""
 	createFlags.description = ""
 	createFlags.design = ""
 	createFlags.acceptance = ""
 	createFlags.ticketType = ""
 	createFlags.priority = 2
+	createFlags.estimate = 0
+	createFlags.due = ""
 	createFlags.assignee = ""
 	createFlags.externalRef = ""
 	createFlags.parent = ""
 	createFlags.tags = nil
+	ensureFlags.externalRef = ""
+	ensureFlags.title = ""
+	ensureFlags.status = ""
+	ensureFlags.description = ""
+	ensureFlags.design = ""
+	ensureFlags.acceptance = ""
+	ensureFlags.ticketType = ""
+	ensureFlags.due = ""
+	ensureFlags.assignee = ""
+	ensureFlags.parent = ""
+	ensureFlags.priority = 2
+	ensureFlags.estimate = 0
+	ensureFlags.tags = nil
 	exportFlags.format = "json"
 	exportFlags.output = ""
+	exportFlags.splitBy = ""
 	importFlags.skipExisting = false
-	bulkFlags.tag = ""
-	bulkFlags.status = ""
-	bulkFlags.assignee = ""
+	importFlags.merge = false
+	importFlags.prefer = PreferNewest
+	importFlags.strategy = ""
+	importFlags.mapping = ""
+	moveFlags.to = ""
+	noteCompactFlags.keepLast = 5
+	noteCompactFlags.attach = false
+	reportEffortFlags.groupBy = GroupByTag
+	reportEffortFlags.since = time.Time{}
+	reportEffortFlags.until = time.Time{}
+	reportEffortFlags.json = false
+	queryFlags.explain = false
+	benchFlags.tickets = 1000
+	benchFlags.iterations = 5
+	benchFlags.current = false
+	cpuProfileFlag = ""
+	memProfileFlag = ""
+	bulkFlags.tag = nil
+	bulkFlags.status = nil
+	bulkFlags.assignee = nil
 	bulkFlags.dryRun = false
+	undoFlags.show = false
+	forceFlag = false
+	overwriteFlag = false
+	asFlag = ""
+	showDiffFlag = false
+	asciiFlag = false
+	gcFlags.dryRun = false
+	lockFlags.reason = ""
+	showFlags.section = ""
+	editFlags.section = ""
+	pickFlags.roundRobin = false
+	pickFlags.claim = false
+	readyFlags.limit = 0
+	readyFlags.claimable = false
+	startFlags.lease = 0
+	pickFlags.lease = 0
+	triageFlags.snooze = 0
+	depGraphFlags.format = "json"
+	statsFlags.json = false
+	statsFlags.byWeek = false
+	statsFlags.weeks = 12
+	grepFlags.ignoreCase = false
+	unblockedFlags.since = time.Time{}
+	unblockedFlags.sinceLast = false
+	grepFlags.lineNumber = false
+	grepFlags.filesOnly = false
+	forecastFlags.weeks = 12
+	forecastFlags.trials = 10000
+	forecastFlags.seed = 0
+	forecastFlags.json = false
+	mentionsFlags.user = ""
+	watchlistFlags.user = ""
+	watchlistFlags.since = time.Time{}
+	prsFlags.open = false
+	i18nFlags.output = ""
+	i18nFlags.missing = ""
+	activityFlags.since = time.Time{}
+	activityFlags.assignee = nil
+	activityFlags.limit = 50
 
 	s.cleanup = func() {
 		_ = os.RemoveAll(tempDir)
 	}
 }
 
+// resetChangedFlags clears the Changed state pflag keeps between executions
+// on cmd and all its subcommands.
+func resetChangedFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(f *pflag.Flag) { f.Changed = false })
+	for _, c := range cmd.Commands() {
+		resetChangedFlags(c)
+	}
+}
+
 func (s *CmdSuite) TearDownTest() {
 	if s.cleanup != nil {
 		s.cleanup()
@@ -134,6 +245,24 @@ func (s *CmdSuite) TestCloseCommand() {
 	require.Equal(s.T(), domain.StatusClosed, ticket.Status)
 }
 
+func (s *CmdSuite) TestCloseCommandSetsClosedAt() {
+	s.createTestTicket("tic-closedat", domain.StatusOpen, "Ticket to close")
+
+	_, err := s.executeCommand("close", "tic-closedat")
+	require.NoError(s.T(), err)
+
+	ticket, err := store.Read("tic-closedat")
+	require.NoError(s.T(), err)
+	require.False(s.T(), ticket.ClosedAt.IsZero())
+
+	_, err = s.executeCommand("reopen", "tic-closedat")
+	require.NoError(s.T(), err)
+
+	ticket, err = store.Read("tic-closedat")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ticket.ClosedAt.IsZero())
+}
+
 func (s *CmdSuite) TestCloseCommandNotFound() {
 	_, err := s.executeCommand("close", "nonexistent")
 	require.Error(s.T(), err)
@@ -379,6 +508,160 @@ func (s *CmdSuite) TestListWithTagFilter() {
 	require.NotContains(s.T(), output, "tic-tag2")
 }
 
+func (s *CmdSuite) TestListWithLineFormat() {
+	s.createTestTicket("tic-fmt1", domain.StatusOpen, "Formatted ticket")
+
+	output, err := s.executeCommand("list", "--line-format", "{{.ID}}|{{.Title}}")
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "tic-fmt1|Formatted ticket\n", output)
+}
+
+func (s *CmdSuite) TestListWithLineFormatFromConfig() {
+	s.createTestTicket("tic-fmt2", domain.StatusOpen, "Configured ticket")
+	configPath := filepath.Join(s.tempDir, "config.yaml")
+	require.NoError(s.T(), os.WriteFile(configPath, []byte("line_format: \"{{.Title}} ({{.ID}})\"\n"), 0644))
+
+	output, err := s.executeCommand("list")
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Configured ticket (tic-fmt2)\n", output)
+}
+
+func (s *CmdSuite) TestListWithInvalidLineFormat() {
+	_, err := s.executeCommand("list", "--line-format", "{{.Nope}}")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "invalid line format")
+}
+
+func (s *CmdSuite) TestCountFlag() {
+	s.createTestTicket("tic-cnt1", domain.StatusOpen, "Count one")
+	t2 := s.createTestTicket("tic-cnt2", domain.StatusOpen, "Count two")
+	t2.Deps = []string{"tic-cnt1"}
+	require.NoError(s.T(), store.Write(t2))
+	s.createTestTicket("tic-cnt3", domain.StatusClosed, "Count three")
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"list", "--count"}, want: "3\n"},
+		{args: []string{"ready", "--count"}, want: "1\n"},
+		{args: []string{"blocked", "--count"}, want: "1\n"},
+		{args: []string{"closed", "--count"}, want: "1\n"},
+		{args: []string{"search", "count", "--count"}, want: "3\n"},
+		{args: []string{"search", "nothing-matches", "--count"}, want: "0\n"},
+	}
+
+	for _, tt := range tests {
+		output, err := s.executeCommand(tt.args...)
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), tt.want, output, "tk %v", tt.args)
+	}
+}
+
+func (s *CmdSuite) TestListWithMultipleStatuses() {
+	s.createTestTicket("tic-ms1", domain.StatusOpen, "Open ticket")
+	s.createTestTicket("tic-ms2", domain.StatusInProgress, "In progress ticket")
+	s.createTestTicket("tic-ms3", domain.StatusClosed, "Closed ticket")
+
+	output, err := s.executeCommand("list", "--status", "open,in_progress")
+
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-ms1")
+	require.Contains(s.T(), output, "tic-ms2")
+	require.NotContains(s.T(), output, "tic-ms3")
+}
+
+func (s *CmdSuite) TestListWithRepeatedTagFlags() {
+	t1 := s.createTestTicket("tic-rt-tag1", domain.StatusOpen, "Both tags")
+	t1.Tags = []string{"backend", "urgent"}
+	require.NoError(s.T(), store.Write(t1))
+
+	t2 := s.createTestTicket("tic-rt-tag2", domain.StatusOpen, "One tag")
+	t2.Tags = []string{"urgent"}
+	require.NoError(s.T(), store.Write(t2))
+
+	output, err := s.executeCommand("list", "--tag", "backend", "--tag", "urgent")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-rt-tag1")
+	require.NotContains(s.T(), output, "tic-rt-tag2")
+
+	listFlags.Tag = nil
+	output, err = s.executeCommand("list", "--tag", "backend", "--tag", "urgent", "--tag-mode", "any")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-rt-tag1")
+	require.Contains(s.T(), output, "tic-rt-tag2")
+}
+
+func (s *CmdSuite) TestListWithNegationFilters() {
+	t1 := s.createTestTicket("tic-neg1", domain.StatusOpen, "Icebox ticket")
+	t1.Tags = []string{"icebox"}
+	t1.Assignee = "alice"
+	require.NoError(s.T(), store.Write(t1))
+
+	t2 := s.createTestTicket("tic-neg2", domain.StatusOpen, "Active ticket")
+	t2.Assignee = "bob"
+	require.NoError(s.T(), store.Write(t2))
+
+	s.createTestTicket("tic-neg3", domain.StatusClosed, "Closed ticket")
+
+	output, err := s.executeCommand("list", "--not-status", "closed", "--not-tag", "icebox")
+	require.NoError(s.T(), err)
+	require.NotContains(s.T(), output, "tic-neg1")
+	require.Contains(s.T(), output, "tic-neg2")
+	require.NotContains(s.T(), output, "tic-neg3")
+
+	listFlags.NotStatus = nil
+	listFlags.NotTag = nil
+	output, err = s.executeCommand("list", "--not-assignee", "bob")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-neg1")
+	require.NotContains(s.T(), output, "tic-neg2")
+	require.Contains(s.T(), output, "tic-neg3")
+
+	listFlags.NotAssignee = nil
+	output, err = s.executeCommand("list", "--no-assignee")
+	require.NoError(s.T(), err)
+	require.NotContains(s.T(), output, "tic-neg1")
+	require.NotContains(s.T(), output, "tic-neg2")
+	require.Contains(s.T(), output, "tic-neg3")
+}
+
+func (s *CmdSuite) TestReadyWithTriageFilters() {
+	t1 := s.createTestTicket("tic-tri1", domain.StatusOpen, "Well-formed")
+	t1.Assignee = "alice"
+	t1.Tags = []string{"backend"}
+	require.NoError(s.T(), store.Write(t1))
+
+	t2 := s.createTestTicket("tic-tri2", domain.StatusOpen, "No tags")
+	t2.Assignee = "bob"
+	require.NoError(s.T(), store.Write(t2))
+
+	t3 := s.createTestTicket("tic-tri3", domain.StatusOpen, "No assignee")
+	t3.Tags = []string{"frontend"}
+	require.NoError(s.T(), store.Write(t3))
+
+	output, err := s.executeCommand("ready", "--untagged")
+	require.NoError(s.T(), err)
+	require.NotContains(s.T(), output, "tic-tri1")
+	require.Contains(s.T(), output, "tic-tri2")
+	require.NotContains(s.T(), output, "tic-tri3")
+
+	listFlags.Untagged = false
+	output, err = s.executeCommand("ready", "--unassigned")
+	require.NoError(s.T(), err)
+	require.NotContains(s.T(), output, "tic-tri1")
+	require.NotContains(s.T(), output, "tic-tri2")
+	require.Contains(s.T(), output, "tic-tri3")
+}
+
+func (s *CmdSuite) TestListWithInvalidTagMode() {
+	_, err := s.executeCommand("list", "--tag-mode", "some")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "invalid tag mode")
+}
+
 func (s *CmdSuite) TestReadyWithAssigneeFilter() {
 	t1 := s.createTestTicket("tic-r-asn1", domain.StatusOpen, "Alice ready")
 	t1.Assignee = "alice"
@@ -498,6 +781,61 @@ func (s *CmdSuite) TestClosedWithLimit() {
 	require.Len(s.T(), lines, 2)
 }
 
+func (s *CmdSuite) TestClosedWithClosedAfter() {
+	recent := s.createTestTicket("tic-cl-recent", domain.StatusClosed, "Recently closed")
+	recent.ClosedAt = time.Now().UTC().Add(-24 * time.Hour)
+	require.NoError(s.T(), store.Write(recent))
+
+	old := s.createTestTicket("tic-cl-old", domain.StatusClosed, "Closed long ago")
+	old.ClosedAt = time.Now().UTC().Add(-30 * 24 * time.Hour)
+	require.NoError(s.T(), store.Write(old))
+
+	output, err := s.executeCommand("closed", "--closed-after", "1w")
+
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-cl-recent")
+	require.NotContains(s.T(), output, "tic-cl-old")
+}
+
+func (s *CmdSuite) TestClosedWithSince() {
+	for i := range 25 {
+		t := s.createTestTicket(fmt.Sprintf("tic-since%02d", i), domain.StatusClosed, "Closed this week")
+		t.ClosedAt = time.Now().UTC().Add(-time.Duration(i+1) * time.Hour)
+		require.NoError(s.T(), store.Write(t))
+	}
+	old := s.createTestTicket("tic-since-old", domain.StatusClosed, "Closed last month")
+	old.ClosedAt = time.Now().UTC().Add(-30 * 24 * time.Hour)
+	require.NoError(s.T(), store.Write(old))
+
+	output, err := s.executeCommand("closed", "--since", "7d")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 25, strings.Count(output, "\n"), "--since lifts the default limit")
+	require.NotContains(s.T(), output, "tic-since-old")
+
+	listFlags.ClosedAfter = time.Time{}
+	output, err = s.executeCommand("closed", "--since", "7d", "--limit", "5")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 5, strings.Count(output, "\n"))
+}
+
+func (s *CmdSuite) TestListWithCreatedBefore() {
+	old := s.createTestTicket("tic-cr-old", domain.StatusOpen, "Old ticket")
+	old.Created = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(s.T(), store.Write(old))
+	s.createTestTicket("tic-cr-new", domain.StatusOpen, "New ticket")
+
+	output, err := s.executeCommand("list", "--created-before", "2025-06-01")
+
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-cr-old")
+	require.NotContains(s.T(), output, "tic-cr-new")
+}
+
+func (s *CmdSuite) TestListWithInvalidDateFilter() {
+	_, err := s.executeCommand("list", "--created-after", "someday")
+	require.Error(s.T(), err)
+}
+
 func (s *CmdSuite) TestReadyExcludesClosedTickets() {
 	s.createTestTicket("tic-ready-excl1", domain.StatusOpen, "Open ticket")
 	s.createTestTicket("tic-ready-excl2", domain.StatusClosed, "Closed ticket")
@@ -601,6 +939,31 @@ func (s *CmdSuite) TestDepAddCommand() {
 	require.Contains(s.T(), ticket.Deps, "tic-dep-a")
 }
 
+func (s *CmdSuite) TestDepAddClosedDependency() {
+	s.createTestTicket("tic-dep-done", domain.StatusClosed, "Finished work")
+	s.createTestTicket("tic-dep-next", domain.StatusOpen, "Next work")
+
+	_, err := s.executeCommand("dep", "add", "tic-dep-next", "tic-dep-done")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "is closed")
+
+	ticket, err := store.Read("tic-dep-next")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), ticket.Deps)
+
+	_, err = s.executeCommand("dep", "add", "tic-dep-next", "tic-dep-done", "--force")
+	require.NoError(s.T(), err)
+
+	ticket, err = store.Read("tic-dep-next")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []string{"tic-dep-done"}, ticket.Deps)
+
+	forceFlag = false
+	output, err := s.executeCommand("lint")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), output, "tic-dep-next: depends on closed ticket tic-dep-done")
+}
+
 func (s *CmdSuite) TestDepAddCommandNotFound() {
 	s.createTestTicket("tic-dep-exists", domain.StatusOpen, "Existing ticket")
 
@@ -692,6 +1055,64 @@ func (s *CmdSuite) TestQueryCommand() {
 	require.Contains(s.T(), output, "tic-query2")
 }
 
+// writeArchivedTicket writes a closed ticket into the archive directory.
+func (s *CmdSuite) writeArchivedTicket(id, title string) {
+	archive := store.Archive()
+	require.NoError(s.T(), archive.EnsureDir())
+	require.NoError(s.T(), archive.Write(&domain.Ticket{
+		ID:          id,
+		Status:      domain.StatusClosed,
+		Type:        domain.TypeTask,
+		Title:       title,
+		Description: "Historical outage notes",
+		Created:     time.Now().UTC(),
+	}))
+}
+
+func (s *CmdSuite) TestQueryWithArchiveFlags() {
+	s.createTestTicket("tic-live", domain.StatusOpen, "Live ticket")
+	s.writeArchivedTicket("tic-old", "Archived ticket")
+
+	output, err := s.executeCommand("query")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-live")
+	require.NotContains(s.T(), output, "tic-old")
+
+	output, err = s.executeCommand("query", "--archived")
+	require.NoError(s.T(), err)
+	require.NotContains(s.T(), output, "tic-live")
+	require.Contains(s.T(), output, "tic-old")
+
+	archiveFlags.archived = false
+	output, err = s.executeCommand("query", "--all")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-live")
+	require.Contains(s.T(), output, "tic-old")
+}
+
+func (s *CmdSuite) TestSearchWithAllIncludesArchive() {
+	s.writeArchivedTicket("tic-old", "Archived ticket")
+
+	output, err := s.executeCommand("search", "outage")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), output)
+
+	output, err = s.executeCommand("search", "outage", "--all")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-old")
+}
+
+func (s *CmdSuite) TestShowArchivedTicket() {
+	s.writeArchivedTicket("tic-old", "Archived ticket")
+
+	_, err := s.executeCommand("show", "old")
+	require.Error(s.T(), err)
+
+	output, err := s.executeCommand("show", "old", "--all")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Archived ticket")
+}
+
 func (s *CmdSuite) TestHelpOutput() {
 	// Test that running root command with no args produces expected help output
 	output, err := s.executeCommand()
@@ -933,6 +1354,100 @@ func (s *CmdSuite) TestAddNoteCommandMultipleNotes() {
 	require.Contains(s.T(), ticket.Notes[1].Content, "Second note")
 }
 
+func (s *CmdSuite) TestReplyCommand() {
+	s.createTestTicket("tic-reply1", domain.StatusOpen, "Threaded Ticket")
+
+	_, err := s.executeCommand("add-note", "tic-reply1", "Question")
+	require.NoError(s.T(), err)
+	_, err = s.executeCommand("add-note", "tic-reply1", "Unrelated")
+	require.NoError(s.T(), err)
+
+	output, err := s.executeCommand("reply", "tic-reply1", "1", "Answer")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Added reply to note #1 on tic-reply1")
+
+	ticket, err := store.Read("tic-reply1")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), ticket.Notes, 3)
+	require.Equal(s.T(), 1, ticket.Notes[2].ReplyTo)
+
+	output, err = s.executeCommand("show", "tic-reply1")
+	require.NoError(s.T(), err)
+	question := strings.Index(output, "### #1 ")
+	answer := strings.Index(output, "  ### #3 ")
+	unrelated := strings.Index(output, "### #2 ")
+	require.True(s.T(), question >= 0 && answer > question && unrelated > answer, output)
+	require.Contains(s.T(), output, "re: #1\n\n  Answer")
+}
+
+func (s *CmdSuite) TestReplyInvalidNoteNumber() {
+	s.createTestTicket("tic-reply2", domain.StatusOpen, "No Notes")
+
+	_, err := s.executeCommand("reply", "tic-reply2", "1", "Answer")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "invalid note number")
+}
+
+func (s *CmdSuite) TestNoteCompact() {
+	s.createTestTicket("tic-compact", domain.StatusOpen, "Chatty Ticket")
+	ticket, err := store.Read("tic-compact")
+	require.NoError(s.T(), err)
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		ticket.Notes = append(ticket.Notes, domain.Note{
+			Timestamp: start.Add(time.Duration(i) * time.Hour),
+			Content:   fmt.Sprintf("Progress %d\nDetails", i+1),
+		})
+	}
+	ticket.Notes[3].ReplyTo = 1
+	ticket.Notes[4].ReplyTo = 4
+	require.NoError(s.T(), store.Write(ticket))
+
+	output, err := s.executeCommand("note", "compact", "tic-compact", "--keep-last", "2", "--attach")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Compacted 3 notes on tic-compact into 1")
+
+	ticket, err = store.Read("tic-compact")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), ticket.Notes, 3)
+	summary := ticket.Notes[0].Content
+	require.Contains(s.T(), summary, "Compacted 3 notes from 2026-01-01T10:00:00Z to 2026-01-01T12:00:00Z:")
+	require.Contains(s.T(), summary, "- 2026-01-01T11:00:00Z: Progress 2\n")
+	require.NotContains(s.T(), summary, "Details")
+	require.Equal(s.T(), start.Add(2*time.Hour), ticket.Notes[0].Timestamp)
+	require.Equal(s.T(), 1, ticket.Notes[1].ReplyTo)
+	require.Equal(s.T(), 2, ticket.Notes[2].ReplyTo)
+
+	attachment := filepath.Join("attachments", "tic-compact", "notes-20260101T120000Z.md")
+	require.Contains(s.T(), summary, "Full text: attachments/tic-compact/notes-20260101T120000Z.md")
+	data, err := os.ReadFile(filepath.Join(s.tempDir, attachment))
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), string(data), "# Notes compacted from tic-compact")
+	require.Contains(s.T(), string(data), "Progress 3\nDetails")
+
+	output, err = s.executeCommand("note", "compact", "tic-compact", "--keep-last", "2")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Nothing to compact on tic-compact")
+}
+
+func (s *CmdSuite) TestNoteCompactAttachFailedWrite() {
+	s.createTestTicket("tic-compactlock", domain.StatusOpen, "Locked Ticket")
+	ticket, err := store.Read("tic-compactlock")
+	require.NoError(s.T(), err)
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		ticket.Notes = append(ticket.Notes, domain.Note{Timestamp: start.Add(time.Duration(i) * time.Hour), Content: "Progress"})
+	}
+	require.NoError(s.T(), store.Write(ticket))
+	_, err = s.executeCommand("lock", "tic-compactlock")
+	require.NoError(s.T(), err)
+
+	_, err = s.executeCommand("note", "compact", "tic-compactlock", "--keep-last", "0", "--attach")
+	require.ErrorIs(s.T(), err, storage.ErrLocked)
+	_, err = os.Stat(filepath.Join(s.tempDir, "attachments", "tic-compactlock", "notes-20260101T120000Z.md"))
+	require.True(s.T(), os.IsNotExist(err))
+}
+
 func (s *CmdSuite) TestQueryWithJqFilter() {
 	s.createTestTicket("tic-jq1", domain.StatusOpen, "JQ Test 1")
 	s.createTestTicket("tic-jq2", domain.StatusClosed, "JQ Test 2")
@@ -944,6 +1459,36 @@ func (s *CmdSuite) TestQueryWithJqFilter() {
 	require.NotContains(s.T(), output, "tic-jq2")
 }
 
+func (s *CmdSuite) TestQueryReportsMalformedFilter() {
+	s.createTestTicket("tic-jqbad", domain.StatusOpen, "Bad filter")
+
+	_, err := s.executeCommand("query", `.[] | select(.Status == "open"`)
+	require.ErrorContains(s.T(), err, "invalid jq filter: unclosed '(' at line 1, column 13")
+
+	_, err = s.executeCommand("query", ".[] | .ID +")
+	require.Error(s.T(), err)
+	require.True(s.T(), strings.HasPrefix(err.Error(), "invalid jq filter: syntax error"), err.Error())
+	require.NotContains(s.T(), err.Error(), "compile error")
+}
+
+func (s *CmdSuite) TestQueryExplain() {
+	s.createTestTicket("tic-exp1", domain.StatusOpen, "Explain 1")
+	s.createTestTicket("tic-exp2", domain.StatusClosed, "Explain 2")
+
+	output, err := s.executeCommand("query", "--explain", `.[] | select(.Status == "open") | .ID`)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), `Loaded:   2 tickets
+Filtered: 2 tickets after date filters
+Stages:
+  1. .[]                        2 results
+  2. select(.Status == "open")  1 result
+  3. .ID                        1 result
+`, output)
+
+	_, err = s.executeCommand("query", "--explain")
+	require.ErrorContains(s.T(), err, "--explain needs a jq filter")
+}
+
 func (s *CmdSuite) TestQueryWithLengthFilter() {
 	s.createTestTicket("tic-jqlen1", domain.StatusOpen, "Length Test 1")
 	s.createTestTicket("tic-jqlen2", domain.StatusOpen, "Length Test 2")
@@ -1041,6 +1586,34 @@ func (s *CmdSuite) TestExportCommandToFile() {
 	require.Contains(s.T(), string(data), "File Export Test")
 }
 
+func (s *CmdSuite) TestExportImportRoundTripIsLossless() {
+	s.T().Setenv("TK_USER", "Backup User")
+	ticket := s.createTestTicket("tic-backup", domain.StatusOpen, "Backed up")
+	ticket.Description = "Intro."
+	ticket.Estimate = 2.5
+	ticket.Sections = []domain.Section{{Name: "Test Plan", Content: "1. run\n2. check"}}
+	ticket.Notes = []domain.Note{{Timestamp: time.Date(2026, 1, 31, 11, 0, 0, 0, time.UTC), Content: "first"}}
+	ticket.Reviews = []domain.Review{{Reviewer: "jane", Decision: domain.ReviewApproved, At: time.Now().UTC()}}
+	require.NoError(s.T(), store.Write(ticket))
+	_, err := s.executeCommand("lock", "tic-backup", "--reason", "signed off")
+	require.NoError(s.T(), err)
+
+	path := filepath.Join(s.tempDir, "tic-backup.md")
+	original, err := os.ReadFile(path)
+	require.NoError(s.T(), err)
+
+	backup := filepath.Join(s.T().TempDir(), "backup.json")
+	_, err = s.executeCommand("export", "--output="+backup)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), os.Remove(path))
+
+	_, err = s.executeCommand("import", backup)
+	require.NoError(s.T(), err)
+	restored, err := os.ReadFile(path)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), string(original), string(restored))
+}
+
 func (s *CmdSuite) TestExportCommandInvalidFormat() {
 	_, err := s.executeCommand("export", "--format=xml")
 
@@ -1174,6 +1747,111 @@ func (s *CmdSuite) TestImportCommandInvalidJSON() {
 	require.Contains(s.T(), err.Error(), "failed to parse JSON")
 }
 
+func (s *CmdSuite) TestImportMerge() {
+	local := s.createTestTicket("tic-merge", domain.StatusOpen, "Local title")
+	local.Design = "Local design"
+	require.NoError(s.T(), store.Write(local))
+	local, err := store.Read("tic-merge")
+	require.NoError(s.T(), err)
+
+	older := local.UpdatedAt.Add(-time.Hour).Format(time.RFC3339Nano)
+	newer := local.UpdatedAt.Add(time.Hour).Format(time.RFC3339Nano)
+	importFile := filepath.Join(s.T().TempDir(), "upstream.json")
+	write := func(updatedAt string) {
+		data := fmt.Sprintf(`[{"ID": "tic-merge", "Title": "Remote title", "Status": "closed", "Priority": 0,
+			"Acceptance": "Remote acceptance", "UpdatedAt": %q}]`, updatedAt)
+		require.NoError(s.T(), os.WriteFile(importFile, []byte(data), 0644))
+	}
+
+	// An older remote only fills fields that are empty locally
+	write(older)
+	output, err := s.executeCommand("import", importFile, "--merge")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "merged 1 existing")
+	ticket, err := store.Read("tic-merge")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Local title", ticket.Title)
+	require.Equal(s.T(), domain.StatusOpen, ticket.Status)
+	require.Equal(s.T(), "Remote acceptance", ticket.Acceptance)
+	require.Equal(s.T(), "Local design", ticket.Design)
+
+	// A newer remote wins every field it gives, but absent fields are kept
+	write(newer)
+	_, err = s.executeCommand("import", importFile, "--merge")
+	require.NoError(s.T(), err)
+	ticket, err = store.Read("tic-merge")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Remote title", ticket.Title)
+	require.Equal(s.T(), domain.StatusClosed, ticket.Status)
+	require.False(s.T(), ticket.ClosedAt.IsZero())
+	require.Equal(s.T(), 0, ticket.Priority)
+	require.Equal(s.T(), "Local design", ticket.Design)
+
+	// Nothing left to change
+	output, err = s.executeCommand("import", importFile, "--merge", "--prefer", "remote")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "skipped 1 existing")
+
+	_, err = s.executeCommand("import", importFile, "--merge", "--prefer", "mine")
+	require.ErrorContains(s.T(), err, `invalid --prefer "mine"`)
+}
+
+func (s *CmdSuite) TestEditFieldValueValidates() {
+	s.createTestTicket("tic-edita", domain.StatusOpen, "A")
+	s.createTestTicket("tic-editb", domain.StatusOpen, "B")
+	editor := filepath.Join(s.T().TempDir(), "editor.sh")
+	s.T().Setenv("EDITOR", editor)
+	edit := func(field string, value any, text string) (reflect.Value, error) {
+		script := "#!/bin/sh\ncat > \"$1\" <<'EOF'\n" + text + "\nEOF\n"
+		require.NoError(s.T(), os.WriteFile(editor, []byte(script), 0755))
+		return editFieldValue("tic-edita", field, reflect.ValueOf(value))
+	}
+
+	_, err := edit("Priority", 2, "9")
+	require.ErrorContains(s.T(), err, "invalid priority 9")
+	_, err = edit("Estimate", 1.0, "-3")
+	require.ErrorContains(s.T(), err, "invalid estimate -3")
+	_, err = edit("Deps", []string{}, `["tic-nope"]`)
+	require.ErrorContains(s.T(), err, "invalid dependency")
+	_, err = edit("Deps", []string{}, `["tic-edita"]`)
+	require.ErrorContains(s.T(), err, "cannot depend on itself")
+	_, err = edit("Resolution", "", "maybe")
+	require.Error(s.T(), err)
+
+	v, err := edit("Deps", []string{}, `["tic-editb"]`)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []string{"tic-editb"}, v.Interface())
+	v, err = edit("Priority", 2, "0")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 0, v.Interface())
+}
+
+func (s *CmdSuite) TestImportMergeStrategy() {
+	s.createTestTicket("tic-strategy", domain.StatusOpen, "Local title")
+	importFile := filepath.Join(s.T().TempDir(), "upstream.json")
+	data := `[{"ID": "tic-strategy", "Title": "Remote title", "UpdatedAt": "2000-01-01T00:00:00Z"}]`
+	require.NoError(s.T(), os.WriteFile(importFile, []byte(data), 0644))
+
+	output, err := s.executeCommand("import", importFile, "--merge", "--strategy", "ours")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "skipped 1 existing")
+
+	// Theirs wins even though the remote is older
+	output, err = s.executeCommand("import", importFile, "--merge", "--strategy", "theirs")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "merged 1 existing")
+	ticket, err := store.Read("tic-strategy")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Remote title", ticket.Title)
+
+	_, err = s.executeCommand("import", importFile, "--merge", "--strategy", "mine")
+	require.ErrorContains(s.T(), err, `invalid --strategy "mine"`)
+
+	importFlags.merge = false
+	_, err = s.executeCommand("import", importFile, "--strategy", "theirs")
+	require.ErrorContains(s.T(), err, "--strategy requires --merge")
+}
+
 func (s *CmdSuite) TestImportCommandFileNotFound() {
 	_, err := s.executeCommand("import", "/nonexistent/file.json")
 
@@ -1260,6 +1938,28 @@ func (s *CmdSuite) TestBulkCloseByTag() {
 	require.Equal(s.T(), domain.StatusOpen, ticket3.Status)
 }
 
+func (s *CmdSuite) TestBulkCloseAllOrNothing() {
+	policy := "policies:\n  bug:\n    required: [acceptance]\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte(policy), 0644))
+
+	task := s.createTestTicket("tic-bulkpol-a", domain.StatusOpen, "Fine")
+	task.Tags = []string{"batch"}
+	require.NoError(s.T(), store.Write(task))
+	bug := s.createTestTicket("tic-bulkpol-b", domain.StatusOpen, "Missing acceptance")
+	bug.Type = domain.TypeBug
+	bug.Tags = []string{"batch"}
+	require.NoError(s.T(), store.Write(bug))
+
+	_, err := s.executeCommand("bulk", "close", "--tag=batch")
+	require.ErrorContains(s.T(), err, "cannot update tic-bulkpol-b")
+
+	for _, id := range []string{"tic-bulkpol-a", "tic-bulkpol-b"} {
+		ticket, err := store.Read(id)
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), domain.StatusOpen, ticket.Status, id)
+	}
+}
+
 func (s *CmdSuite) TestBulkStartByAssignee() {
 	t1 := s.createTestTicket("tic-bulkstart1", domain.StatusOpen, "Start Test 1")
 	t1.Assignee = "alice"
@@ -1382,3 +2082,1669 @@ func (s *CmdSuite) TestBulkMultipleFilters() {
 	ticket3, _ := store.Read("tic-bulkmulti3")
 	require.Equal(s.T(), domain.StatusOpen, ticket3.Status)
 }
+
+func (s *CmdSuite) TestUndoRevertsLastChange() {
+	s.createTestTicket("tic-undo1", domain.StatusOpen, "Undo me")
+
+	_, err := s.executeCommand("close", "tic-undo1")
+	require.NoError(s.T(), err)
+
+	output, err := s.executeCommand("undo", "--show")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-undo1 status")
+	require.Contains(s.T(), output, "status: open -> closed")
+
+	ticket, err := store.Read("tic-undo1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.StatusClosed, ticket.Status, "--show must not change anything")
+
+	undoFlags.show = false
+	output, err = s.executeCommand("undo")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Reverted")
+
+	ticket, err = store.Read("tic-undo1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.StatusOpen, ticket.Status)
+	require.True(s.T(), ticket.ClosedAt.IsZero())
+
+	output, err = s.executeCommand("undo")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Nothing to undo")
+}
+
+func (s *CmdSuite) TestUndoBulkClose() {
+	s.createTestTicket("tic-bulk1", domain.StatusOpen, "One")
+	s.createTestTicket("tic-bulk2", domain.StatusOpen, "Two")
+
+	_, err := s.executeCommand("bulk", "close", "--status", "open")
+	require.NoError(s.T(), err)
+
+	_, err = s.executeCommand("undo", "2")
+	require.NoError(s.T(), err)
+
+	for _, id := range []string{"tic-bulk1", "tic-bulk2"} {
+		ticket, err := store.Read(id)
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), domain.StatusOpen, ticket.Status)
+	}
+}
+
+func (s *CmdSuite) TestUndoInvalidCount() {
+	_, err := s.executeCommand("undo", "0")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "invalid count")
+}
+
+func (s *CmdSuite) TestMentionsCommand() {
+	mentioned := s.createTestTicket("tic-men1", domain.StatusOpen, "Needs review")
+	mentioned.Description = "Can @alice take a look?"
+	require.NoError(s.T(), store.Write(mentioned))
+
+	noted := s.createTestTicket("tic-men2", domain.StatusInProgress, "Flaky test")
+	noted.Notes = []domain.Note{{Timestamp: time.Now().UTC(), Content: "cc @Alice.Smith"}}
+	require.NoError(s.T(), store.Write(noted))
+
+	assigned := s.createTestTicket("tic-men3", domain.StatusOpen, "Already hers")
+	assigned.Assignee = "Alice Smith"
+	assigned.Description = "@alice FYI"
+	require.NoError(s.T(), store.Write(assigned))
+
+	closed := s.createTestTicket("tic-men4", domain.StatusClosed, "Done")
+	closed.Description = "@alice thanks"
+	require.NoError(s.T(), store.Write(closed))
+
+	email := s.createTestTicket("tic-men5", domain.StatusOpen, "Email")
+	email.Description = "mail bob@alice.com"
+	require.NoError(s.T(), store.Write(email))
+
+	output, err := s.executeCommand("mentions", "--user", "Alice Smith")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-men1")
+	require.Contains(s.T(), output, "tic-men2")
+	require.NotContains(s.T(), output, "tic-men3")
+	require.NotContains(s.T(), output, "tic-men4")
+	require.NotContains(s.T(), output, "tic-men5")
+}
+
+func (s *CmdSuite) TestMineCommand() {
+	s.T().Setenv("TK_USER", "Test User")
+
+	mine := s.createTestTicket("tic-mine1", domain.StatusOpen, "Mine")
+	mine.Assignee = "Test User"
+	require.NoError(s.T(), store.Write(mine))
+
+	closed := s.createTestTicket("tic-mine2", domain.StatusClosed, "Mine but done")
+	closed.Assignee = "Test User"
+	require.NoError(s.T(), store.Write(closed))
+
+	other := s.createTestTicket("tic-mine3", domain.StatusOpen, "Not mine")
+	other.Assignee = "Someone Else"
+	require.NoError(s.T(), store.Write(other))
+
+	output, err := s.executeCommand("mine")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-mine1")
+	require.NotContains(s.T(), output, "tic-mine2")
+	require.NotContains(s.T(), output, "tic-mine3")
+
+	_, err = s.executeCommand("mine", "--assignee", "Someone Else")
+	require.ErrorContains(s.T(), err, "--assignee does not apply to tk mine")
+}
+
+func (s *CmdSuite) TestAssigneeMePlaceholder() {
+	s.T().Setenv("TK_USER", "Test User")
+
+	mine := s.createTestTicket("tic-me1", domain.StatusOpen, "Mine")
+	mine.Assignee = "Test User"
+	require.NoError(s.T(), store.Write(mine))
+	s.createTestTicket("tic-me2", domain.StatusOpen, "Unassigned")
+
+	output, err := s.executeCommand("list", "--assignee", "@me")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-me1")
+	require.NotContains(s.T(), output, "tic-me2")
+
+	output, err = s.executeCommand("create", "Created for me", "--assignee", "@me")
+	require.NoError(s.T(), err)
+	ticket, err := store.Read(strings.TrimSpace(output))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Test User", ticket.Assignee)
+}
+
+func (s *CmdSuite) TestTKUserUsedAsActor() {
+	s.T().Setenv("TK_USER", "Journal User")
+	s.createTestTicket("tic-actor1", domain.StatusOpen, "Actor")
+
+	_, err := s.executeCommand("close", "tic-actor1")
+	require.NoError(s.T(), err)
+
+	events, err := store.ReadJournal()
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Journal User", events[len(events)-1].Actor)
+}
+
+func (s *CmdSuite) TestActivityCommand() {
+	s.T().Setenv("TK_USER", "Feed User")
+
+	_, err := s.executeCommand("create", "Feed ticket", "--assignee", "Someone")
+	require.NoError(s.T(), err)
+	tickets, err := store.List()
+	require.NoError(s.T(), err)
+	id := tickets[0].ID
+
+	_, err = s.executeCommand("start", id)
+	require.NoError(s.T(), err)
+	_, err = s.executeCommand("add-note", id, "progress")
+	require.NoError(s.T(), err)
+	_, err = s.executeCommand("close", id)
+	require.NoError(s.T(), err)
+
+	output, err := s.executeCommand("activity", "--since", "1h")
+	require.NoError(s.T(), err)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(s.T(), lines, 4)
+	for i, verb := range []string{"closed", "noted", "started", "created"} {
+		require.Contains(s.T(), lines[i], verb)
+		require.Contains(s.T(), lines[i], id+"  Feed ticket  (Feed User)")
+	}
+
+	output, err = s.executeCommand("activity", "--assignee", "@me")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), strings.TrimSpace(output))
+}
+
+func (s *CmdSuite) TestStatusChangeRecordsActor() {
+	s.T().Setenv("TK_USER", "Env User")
+	s.createTestTicket("tic-who1", domain.StatusOpen, "Who closed this")
+
+	_, err := s.executeCommand("start", "tic-who1")
+	require.NoError(s.T(), err)
+	_, err = s.executeCommand("close", "tic-who1", "--as", "agent-7")
+	require.NoError(s.T(), err)
+
+	ticket, err := store.Read("tic-who1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "agent-7", ticket.LastUpdatedBy)
+
+	asFlag = ""
+	output, err := s.executeCommand("show", "tic-who1")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "last-updated-by: agent-7")
+
+	output, err = s.executeCommand("history", "who1")
+	require.NoError(s.T(), err)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(s.T(), lines, 3)
+	require.Contains(s.T(), lines[1], "started     by Env User  (status: open -> in_progress)")
+	require.Contains(s.T(), lines[2], "closed      by agent-7")
+}
+
+func (s *CmdSuite) TestForecastCommand() {
+	for i, ago := range []time.Duration{24 * time.Hour, 8 * 24 * time.Hour} {
+		t := s.createTestTicket(fmt.Sprintf("tic-done%d", i), domain.StatusClosed, "Done")
+		t.ClosedAt = time.Now().UTC().Add(-ago)
+		require.NoError(s.T(), store.Write(t))
+	}
+	release := s.createTestTicket("tic-rel1", domain.StatusOpen, "Release work")
+	release.Tags = []string{"release-2"}
+	require.NoError(s.T(), store.Write(release))
+	s.createTestTicket("tic-other", domain.StatusOpen, "Other work")
+
+	output, err := s.executeCommand("forecast", "--tag", "release-2", "--weeks", "2", "--seed", "7")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Remaining:  1 tickets")
+	require.Contains(s.T(), output, "Throughput: 1.0/week over the last 2 weeks")
+	require.Contains(s.T(), output, "P85:")
+}
+
+func (s *CmdSuite) TestStartEnforcesWIPLimit() {
+	s.T().Setenv("TK_USER", "Busy User")
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte("wip_limit: 1\n"), 0644))
+
+	busy := s.createTestTicket("tic-wip1", domain.StatusInProgress, "Already working")
+	busy.Assignee = "Busy User"
+	require.NoError(s.T(), store.Write(busy))
+	s.createTestTicket("tic-wip2", domain.StatusOpen, "Next")
+
+	_, err := s.executeCommand("start", "tic-wip2")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "WIP limit reached: Busy User already has 1 in_progress ticket(s) (limit 1)")
+
+	ticket, err := store.Read("tic-wip2")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.StatusOpen, ticket.Status)
+
+	_, err = s.executeCommand("start", "tic-wip2", "--force")
+	require.NoError(s.T(), err)
+
+	ticket, err = store.Read("tic-wip2")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.StatusInProgress, ticket.Status)
+}
+
+func (s *CmdSuite) TestSchemaCommand() {
+	output, err := s.executeCommand("schema")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, `"$schema": "https://json-schema.org/draft/2020-12/schema"`)
+	require.Contains(s.T(), output, `"title": "tk ticket"`)
+
+	output, err = s.executeCommand("schema", "frontmatter")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, `"external-ref"`)
+}
+
+func (s *CmdSuite) TestRequiredFieldPolicies() {
+	policy := "policies:\n  bug:\n    required: [acceptance]\n    max_priority: 2\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte(policy), 0644))
+
+	_, err := s.executeCommand("create", "Crash on save", "-t", "bug", "-p", "1")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "cannot create ticket: bug tickets require acceptance criteria")
+
+	output, err := s.executeCommand("create", "Crash on save", "-t", "bug", "-p", "1", "--acceptance", "- [ ] no crash")
+	require.NoError(s.T(), err)
+	id := strings.TrimSpace(output)
+
+	legacy := s.createTestTicket("tic-legacy", domain.StatusOpen, "Old bug")
+	legacy.Type = domain.TypeBug
+	legacy.Priority = 3
+	require.NoError(s.T(), store.Write(legacy))
+
+	_, err = s.executeCommand("close", "tic-legacy")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "cannot update tic-legacy")
+
+	output, err = s.executeCommand("lint")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "2 problem(s) found")
+	require.Contains(s.T(), output, "tic-legacy: bug tickets require acceptance criteria")
+	require.Contains(s.T(), output, "tic-legacy: bug tickets require priority 2 or higher")
+	require.NotContains(s.T(), output, id)
+
+	_, err = s.executeCommand("close", "tic-legacy", "--force")
+	require.NoError(s.T(), err)
+}
+
+func (s *CmdSuite) TestGrepCommand() {
+	t := s.createTestTicket("tic-grep1", domain.StatusOpen, "Fix OAuth flow")
+	t.Description = "first line\nTODO: refresh tokens"
+	require.NoError(s.T(), store.Write(t))
+	s.createTestTicket("tic-grep2", domain.StatusOpen, "Unrelated")
+
+	path := filepath.Join(s.tempDir, "tic-grep1.md")
+	data, err := os.ReadFile(path)
+	require.NoError(s.T(), err)
+	lineNo := 0
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "TODO") {
+			lineNo = i + 1
+		}
+	}
+	require.NotZero(s.T(), lineNo)
+
+	output, err := s.executeCommand("grep", "-n", "TODO")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), fmt.Sprintf("%s:%d:TODO: refresh tokens\n", path, lineNo), output)
+
+	output, err = s.executeCommand("grep", "-l", "-i", "oauth")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), path+"\n", output)
+
+	_, err = s.executeCommand("grep", "(")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "invalid pattern")
+}
+
+func (s *CmdSuite) TestUnblockedCommand() {
+	dep := s.createTestTicket("tic-unb-dep", domain.StatusOpen, "Prerequisite")
+	waiting := s.createTestTicket("tic-unb-wait", domain.StatusOpen, "Waiting work")
+	waiting.Deps = []string{"tic-unb-dep"}
+	require.NoError(s.T(), store.Write(waiting))
+
+	output, err := s.executeCommand("unblocked")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), output)
+
+	dep.Status = domain.StatusClosed
+	dep.ClosedAt = time.Now().UTC()
+	require.NoError(s.T(), store.Write(dep))
+
+	output, err = s.executeCommand("unblocked")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-unb-wait")
+
+	output, err = s.executeCommand("unblocked", "--since-last")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-unb-wait")
+	require.FileExists(s.T(), filepath.Join(s.tempDir, unblockedMarkerFileName))
+
+	output, err = s.executeCommand("unblocked", "--since-last")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), output)
+}
+
+func (s *CmdSuite) TestShowDiffFlag() {
+	s.createTestTicket("tic-sdiff", domain.StatusOpen, "Diff me")
+
+	output, err := s.executeCommand("--show-diff", "close", "tic-sdiff")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "+++ b/")
+	require.Contains(s.T(), output, "-status: open")
+	require.Contains(s.T(), output, "+status: closed")
+
+	editor := filepath.Join(s.T().TempDir(), "editor.sh")
+	require.NoError(s.T(), os.WriteFile(editor, []byte("#!/bin/sh\nsed 's/^# Diff me$/# Edited/' \"$1\" > \"$1.new\" && mv \"$1.new\" \"$1\"\n"), 0755))
+	s.T().Setenv("EDITOR", editor)
+	output, err = s.executeCommand("--show-diff", "edit", "tic-sdiff")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "-# Diff me")
+	require.Contains(s.T(), output, "+# Edited")
+}
+
+func (s *CmdSuite) TestDiffCommandUncommitted() {
+	s.T().Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(s.tempDir))
+	s.createTestTicket("tic-gdiff", domain.StatusOpen, "New ticket")
+
+	_, err := s.executeCommand("diff", "tic-gdiff")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "not inside a git repository")
+
+	cmd := exec.Command("git", "init", "-q", s.tempDir)
+	require.NoError(s.T(), cmd.Run())
+
+	output, err := s.executeCommand("diff", "tic-gdiff")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "--- /dev/null")
+	require.Contains(s.T(), output, "+# New ticket")
+}
+
+func (s *CmdSuite) TestTouchCommand() {
+	s.createTestTicket("tic-touch", domain.StatusOpen, "Waiting ticket")
+	before, err := store.Read("tic-touch")
+	require.NoError(s.T(), err)
+	require.False(s.T(), before.UpdatedAt.IsZero())
+
+	time.Sleep(10 * time.Millisecond)
+	output, err := s.executeCommand("touch", "tic-touch", "waiting", "on", "vendor")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Touched tic-touch")
+
+	ticket, err := store.Read("tic-touch")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ticket.UpdatedAt.After(before.UpdatedAt))
+	require.Len(s.T(), ticket.Notes, 1)
+	require.Equal(s.T(), "Touched: waiting on vendor", ticket.Notes[0].Content)
+
+	_, err = s.executeCommand("ping", "tic-touch")
+	require.NoError(s.T(), err)
+	ticket, err = store.Read("tic-touch")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Touched", ticket.Notes[1].Content)
+}
+
+func (s *CmdSuite) TestLockCommand() {
+	s.createTestTicket("tic-lock", domain.StatusOpen, "Signed off")
+
+	output, err := s.executeCommand("lock", "tic-lock", "--reason", "under audit")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Locked tic-lock")
+
+	ticket, err := store.Read("tic-lock")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ticket.Locked)
+	require.Equal(s.T(), "under audit", ticket.LockReason)
+
+	_, err = s.executeCommand("close", "tic-lock")
+	require.ErrorIs(s.T(), err, storage.ErrLocked)
+	_, err = s.executeCommand("add-note", "tic-lock", "sneaky")
+	require.ErrorIs(s.T(), err, storage.ErrLocked)
+	_, err = s.executeCommand("edit", "tic-lock")
+	require.ErrorIs(s.T(), err, storage.ErrLocked)
+	_, err = s.executeCommand("--force", "start", "tic-lock")
+	require.ErrorIs(s.T(), err, storage.ErrLocked)
+	_, err = s.executeCommand("lock", "tic-lock")
+	require.ErrorContains(s.T(), err, "already locked")
+
+	// An import can neither unlock the ticket nor change it
+	importFile := filepath.Join(s.T().TempDir(), "remote.json")
+	require.NoError(s.T(), os.WriteFile(importFile, []byte(`[{"ID": "tic-lock", "Title": "Hijacked", "Locked": false}]`), 0644))
+	_, err = s.executeCommand("import", importFile, "--merge", "--strategy", "theirs")
+	require.ErrorIs(s.T(), err, storage.ErrLocked)
+	ticket, err = store.Read("tic-lock")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Signed off", ticket.Title)
+	require.True(s.T(), ticket.Locked)
+
+	output, err = s.executeCommand("unlock", "tic-lock")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Unlocked tic-lock")
+
+	_, err = s.executeCommand("close", "tic-lock")
+	require.NoError(s.T(), err)
+	ticket, err = store.Read("tic-lock")
+	require.NoError(s.T(), err)
+	require.False(s.T(), ticket.Locked)
+	require.Empty(s.T(), ticket.LockReason)
+	require.Equal(s.T(), domain.StatusClosed, ticket.Status)
+
+	_, err = s.executeCommand("unlock", "tic-lock")
+	require.ErrorContains(s.T(), err, "not locked")
+}
+
+func (s *CmdSuite) TestReviewWorkflow() {
+	s.T().Setenv("TK_USER", "Jane Doe")
+	s.createTestTicket("tic-rev", domain.StatusOpen, "Needs eyes")
+	s.createTestTicket("tic-norev", domain.StatusOpen, "Nobody asked")
+
+	output, err := s.executeCommand("review", "request", "tic-rev", "@jane", "@bob")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Requested review of tic-rev from jane, bob")
+
+	output, err = s.executeCommand("list", "--needs-review")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-rev")
+	require.NotContains(s.T(), output, "tic-norev")
+
+	output, err = s.executeCommand("review", "approve", "tic-rev", "looks", "good")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Approved tic-rev")
+
+	ticket, err := store.Read("tic-rev")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), ticket.Reviews, 2)
+	require.Equal(s.T(), domain.ReviewApproved, ticket.Reviews[0].Decision)
+	require.Equal(s.T(), "looks good", ticket.Reviews[0].Comment)
+	require.Equal(s.T(), domain.ReviewPending, ticket.Reviews[1].Decision)
+
+	s.T().Setenv("TK_USER", "Bob")
+	_, err = s.executeCommand("review", "reject", "tic-rev", "missing tests")
+	require.NoError(s.T(), err)
+	output, err = s.executeCommand("list", "--needs-review")
+	require.NoError(s.T(), err)
+	require.NotContains(s.T(), output, "tic-rev")
+
+	// Re-requesting resets the decision instead of adding a duplicate reviewer
+	_, err = s.executeCommand("review", "request", "tic-rev", "bob")
+	require.NoError(s.T(), err)
+	ticket, err = store.Read("tic-rev")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), ticket.Reviews, 2)
+	require.Equal(s.T(), domain.ReviewPending, ticket.Reviews[1].Decision)
+	require.Empty(s.T(), ticket.Reviews[1].Comment)
+}
+
+func (s *CmdSuite) TestSectionShowAndEdit() {
+	ticket := s.createTestTicket("tic-sect", domain.StatusOpen, "Sections")
+	ticket.Sections = []domain.Section{{Name: "Test Plan", Content: "1. run it"}}
+	require.NoError(s.T(), store.Write(ticket))
+
+	output, err := s.executeCommand("show", "tic-sect", "--section", "test plan")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "1. run it\n", output)
+
+	_, err = s.executeCommand("show", "tic-sect", "--section", "Rollout")
+	require.ErrorContains(s.T(), err, `no section "Rollout"`)
+	_, err = s.executeCommand("show", "tic-sect", "--section", "Notes")
+	require.ErrorContains(s.T(), err, "tk add-note")
+
+	editor := filepath.Join(s.T().TempDir(), "editor.sh")
+	require.NoError(s.T(), os.WriteFile(editor, []byte("#!/bin/sh\nprintf 'staged rollout\\n' > \"$1\"\n"), 0755))
+	s.T().Setenv("EDITOR", editor)
+
+	_, err = s.executeCommand("edit", "tic-sect", "--section", "Rollout")
+	require.NoError(s.T(), err)
+	_, err = s.executeCommand("edit", "tic-sect", "--section", "Test Plan")
+	require.NoError(s.T(), err)
+
+	ticket, err = store.Read("tic-sect")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []domain.Section{
+		{Name: "Test Plan", Content: "staged rollout"},
+		{Name: "Rollout", Content: "staged rollout"},
+	}, ticket.Sections)
+}
+
+func (s *CmdSuite) TestEditWholeTicket() {
+	ticket := s.createTestTicket("tic-edit", domain.StatusOpen, "Before")
+
+	editor := filepath.Join(s.T().TempDir(), "editor.sh")
+	require.NoError(s.T(), os.WriteFile(editor, []byte("#!/bin/sh\nsed 's/^# Before$/# After/' \"$1\" > \"$1.new\" && mv \"$1.new\" \"$1\"\n"), 0755))
+	s.T().Setenv("EDITOR", editor)
+
+	_, err := s.executeCommand("edit", "tic-edit")
+	require.NoError(s.T(), err)
+
+	edited, err := store.Read("tic-edit")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "After", edited.Title)
+	require.Equal(s.T(), ticket.Revision+1, edited.Revision)
+
+	events, err := store.ReadJournal()
+	require.NoError(s.T(), err)
+	last := events[len(events)-1]
+	require.Equal(s.T(), "tic-edit", last.Ticket)
+	require.Equal(s.T(), storage.Change{From: "Before", To: "After"}, last.Changes["title"])
+}
+
+func (s *CmdSuite) TestEditKeepsFileAsTyped() {
+	s.createTestTicket("tic-edit", domain.StatusOpen, "Before")
+	editor := filepath.Join(s.T().TempDir(), "editor.sh")
+	s.T().Setenv("EDITOR", editor)
+
+	// Keys tk does not know and hand formatting are saved as typed.
+	require.NoError(s.T(), os.WriteFile(editor, []byte("#!/bin/sh\nsed 's/^status: open$/status: open\\nsprint: 42/; s/^# Before$/# After\\n\\n\\n  indented/' \"$1\" > \"$1.new\" && mv \"$1.new\" \"$1\"\n"), 0755))
+	_, err := s.executeCommand("edit", "tic-edit")
+	require.NoError(s.T(), err)
+	data, err := os.ReadFile(filepath.Join(s.tempDir, "tic-edit.md"))
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), string(data), "sprint: 42\n")
+	require.Contains(s.T(), string(data), "# After\n\n\n  indented\n")
+
+	// An edit that cannot be saved is kept for the user.
+	require.NoError(s.T(), os.WriteFile(editor, []byte("#!/bin/sh\nprintf 'not a ticket\\n' > \"$1\"\n"), 0755))
+	_, err = s.executeCommand("edit", "tic-edit")
+	require.ErrorContains(s.T(), err, "nothing was saved; your edit is kept in ")
+	kept := err.Error()[strings.LastIndex(err.Error(), " ")+1:]
+	defer func() { _ = os.Remove(kept) }()
+	typed, err := os.ReadFile(kept)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "not a ticket\n", string(typed))
+
+	// A locked ticket is refused with its reason before the editor opens.
+	_, err = s.executeCommand("lock", "tic-edit", "--reason", "release freeze")
+	require.NoError(s.T(), err)
+	_, err = s.executeCommand("edit", "tic-edit")
+	require.ErrorIs(s.T(), err, storage.ErrLocked)
+	require.ErrorContains(s.T(), err, "release freeze")
+}
+
+func (s *CmdSuite) TestDepEdit() {
+	ticket := s.createTestTicket("tic-depe", domain.StatusOpen, "Needs things")
+	s.createTestTicket("tic-depa", domain.StatusOpen, "Old dep")
+	s.createTestTicket("tic-depb", domain.StatusOpen, "New dep")
+	s.createTestTicket("tic-depc", domain.StatusOpen, "Other new dep")
+	ticket.Deps = []string{"tic-depa"}
+	require.NoError(s.T(), store.Write(ticket))
+
+	// The editor keeps a copy of what it was given and writes the new list
+	dir := s.T().TempDir()
+	editor := filepath.Join(dir, "editor.sh")
+	script := "#!/bin/sh\ncp \"$1\" " + filepath.Join(dir, "before.txt") + "\nprintf 'tic-depb  # new\\n\\ntic-depc\\n' > \"$1\"\n"
+	require.NoError(s.T(), os.WriteFile(editor, []byte(script), 0755))
+	s.T().Setenv("EDITOR", editor)
+
+	output, err := s.executeCommand("dep", "edit", "tic-depe")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Added dependency: tic-depe -> tic-depb")
+	require.Contains(s.T(), output, "Added dependency: tic-depe -> tic-depc")
+	require.Contains(s.T(), output, "Removed dependency: tic-depe -> tic-depa")
+
+	before, err := os.ReadFile(filepath.Join(dir, "before.txt"))
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), string(before), "tic-depa  # [open] Old dep\n")
+
+	ticket, err = store.Read("tic-depe")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []string{"tic-depb", "tic-depc"}, ticket.Deps)
+
+	// Adding a dependent as a dependency is refused and changes nothing
+	require.NoError(s.T(), os.WriteFile(editor, []byte("#!/bin/sh\nprintf 'tic-depe\\n' > \"$1\"\n"), 0755))
+	_, err = s.executeCommand("dep", "edit", "tic-depc")
+	require.ErrorContains(s.T(), err, "would create a cycle: tic-depc -> tic-depe")
+	ticket, err = store.Read("tic-depc")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), ticket.Deps)
+}
+
+func (s *CmdSuite) TestPickCommand() {
+	_, err := s.executeCommand("pick")
+	require.ErrorContains(s.T(), err, "no ready tickets")
+
+	s.createTestTicket("tic-pick1", domain.StatusOpen, "First")
+	s.createTestTicket("tic-pick2", domain.StatusOpen, "Second")
+	s.createTestTicket("tic-pick3", domain.StatusInProgress, "Taken")
+	blocked := s.createTestTicket("tic-pick4", domain.StatusOpen, "Blocked")
+	blocked.Deps = []string{"tic-pick3"}
+	require.NoError(s.T(), store.Write(blocked))
+
+	output, err := s.executeCommand("pick")
+	require.NoError(s.T(), err)
+	require.Regexp(s.T(), `tic-pick[12]`, output)
+
+	var picked []string
+	for range 3 {
+		output, err = s.executeCommand("pick", "--round-robin")
+		require.NoError(s.T(), err)
+		picked = append(picked, strings.Fields(output)[0])
+	}
+	require.Equal(s.T(), []string{"tic-pick1", "tic-pick2", "tic-pick1"}, picked)
+
+	_, err = s.executeCommand("lock", "tic-pick2")
+	require.NoError(s.T(), err)
+	_, err = s.executeCommand("pick", "--claim", "--round-robin")
+	require.Error(s.T(), err)
+	_, err = s.executeCommand("unlock", "tic-pick2")
+	require.NoError(s.T(), err)
+
+	output, err = s.executeCommand("pick", "--claim", "--round-robin")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-pick2")
+	ticket, err := store.Read("tic-pick2")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.StatusInProgress, ticket.Status)
+}
+
+func (s *CmdSuite) TestDueCommand() {
+	s.createTestTicket("tic-due", domain.StatusOpen, "Due")
+
+	output, err := s.executeCommand("due", "tic-due", "2025-03-01")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Set tic-due due 2025-03-01")
+	ticket, err := store.Read("tic-due")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), ticket.Due)
+
+	_, err = s.executeCommand("due", "tic-due", "soon")
+	require.ErrorContains(s.T(), err, `invalid due date "soon"`)
+
+	output, err = s.executeCommand("due", "tic-due")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Cleared due date of tic-due")
+	ticket, err = store.Read("tic-due")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ticket.Due.IsZero())
+}
+
+func (s *CmdSuite) TestEstimateRollupInShow() {
+	s.createTestTicket("tic-epic", domain.StatusOpen, "Epic")
+	output, err := s.executeCommand("create", "Part one", "--parent", "tic-epic", "-e", "3")
+	require.NoError(s.T(), err)
+	first := strings.TrimSpace(output)
+	output, err = s.executeCommand("create", "Part two", "--parent", "tic-epic")
+	require.NoError(s.T(), err)
+	second := strings.TrimSpace(output)
+
+	output, err = s.executeCommand("estimate", second, "2.5")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Estimated "+second+" at 2.5")
+	_, err = s.executeCommand("estimate", second, "--", "-1")
+	require.ErrorContains(s.T(), err, "non-negative")
+
+	_, err = s.executeCommand("close", first)
+	require.NoError(s.T(), err)
+	output, err = s.executeCommand("show", "tic-epic")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Estimate: 5.5 (2.5 remaining) from children")
+}
+
+func (s *CmdSuite) TestReadyLimit() {
+	for _, id := range []string{"tic-lim3", "tic-lim1", "tic-lim2"} {
+		s.createTestTicket(id, domain.StatusOpen, "Same priority")
+	}
+
+	output, err := s.executeCommand("ready", "--limit", "2")
+	require.NoError(s.T(), err)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(s.T(), lines, 2)
+	require.True(s.T(), strings.HasPrefix(lines[0], "tic-lim1"))
+	require.True(s.T(), strings.HasPrefix(lines[1], "tic-lim2"))
+
+	_, err = s.executeCommand("ready", "--limit", "-1")
+	require.ErrorContains(s.T(), err, "must not be negative")
+}
+
+func (s *CmdSuite) TestDepGraphCommand() {
+	s.createTestTicket("tic-g1", domain.StatusOpen, "Depends")
+	s.createTestTicket("tic-g2", domain.StatusOpen, "Dependency")
+	_, err := s.executeCommand("dep", "add", "tic-g1", "tic-g2")
+	require.NoError(s.T(), err)
+
+	output, err := s.executeCommand("dep", "graph", "--format", "json")
+	require.NoError(s.T(), err)
+	var graph depGraph
+	require.NoError(s.T(), json.Unmarshal([]byte(output), &graph))
+	require.Len(s.T(), graph.Nodes, 2)
+	require.Equal(s.T(), []depGraphEdge{{From: "tic-g1", To: "tic-g2"}}, graph.Edges)
+
+	_, err = s.executeCommand("dep", "graph", "--format", "dot")
+	require.ErrorContains(s.T(), err, "unsupported format: dot")
+}
+
+func (s *CmdSuite) TestMoveCommand() {
+	parent := s.createTestTicket("tic-epic", domain.StatusOpen, "Epic")
+	child := s.createTestTicket("tic-child", domain.StatusOpen, "Child")
+	stay := s.createTestTicket("tic-stay", domain.StatusOpen, "Stays")
+	child.Parent = parent.ID
+	child.Deps = []string{stay.ID}
+	child.Links = []string{stay.ID}
+	require.NoError(s.T(), store.Write(child))
+	stay.Deps = []string{parent.ID}
+	stay.Links = []string{child.ID}
+	require.NoError(s.T(), store.Write(stay))
+
+	targetDir := filepath.Join(s.T().TempDir(), "billing", ".tickets")
+	output, err := s.executeCommand("move", "tic-epic", "tic-child", "--to", targetDir)
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Moved tic-epic to "+targetDir)
+	require.Contains(s.T(), output, "tic-child: dropped dep tic-stay")
+	require.Contains(s.T(), output, "tic-stay: dropped dep tic-epic")
+
+	require.False(s.T(), store.Exists("tic-epic"))
+	require.False(s.T(), store.Exists("tic-child"))
+
+	target := storage.New(targetDir)
+	moved, err := target.Read("tic-child")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "tic-epic", moved.Parent)
+	require.Empty(s.T(), moved.Deps)
+	require.Empty(s.T(), moved.Links)
+
+	remaining, err := store.Read("tic-stay")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), remaining.Deps)
+	require.Empty(s.T(), remaining.Links)
+}
+
+func (s *CmdSuite) TestMoveCommandRefusesExistingAndLocked() {
+	s.createTestTicket("tic-dup", domain.StatusOpen, "Duplicate")
+	locked := s.createTestTicket("tic-frozen", domain.StatusOpen, "Frozen")
+	locked.Locked = true
+	require.NoError(s.T(), store.Write(locked))
+
+	targetDir := s.T().TempDir()
+	target := storage.New(targetDir)
+	require.NoError(s.T(), target.Write(&domain.Ticket{ID: "tic-dup", Status: domain.StatusOpen, Title: "Elsewhere"}))
+
+	_, err := s.executeCommand("move", "tic-dup", "--to", targetDir)
+	require.ErrorContains(s.T(), err, "ticket tic-dup already exists")
+	require.True(s.T(), store.Exists("tic-dup"))
+
+	_, err = s.executeCommand("move", "tic-frozen", "--to", targetDir)
+	require.ErrorIs(s.T(), err, storage.ErrLocked)
+
+	_, err = s.executeCommand("move", "tic-dup", "--to", s.tempDir)
+	require.ErrorContains(s.T(), err, "already in")
+}
+
+func (s *CmdSuite) TestStatusSymbolsFromConfig() {
+	s.createTestTicket("tic-sym", domain.StatusInProgress, "Symbols")
+	config := "symbol_set: emoji\nstatus_symbols:\n  in_progress: \">>\"\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte(config), 0644))
+	defer func() { require.NoError(s.T(), setStatusSymbols("", nil)) }()
+
+	output, err := s.executeCommand("dep", "tree", "tic-sym")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), ">> tic-sym - Symbols\n", output)
+
+	output, err = s.executeCommand("ls", "--line-format", "{{.Symbol}} {{.ID}}")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), ">> tic-sym\n", output)
+}
+
+func (s *CmdSuite) TestASCIIOutput() {
+	s.createTestTicket("tic-a11y", domain.StatusInProgress, "Accessible")
+	defer func() { require.NoError(s.T(), setStatusSymbols("", nil)) }()
+
+	output, err := s.executeCommand("dep", "tree", "tic-a11y", "--ascii")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "[in_progress] tic-a11y - Accessible\n", output)
+
+	config := "symbol_set: emoji\nascii: true\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte(config), 0644))
+	asciiFlag = false
+	output, err = s.executeCommand("ls", "--line-format", "{{.Symbol}} {{.ID}}")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "[in_progress] tic-a11y\n", output)
+
+	require.NoError(s.T(), os.Remove(filepath.Join(s.tempDir, "config.yaml")))
+	output, err = s.executeCommand("dep", "tree", "tic-a11y")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "[~] tic-a11y - Accessible\n", output)
+}
+
+func (s *CmdSuite) TestDisplayTimeFromConfig() {
+	ticket := s.createTestTicket("tic-tz", domain.StatusClosed, "Zoned")
+	ticket.Created = time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)
+	ticket.ClosedAt = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	ticket.Due = time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	require.NoError(s.T(), store.Write(ticket))
+	config := "timezone: Asia/Tokyo\ndate_format: \"2006-01-02 15:04 MST\"\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte(config), 0644))
+	defer func() { require.NoError(s.T(), setDisplayTime("", "")) }()
+
+	output, err := s.executeCommand("show", "tic-tz")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "created: 2026-03-02 08:30 JST\n")
+	require.Contains(s.T(), output, "closed-at: 2026-03-02 17:00 JST\n")
+	require.Contains(s.T(), output, "due: 2026-03-05\n")
+
+	output, err = s.executeCommand("ls", "--status", "closed", "--line-format", "{{.ID}} {{time .Created}} {{date .Due}}")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "tic-tz 2026-03-02 08:30 JST 2026-03-05\n", output)
+
+	// Stored times stay in UTC
+	data, err := os.ReadFile(filepath.Join(s.tempDir, "tic-tz.md"))
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), string(data), "created: 2026-03-01T23:30:00Z")
+
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte("timezone: Mars/Olympus\n"), 0644))
+	_, err = s.executeCommand("show", "tic-tz")
+	require.ErrorContains(s.T(), err, `invalid timezone "Mars/Olympus"`)
+}
+
+func (s *CmdSuite) TestClaimLease() {
+	s.createTestTicket("tic-lease", domain.StatusOpen, "Agent work")
+	stuck := s.createTestTicket("tic-stuck", domain.StatusInProgress, "Crashed agent")
+	stuck.LeaseUntil = time.Now().UTC().Add(-time.Hour)
+	stuck.LeaseHolder = "agent-crashed"
+	require.NoError(s.T(), store.Write(stuck))
+	s.createTestTicket("tic-held", domain.StatusInProgress, "No lease")
+
+	output, err := s.executeCommand("claim", "tic-lease", "--lease", "30m")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Claimed tic-lease -> in_progress (lease until ")
+
+	output, err = s.executeCommand("ready", "--claimable")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-stuck")
+	require.NotContains(s.T(), output, "tic-lease")
+	require.NotContains(s.T(), output, "tic-held")
+
+	output, err = s.executeCommand("leases")
+	require.NoError(s.T(), err)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(s.T(), lines, 3)
+	require.Regexp(s.T(), `^tic-stuck\s+agent-crashed\s+\S+\s+expired\s+Crashed agent$`, lines[1])
+	require.Regexp(s.T(), `^tic-lease\s+\S+\s+\S+\s+(29m5\d|30m0)s\s+Agent work$`, lines[2])
+
+	output, err = s.executeCommand("pick", "--claim", "--lease", "1h")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-stuck")
+
+	ticket, err := store.Read("tic-stuck")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), store.Actor(), ticket.LeaseHolder)
+	require.True(s.T(), ticket.LeaseUntil.After(time.Now()))
+}
+
+func (s *CmdSuite) TestWatchAndWatchlist() {
+	s.T().Setenv("TK_USER", "Jane Doe")
+	s.createTestTicket("tic-watch1", domain.StatusOpen, "Watched")
+	s.createTestTicket("tic-watch2", domain.StatusOpen, "Not watched")
+
+	output, err := s.executeCommand("watch", "add", "tic-watch1")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Jane Doe now watches tic-watch1")
+	output, err = s.executeCommand("watch", "add", "tic-watch1", "@bob")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "bob now watches tic-watch1")
+	output, err = s.executeCommand("watch", "add", "tic-watch1", "@janedoe")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "already watches")
+
+	ticket, err := store.Read("tic-watch1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []string{"Jane Doe", "bob"}, ticket.Watchers)
+
+	_, err = s.executeCommand("start", "tic-watch1")
+	require.NoError(s.T(), err)
+
+	output, err = s.executeCommand("watchlist", "--line-format", "{{.ID}}")
+	require.NoError(s.T(), err)
+	require.Regexp(s.T(), `^tic-watch1\n  \S+ \S+  created\n(.*\n)*  \S+ \S+  started  \(Jane Doe\)\n$`, output)
+	require.Contains(s.T(), output, "  watchers  (Jane Doe)\n")
+	require.NotContains(s.T(), output, "tic-watch2")
+
+	_, err = s.executeCommand("watch", "rm", "tic-watch1", "@me")
+	require.NoError(s.T(), err)
+	output, err = s.executeCommand("watchlist")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Jane Doe watches no tickets\n", output)
+
+	_, err = s.executeCommand("watch", "rm", "tic-watch1", "@carol")
+	require.ErrorContains(s.T(), err, "carol does not watch tic-watch1")
+}
+
+func (s *CmdSuite) TestNotifyWatchers() {
+	s.T().Setenv("TK_USER", "alice")
+	ticket := s.createTestTicket("tic-notify", domain.StatusOpen, "Watched")
+	ticket.Watchers = []string{"alice", "bob"}
+	require.NoError(s.T(), store.Write(ticket))
+
+	log := filepath.Join(s.T().TempDir(), "notify.log")
+	hook := `echo "$TK_TICKET $TK_EVENT $TK_CHANGES $TK_ACTOR $TK_WATCHERS" >> ` + log
+	// The committed config file cannot set the command
+	config := "notify_command: 'echo config >> " + log + "'\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte(config), 0644))
+	_, err := s.executeCommand("start", "tic-notify")
+	require.NoError(s.T(), err)
+	require.NoFileExists(s.T(), log)
+
+	s.T().Setenv("TK_NOTIFY_COMMAND", hook)
+	_, err = s.executeCommand("close", "tic-notify")
+	require.NoError(s.T(), err)
+	// Changing only the watchers notifies nobody
+	_, err = s.executeCommand("watch", "add", "tic-notify", "@carol")
+	require.NoError(s.T(), err)
+
+	data, err := os.ReadFile(log)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "tic-notify updated closed-at,status alice bob\n", string(data))
+}
+
+func (s *CmdSuite) TestNotifyWatchersSkipsRollback() {
+	watched := s.createTestTicket("tic-notify1", domain.StatusOpen, "Watched")
+	watched.Watchers = []string{"bob"}
+	require.NoError(s.T(), store.Write(watched))
+	locked := s.createTestTicket("tic-notify2", domain.StatusOpen, "Locked")
+	locked.Locked = true
+	require.NoError(s.T(), store.Write(locked))
+
+	log := filepath.Join(s.T().TempDir(), "notify.log")
+	s.T().Setenv("TK_NOTIFY_COMMAND", `echo "$TK_TICKET $TK_EVENT" >> `+log)
+
+	// The link to the locked ticket fails, rolling back the watched one
+	_, err := s.executeCommand("link", "tic-notify1", "tic-notify2")
+	require.ErrorIs(s.T(), err, storage.ErrLocked)
+	unchanged, err := store.Read("tic-notify1")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), unchanged.Links)
+	require.NoFileExists(s.T(), log)
+
+	_, err = s.executeCommand("add-note", "tic-notify1", "first")
+	require.NoError(s.T(), err)
+	data, err := os.ReadFile(log)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "tic-notify1 updated\n", string(data))
+}
+
+func (s *CmdSuite) TestNotifyWatchersAfterUnlock() {
+	if _, err := exec.LookPath("flock"); err != nil {
+		s.T().Skip("flock not installed")
+	}
+	ticket := s.createTestTicket("tic-notifylock", domain.StatusOpen, "Watched")
+	ticket.Watchers = []string{"bob"}
+	require.NoError(s.T(), store.Write(ticket))
+
+	// The hook can lock the ticket file only once tk has released it
+	log := filepath.Join(s.T().TempDir(), "notify.log")
+	path := filepath.Join(store.TicketsDir(), "tic-notifylock.md")
+	s.T().Setenv("TK_NOTIFY_COMMAND", "flock -n "+path+" echo unlocked >> "+log)
+	_, err := s.executeCommand("close", "tic-notifylock")
+	require.NoError(s.T(), err)
+
+	data, err := os.ReadFile(log)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "unlocked\n", string(data))
+}
+
+func (s *CmdSuite) TestImportWithMapping() {
+	dir := s.T().TempDir()
+	mapping := filepath.Join(dir, "jira.yml")
+	require.NoError(s.T(), os.WriteFile(mapping, []byte(`fields:
+  summary: Title
+  key: ExternalRef
+status:
+  In Review: in_progress
+assignees:
+  jane@example.com: Jane Doe
+tags:
+  add: [jira]
+`), 0644))
+	input := filepath.Join(dir, "export.json")
+	require.NoError(s.T(), os.WriteFile(input, []byte(`[{"ID": "tic-jira1", "summary": "Fix login", "key": "JIRA-1",
+  "Status": "in review", "Assignee": "jane@example.com"}]`), 0644))
+
+	output, err := s.executeCommand("import", input, "--mapping", mapping)
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Imported 1 ticket(s)")
+
+	ticket, err := store.Read("tic-jira1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Fix login", ticket.Title)
+	require.Equal(s.T(), "JIRA-1", ticket.ExternalRef)
+	require.Equal(s.T(), domain.StatusInProgress, ticket.Status)
+	require.Equal(s.T(), "Jane Doe", ticket.Assignee)
+	require.Equal(s.T(), []string{"jira"}, ticket.Tags)
+}
+
+func (s *CmdSuite) TestExportSplitBy() {
+	alice := s.createTestTicket("tic-split1", domain.StatusOpen, "Alice's")
+	alice.Assignee = "alice"
+	require.NoError(s.T(), store.Write(alice))
+	s.createTestTicket("tic-split2", domain.StatusOpen, "Nobody's")
+	dir := filepath.Join(s.T().TempDir(), "out")
+
+	output, err := s.executeCommand("export", "--split-by", "assignee", "--format", "csv", "-o", dir)
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Wrote 1 ticket(s) to "+filepath.Join(dir, "alice.csv"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "alice.csv"))
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), string(data), "tic-split1")
+	require.NotContains(s.T(), string(data), "tic-split2")
+	data, err = os.ReadFile(filepath.Join(dir, "unassigned.csv"))
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), string(data), "tic-split2")
+}
+
+func (s *CmdSuite) TestPRLinksAndStates() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"state": "closed", "merged": true}`))
+	}))
+	defer server.Close()
+	defer func(old string) { githubAPIURL = old }(githubAPIURL)
+	githubAPIURL = server.URL
+
+	s.createTestTicket("tic-pr1", domain.StatusInProgress, "Shipped")
+	s.createTestTicket("tic-pr2", domain.StatusClosed, "Done")
+	s.createTestTicket("tic-pr3", domain.StatusOpen, "No PR")
+
+	output, err := s.executeCommand("pr", "add", "tic-pr1", "https://github.com/acme/api/pull/1/")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Linked https://github.com/acme/api/pull/1 to tic-pr1")
+	_, err = s.executeCommand("pr", "add", "tic-pr2", "https://github.com/acme/api/pull/2")
+	require.NoError(s.T(), err)
+	_, err = s.executeCommand("pr", "add", "tic-pr3", "https://github.com/acme/api/issues/3")
+	require.ErrorContains(s.T(), err, "invalid pull request URL")
+
+	output, err = s.executeCommand("prs", "--open", "--line-format", "{{.ID}}")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), `tic-pr1
+  merged  https://github.com/acme/api/pull/1
+  ! merged but ticket still in_progress
+
+1 ticket(s) have only merged pull requests but are not closed
+`, output)
+
+	_, err = s.executeCommand("pr", "rm", "tic-pr1", "https://github.com/acme/api/pull/1")
+	require.NoError(s.T(), err)
+	ticket, err := store.Read("tic-pr1")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), ticket.PRs)
+}
+
+func (s *CmdSuite) TestLocalizedOutput() {
+	s.createTestTicket("tic-i18n", domain.StatusOpen, "Translate me")
+	localesDir := filepath.Join(s.tempDir, LocalesDirName)
+	require.NoError(s.T(), os.MkdirAll(localesDir, 0755))
+	catalog := `status.updated: "Aktualisiert: {{.ID}} -> {{.Status}}"
+tk.close.short: Ticket schließen
+`
+	require.NoError(s.T(), os.WriteFile(filepath.Join(localesDir, "active.de.yaml"), []byte(catalog), 0644))
+	s.T().Setenv("TK_LANG", "de")
+
+	// Messages the catalog lacks stay in English.
+	output, err := s.executeCommand("start", "tic-i18n")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Claimed tic-i18n -> in_progress\n", output)
+
+	output, err = s.executeCommand("close", "tic-i18n")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Aktualisiert: tic-i18n -> closed\n", output)
+	require.Equal(s.T(), "Ticket schließen", closeCmd.Short)
+
+	// The locale is used when neither TK_LANG nor the config picks a language.
+	s.T().Setenv("TK_LANG", "")
+	s.T().Setenv("LC_ALL", "")
+	s.T().Setenv("LC_MESSAGES", "")
+	s.T().Setenv("LANG", "de_DE.UTF-8")
+	output, err = s.executeCommand("reopen", "tic-i18n")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Aktualisiert: tic-i18n -> open\n", output)
+
+	s.T().Setenv("LANG", "C")
+	output, err = s.executeCommand("close", "tic-i18n")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Updated tic-i18n -> closed\n", output)
+	require.Equal(s.T(), "Set ticket status to closed", closeCmd.Short)
+}
+
+func (s *CmdSuite) TestLocalizedCommandOutput() {
+	s.createTestTicket("tic-dep", domain.StatusOpen, "Dependency")
+	ticket := s.createTestTicket("tic-i18n", domain.StatusOpen, "Translate me")
+	ticket.Deps = []string{"tic-dep"}
+	require.NoError(s.T(), store.Write(ticket))
+	localesDir := filepath.Join(s.tempDir, LocalesDirName)
+	require.NoError(s.T(), os.MkdirAll(localesDir, 0755))
+	catalog := `show.blockers: "Blockiert von: {{.IDs}}"
+prs.none: Keine Tickets mit Pull Requests
+triage.nothing: Nichts zu sichten
+import.imported: "{{.Count}} Ticket(s) importiert"
+error.not_found: "Ticket nicht gefunden: {{.ID}}"
+`
+	require.NoError(s.T(), os.WriteFile(filepath.Join(localesDir, "active.de.yaml"), []byte(catalog), 0644))
+	s.T().Setenv("TK_LANG", "de")
+
+	output, err := s.executeCommand("show", "tic-i18n")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Blockiert von: tic-dep\n")
+
+	output, err = s.executeCommand("prs")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Keine Tickets mit Pull Requests\n", output)
+
+	output, err = s.executeCommand("triage", "--type", "feature")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Nichts zu sichten\n", output)
+
+	importFile := filepath.Join(s.tempDir, "import.json")
+	require.NoError(s.T(), os.WriteFile(importFile, []byte(`[{"ID": "tic-imp1", "Title": "Imported"}]`), 0644))
+	output, err = s.executeCommand("import", importFile)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "1 Ticket(s) importiert\n", output)
+
+	// Translated errors still match the storage sentinels.
+	_, err = s.executeCommand("show", "tic-nope")
+	require.EqualError(s.T(), err, "Ticket nicht gefunden: tic-nope")
+	require.ErrorIs(s.T(), err, storage.ErrNotFound)
+}
+
+func (s *CmdSuite) TestLocalizedRemainingCommands() {
+	s.T().Setenv("TK_USER", "alice")
+	s.createTestTicket("tic-i18n", domain.StatusOpen, "Translate me")
+	localesDir := filepath.Join(s.tempDir, LocalesDirName)
+	require.NoError(s.T(), os.MkdirAll(localesDir, 0755))
+	catalog := `watch.added: "{{.User}} beobachtet jetzt {{.ID}}"
+note.nothing_to_compact: "Nichts zu verdichten in {{.ID}}"
+stats.by_status: "Nach Status:"
+bulk.verb.closed: geschlossen
+bulk.dry_run: "Probelauf: würde {{.Count}} Ticket(s) {{.Verb}}:"
+error.not_found: "Ticket nicht gefunden: {{.ID}}"
+`
+	require.NoError(s.T(), os.WriteFile(filepath.Join(localesDir, "active.de.yaml"), []byte(catalog), 0644))
+	s.T().Setenv("TK_LANG", "de")
+
+	output, err := s.executeCommand("watch", "add", "tic-i18n")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "alice beobachtet jetzt tic-i18n\n", output)
+
+	output, err = s.executeCommand("note", "compact", "tic-i18n")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Nichts zu verdichten in tic-i18n\n", output)
+
+	output, err = s.executeCommand("stats")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Nach Status:\n")
+
+	output, err = s.executeCommand("bulk", "close", "--dry-run", "--status", "open")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Probelauf: würde 1 Ticket(s) geschlossen:\n")
+
+	// Commands that resolve an ID report a missing ticket the same way.
+	for _, command := range []string{"start", "diff"} {
+		_, err = s.executeCommand(command, "tic-nope")
+		require.EqualError(s.T(), err, "Ticket nicht gefunden: tic-nope", command)
+		require.ErrorIs(s.T(), err, storage.ErrNotFound, command)
+	}
+}
+
+func (s *CmdSuite) TestLocalizedHelpKeepsVerbs() {
+	localesDir := filepath.Join(s.tempDir, LocalesDirName)
+	require.NoError(s.T(), os.MkdirAll(localesDir, 0755))
+	catalogPath := filepath.Join(localesDir, "active.de.yaml")
+	s.T().Setenv("TK_LANG", "de")
+
+	// A help text with the wrong verbs would garble the output: use English.
+	require.NoError(s.T(), os.WriteFile(catalogPath, []byte("help: \"Hilfe %d\"\n"), 0644))
+	output, err := s.executeCommand()
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tk - minimal ticket system with dependency tracking")
+	require.NotContains(s.T(), output, "Hilfe")
+	require.NotContains(s.T(), output, "%!")
+
+	require.NoError(s.T(), os.WriteFile(catalogPath, []byte("help: \"Hilfe %d-%d (%d=höchste, Standard %d)\\n\"\n"), 0644))
+	output, err = s.executeCommand()
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), fmt.Sprintf("Hilfe %d-%d (%d=höchste, Standard %d)\n",
+		domain.MinPriority, domain.MaxPriority, domain.MinPriority, domain.DefaultPriority), output)
+}
+
+func (s *CmdSuite) TestLocalizedOutputInvalidCatalog() {
+	localesDir := filepath.Join(s.tempDir, LocalesDirName)
+	require.NoError(s.T(), os.MkdirAll(localesDir, 0755))
+	require.NoError(s.T(), os.WriteFile(filepath.Join(localesDir, "active.de.yaml"), []byte("[not a map"), 0644))
+
+	_, err := s.executeCommand("ls")
+	require.ErrorContains(s.T(), err, "failed to load message catalog")
+}
+
+func (s *CmdSuite) TestI18nExtract() {
+	output, err := s.executeCommand("i18n", "extract")
+	require.NoError(s.T(), err)
+
+	var catalog map[string]any
+	require.NoError(s.T(), yaml.Unmarshal([]byte(output), &catalog))
+	require.Equal(s.T(), "Claimed {{.ID}} -> in_progress", catalog["start.claimed"])
+	require.Equal(s.T(), "Set ticket status to closed", catalog["tk.close.short"])
+	require.Contains(s.T(), catalog["help"], "other")
+	require.Contains(s.T(), catalog, "tk.dep.add.long")
+
+	localesDir := filepath.Join(s.tempDir, LocalesDirName)
+	require.NoError(s.T(), os.MkdirAll(localesDir, 0755))
+	require.NoError(s.T(), os.WriteFile(filepath.Join(localesDir, "active.de.yaml"), []byte("start.claimed: Übernommen {{.ID}}\n"), 0644))
+
+	path := filepath.Join(s.tempDir, "missing.de.yaml")
+	_, err = s.executeCommand("i18n", "extract", "--missing", "de", "-o", path)
+	require.NoError(s.T(), err)
+	data, err := os.ReadFile(path)
+	require.NoError(s.T(), err)
+	require.NotContains(s.T(), string(data), "start.claimed:")
+	require.Contains(s.T(), string(data), "start.claimed_lease:")
+}
+
+func (s *CmdSuite) TestEnsure() {
+	s.T().Setenv("TK_USER", "syncer")
+
+	output, err := s.executeCommand("ensure", "--external-ref", "gh-123", "--title", "Fix login", "-p", "1", "--tags", "auth")
+	require.NoError(s.T(), err)
+	id := strings.TrimSpace(output)
+	created, err := store.Read(id)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "gh-123", created.ExternalRef)
+	require.Equal(s.T(), "Fix login", created.Title)
+	require.Equal(s.T(), domain.StatusOpen, created.Status)
+	require.Equal(s.T(), domain.TypeTask, created.Type)
+	require.Equal(s.T(), 1, created.Priority)
+	require.Equal(s.T(), "syncer", created.Assignee)
+	require.Equal(s.T(), []string{"auth"}, created.Tags)
+
+	// Running it again changes nothing, not even the revision.
+	ensureFlags.tags = nil
+	output, err = s.executeCommand("ensure", "--external-ref", "gh-123", "--title", "Fix login", "-p", "1", "--tags", "auth")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), id+"\n", output)
+	same, err := store.Read(id)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), created.Revision, same.Revision)
+
+	// Only the passed fields change.
+	output, err = s.executeCommand("ensure", "--external-ref", "gh-123", "--status", "closed")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), id+"\n", output)
+	closed, err := store.Read(id)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.StatusClosed, closed.Status)
+	require.False(s.T(), closed.ClosedAt.IsZero())
+	require.Equal(s.T(), "Fix login", closed.Title)
+	require.Equal(s.T(), 1, closed.Priority)
+	require.Equal(s.T(), created.Revision+1, closed.Revision)
+}
+
+func (s *CmdSuite) TestEnsureErrors() {
+	_, err := s.executeCommand("ensure", "--title", "No ref")
+	require.ErrorContains(s.T(), err, "--external-ref is required")
+
+	for _, id := range []string{"tic-ens1", "tic-ens2"} {
+		t := s.createTestTicket(id, domain.StatusOpen, "Duplicate")
+		t.ExternalRef = "JIRA-1"
+		require.NoError(s.T(), store.Write(t))
+	}
+	_, err = s.executeCommand("ensure", "--external-ref", "JIRA-1", "--status", "closed")
+	require.ErrorContains(s.T(), err, "external ref JIRA-1 is on 2 tickets: tic-ens1, tic-ens2")
+
+	_, err = s.executeCommand("ensure", "--external-ref", "gh-9", "--status", "done")
+	require.Error(s.T(), err)
+	tickets, err := store.List()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), tickets, 2)
+}
+
+func (s *CmdSuite) TestQueryComputedFields() {
+	s.createTestTicket("tic-dep", domain.StatusOpen, "Dependency")
+	s.createTestTicket("tic-main", domain.StatusOpen, "Main")
+	_, err := s.executeCommand("dep", "add", "tic-main", "tic-dep")
+	require.NoError(s.T(), err)
+
+	output, err := s.executeCommand("query", `.[] | select(.Blocked) | .ID + " " + (.BlockedBy | join(","))`)
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-main tic-dep")
+
+	output, err = s.executeCommand("query", `.[] | select(.Ready) | .ID`)
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-dep")
+	require.NotContains(s.T(), output, "tic-main")
+}
+
+func (s *CmdSuite) TestQueryTicketsComputesOverAllTickets() {
+	dep := &domain.Ticket{ID: "tic-dep", Status: domain.StatusOpen}
+	main := &domain.Ticket{ID: "tic-main", Status: domain.StatusOpen, Deps: []string{"tic-dep"}}
+
+	result := queryTickets([]*domain.Ticket{dep, main}, []*domain.Ticket{main})
+	require.Len(s.T(), result, 1)
+	require.Equal(s.T(), "tic-main", result[0].ID)
+	require.True(s.T(), result[0].Blocked)
+	require.Equal(s.T(), []string{"tic-dep"}, result[0].BlockedBy)
+}
+
+func (s *CmdSuite) TestTriage() {
+	s.createTestTicket("tic-tri1", domain.StatusOpen, "First")
+	s.createTestTicket("tic-tri2", domain.StatusOpen, "Second")
+	s.createTestTicket("tic-tri3", domain.StatusOpen, "Third")
+	s.createTestTicket("tic-tri4", domain.StatusClosed, "Done already")
+	defer rootCmd.SetIn(nil)
+
+	rootCmd.SetIn(strings.NewReader("p\n9\np\n1\nt\nurgent,backend\nt\n-backend\na\nalice\nx\ns\nc\nz\n"))
+	output, err := s.executeCommand("triage")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "[1/3] ")
+	require.Contains(s.T(), output, "# First")
+	require.Contains(s.T(), output, "invalid priority 9")
+	require.Contains(s.T(), output, `Unknown action "x"`)
+	require.Contains(s.T(), output, "Snoozed tic-tri3 until ")
+	require.NotContains(s.T(), output, "Done already")
+	require.Contains(s.T(), output, "Triaged 3 of 3 ticket(s): 1 updated, 1 closed, 1 snoozed, 0 skipped")
+
+	ticket, err := store.Read("tic-tri1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, ticket.Priority)
+	require.Equal(s.T(), []string{"urgent"}, ticket.Tags)
+	require.Equal(s.T(), "alice", ticket.Assignee)
+	ticket, err = store.Read("tic-tri2")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.StatusClosed, ticket.Status)
+
+	// Closed and snoozed tickets are left out; end of input quits
+	output, err = s.executeCommand("triage", "--count")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "1\n", output)
+
+	countFlag = false
+	rootCmd.SetIn(strings.NewReader(""))
+	output, err = s.executeCommand("triage")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "tic-tri1")
+	require.NotContains(s.T(), output, "tic-tri3")
+	require.Contains(s.T(), output, "Triaged 1 of 1 ticket(s): 0 updated, 0 closed, 0 snoozed, 0 skipped")
+
+	output, err = s.executeCommand("triage", "-T", "missing")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Nothing to triage\n", output)
+}
+
+func (s *CmdSuite) TestCloseWithReason() {
+	s.createTestTicket("tic-wont", domain.StatusOpen, "Not doing this")
+	s.createTestTicket("tic-plain", domain.StatusOpen, "Plain close")
+
+	output, err := s.executeCommand("close", "tic-wont", "--reason", "wontfix", "--comment", "Out of scope")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Updated tic-wont -> closed")
+	ticket, err := store.Read("tic-wont")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.ResolutionWontfix, ticket.Resolution)
+	require.Equal(s.T(), "Closed as wontfix: Out of scope", ticket.Notes[len(ticket.Notes)-1].Content)
+
+	// Without --reason no resolution is recorded, and stats count it as done
+	closeFlags.reason = ""
+	closeFlags.comment = ""
+	_, err = s.executeCommand("close", "tic-plain")
+	require.NoError(s.T(), err)
+	ticket, err = store.Read("tic-plain")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), ticket.Resolution)
+	require.Empty(s.T(), ticket.Notes)
+
+	output, err = s.executeCommand("stats", "--json")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, `"wontfix": 1`)
+	require.Contains(s.T(), output, `"done": 1`)
+
+	_, err = s.executeCommand("reopen", "tic-wont")
+	require.NoError(s.T(), err)
+	ticket, err = store.Read("tic-wont")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), ticket.Resolution)
+
+	_, err = s.executeCommand("close", "tic-wont", "--reason", "fixed")
+	require.ErrorContains(s.T(), err, `invalid --reason "fixed": must be done, wontfix, duplicate`)
+}
+
+func (s *CmdSuite) TestReportEffort() {
+	open := s.createTestTicket("tic-rep1", domain.StatusOpen, "Open work")
+	open.Tags = []string{"api", "billing"}
+	open.Estimate = 3
+	open.Assignee = "alice"
+	require.NoError(s.T(), store.Write(open))
+	done := s.createTestTicket("tic-rep2", domain.StatusClosed, "Done work")
+	done.Tags = []string{"api"}
+	done.Estimate = 5
+	done.ClosedAt = time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	require.NoError(s.T(), store.Write(done))
+
+	output, err := s.executeCommand("report", "effort", "--since", "2026-01-01", "--until", "2026-02-01")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), `TAG      TICKETS  ESTIMATE  DONE  REMAINING
+api      2        8         5     3
+billing  1        3         0     3
+total    2        8         5     3
+`, output)
+
+	output, err = s.executeCommand("report", "effort", "--group-by", "assignee", "--since", "2026-02-01", "--json")
+	require.NoError(s.T(), err)
+	var report EffortReport
+	require.NoError(s.T(), json.Unmarshal([]byte(output), &report))
+	require.Equal(s.T(), []EffortGroup{{Name: "alice", Tickets: 1, Estimate: 3, Remaining: 3}}, report.Groups)
+
+	_, err = s.executeCommand("report", "effort", "--group-by", "type")
+	require.ErrorContains(s.T(), err, `invalid --group-by "type"`)
+}
+
+func (s *CmdSuite) TestBenchCommand() {
+	profileDir := s.T().TempDir()
+	cpuPath := filepath.Join(profileDir, "cpu.out")
+	memPath := filepath.Join(profileDir, "mem.out")
+
+	output, err := s.executeCommand("bench", "-n", "20", "--iterations", "2", "--cpuprofile", cpuPath, "--memprofile", memPath)
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "20 tickets, 2 iterations")
+	for _, op := range []string{"write", "read", "list", "search", "query"} {
+		require.Regexp(s.T(), `(?m)^\s*`+op+`\s`, output)
+	}
+	require.FileExists(s.T(), cpuPath)
+	require.FileExists(s.T(), memPath)
+
+	// Synthetic tickets never touch the real tickets directory
+	ids, err := store.ListIDs()
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), ids)
+}
+
+func (s *CmdSuite) TestBenchCommandCurrent() {
+	s.createTestTicket("tic-bench", domain.StatusOpen, "Benchmark me")
+
+	output, err := s.executeCommand("bench", "--current", "--iterations", "1")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "1 tickets, 1 iterations")
+	require.NotContains(s.T(), output, "write")
+}
+
+func (s *CmdSuite) TestCreateWithDue() {
+	output, err := s.executeCommand("create", "Due ticket", "--due", "2026-03-01")
+	require.NoError(s.T(), err)
+	ticket, err := store.Read(strings.TrimSpace(output))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), ticket.Due)
+
+	_, err = s.executeCommand("create", "Bad due", "--due", "someday")
+	require.ErrorContains(s.T(), err, `invalid due date "someday"`)
+}
+
+func (s *CmdSuite) TestSummaryCommand() {
+	for i, title := range []string{"First", "Second", "Third", "Fourth", "Fifth", "Sixth"} {
+		t := s.createTestTicket(fmt.Sprintf("tic-sum%d", i), domain.StatusOpen, title)
+		t.Priority = i % 5
+		require.NoError(s.T(), store.Write(t))
+	}
+	blocked := s.createTestTicket("tic-sumblk", domain.StatusOpen, "Blocked")
+	blocked.Deps = []string{"tic-sum0"}
+	require.NoError(s.T(), store.Write(blocked))
+	working := s.createTestTicket("tic-sumwip", domain.StatusInProgress, "Working")
+	working.Assignee = "alice"
+	working.Due = time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	require.NoError(s.T(), store.Write(working))
+	s.createTestTicket("tic-sumdone", domain.StatusClosed, "Done")
+
+	output, err := s.executeCommand("summary")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Tickets: 9 (7 open, 1 in_progress, 1 closed)\nBlocked: 1\nOverdue: 1\n")
+	require.Contains(s.T(), output, "Ready (5 of 6):\n  tic-sum0 ")
+	require.NotContains(s.T(), output, "tic-sumblk")
+	require.Contains(s.T(), output, "In progress:\n  alice (1)\n    tic-sumwip ")
+	require.Contains(s.T(), output, "Overdue:\n  tic-sumwip ")
+	require.Contains(s.T(), output, "(due 2020-01-02)")
+
+	output, err = s.executeCommand("summary", "-a", "bob")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Tickets: 0 (0 open, 0 in_progress, 0 closed)")
+	require.Contains(s.T(), output, "Ready (0 of 0):\n  none\n\nIn progress:\n  none\n")
+}
+
+func (s *CmdSuite) TestCreateUsesTypeIDPrefix() {
+	content := "id_prefixes:\n  bug: bug-\n  epic: epc\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte(content), 0644))
+
+	output, err := s.executeCommand("create", "Crash on save", "-t", "bug")
+	require.NoError(s.T(), err)
+	bugID := strings.TrimSpace(output)
+	require.Regexp(s.T(), `^bug-[0-9a-f]{4}$`, bugID)
+
+	output, err = s.executeCommand("create", "Big theme", "-t", "epic")
+	require.NoError(s.T(), err)
+	require.Regexp(s.T(), `^epc-[0-9a-f]{4}$`, strings.TrimSpace(output))
+
+	output, err = s.executeCommand("create", "Chore", "-t", "task")
+	require.NoError(s.T(), err)
+	require.Regexp(s.T(), `^tic-[0-9a-f]{4}$`, strings.TrimSpace(output))
+
+	output, err = s.executeCommand("show", strings.TrimPrefix(bugID, "bug-"))
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Crash on save")
+}
+
+func (s *CmdSuite) TestCreateRejectsInvalidIDPrefix() {
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte("id_prefixes:\n  bug: Bug_\n"), 0644))
+
+	_, err := s.executeCommand("create", "Crash", "-t", "bug")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "must be lowercase letters and digits")
+}
+
+func (s *CmdSuite) TestStatsByWeek() {
+	t := s.createTestTicket("tic-heat", domain.StatusOpen, "Done today")
+	t.SetStatus(domain.StatusClosed, time.Now())
+	require.NoError(s.T(), store.Write(t))
+
+	output, err := s.executeCommand("stats", "--by-week", "--weeks", "4")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Mon ")
+	require.Contains(s.T(), output, "█")
+	require.Contains(s.T(), output, "1 closed in the last 4 weeks")
+
+	_, err = s.executeCommand("stats", "--by-week", "--weeks", "0")
+	require.Error(s.T(), err)
+}
+
+func (s *CmdSuite) TestListSortByInStatus() {
+	old := s.createTestTicket("tic-age-old", domain.StatusOpen, "Old but just started")
+	old.Created = time.Now().Add(-48 * time.Hour)
+	require.NoError(s.T(), store.Write(old))
+	young := s.createTestTicket("tic-age-young", domain.StatusOpen, "Young")
+	young.Created = time.Now().Add(-24 * time.Hour)
+	require.NoError(s.T(), store.Write(young))
+
+	_, err := s.executeCommand("start", "tic-age-old")
+	require.NoError(s.T(), err)
+
+	output, err := s.executeCommand("list", "--sort", "in-status", "--line-format", "{{.ID}} {{.InStatus}}")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "tic-age-young 1d\ntic-age-old 0m\n", output)
+
+	output, err = s.executeCommand("list", "--sort", "age", "--line-format", "{{.ID}} {{.Age}}")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "tic-age-old 2d\ntic-age-young 1d\n", output)
+
+	_, err = s.executeCommand("list", "--sort", "agee")
+	require.ErrorContains(s.T(), err, `invalid sort field "agee"`)
+}
+
+func (s *CmdSuite) TestBlameCommand() {
+	s.T().Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(s.tempDir))
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = s.tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(s.T(), err, string(out))
+	}
+
+	t := s.createTestTicket("tic-blame", domain.StatusOpen, "Blame me")
+	git("init", "-q")
+	git("-c", "user.name=Alice", "add", ".")
+	git("-c", "user.name=Alice", "commit", "-q", "-m", "Create ticket")
+
+	t.Acceptance = "- [ ] works"
+	require.NoError(s.T(), store.Write(t))
+	git("-c", "user.name=Bob", "commit", "-q", "-am", "Add acceptance")
+
+	output, err := s.executeCommand("blame", "tic-blame")
+	require.NoError(s.T(), err)
+	require.Regexp(s.T(), `(?m)^title\s+[0-9a-f]{7}  Alice  \S+  Create ticket$`, output)
+	require.Regexp(s.T(), `(?m)^acceptance\s+[0-9a-f]{7}  Bob  \S+  Add acceptance$`, output)
+}
+
+func (s *CmdSuite) TestGCArchivesExpiredTickets() {
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.tempDir, "config.yaml"), []byte("retention:\n  closed: 30d\n"), 0644))
+
+	old := s.createTestTicket("tic-gc-old", domain.StatusClosed, "Closed long ago")
+	old.ClosedAt = time.Now().Add(-60 * 24 * time.Hour)
+	require.NoError(s.T(), store.Write(old))
+	recent := s.createTestTicket("tic-gc-new", domain.StatusClosed, "Closed recently")
+	recent.ClosedAt = time.Now().Add(-time.Hour)
+	require.NoError(s.T(), store.Write(recent))
+	s.createTestTicket("tic-gc-open", domain.StatusOpen, "Still open")
+	locked := s.createTestTicket("tic-gc-lock", domain.StatusClosed, "Under audit")
+	locked.ClosedAt = time.Now().Add(-90 * 24 * time.Hour)
+	locked.Locked = true
+	require.NoError(s.T(), store.Write(locked))
+
+	output, err := s.executeCommand("gc", "--dry-run")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Would archive tic-gc-old (closed 60d ago)")
+	require.True(s.T(), store.Exists("tic-gc-old"))
+
+	gcFlags.dryRun = false
+	output, err = s.executeCommand("gc")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Archived tic-gc-old (closed 60d ago)\n", output)
+	require.False(s.T(), store.Exists("tic-gc-old"))
+	require.True(s.T(), store.Archive().Exists("tic-gc-old"))
+	require.True(s.T(), store.Exists("tic-gc-new"))
+	require.True(s.T(), store.Exists("tic-gc-lock"))
+
+	output, err = s.executeCommand("gc")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Nothing to clean up")
+}
+
+func (s *CmdSuite) TestGCDeleteAndInvalidPolicy() {
+	_, err := s.executeCommand("gc")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "no retention policy")
+
+	configPath := filepath.Join(s.tempDir, "config.yaml")
+	require.NoError(s.T(), os.WriteFile(configPath, []byte("retention:\n  closed: 1d\n  action: shred\n"), 0644))
+	_, err = s.executeCommand("gc")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), `invalid retention.action "shred"`)
+
+	require.NoError(s.T(), os.WriteFile(configPath, []byte("retention:\n  closed: 1d\n  action: delete\n"), 0644))
+	old := s.createTestTicket("tic-gc-del", domain.StatusClosed, "Old")
+	old.ClosedAt = time.Now().Add(-48 * time.Hour)
+	require.NoError(s.T(), store.Write(old))
+
+	output, err := s.executeCommand("gc")
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), output, "Deleted tic-gc-del")
+	require.False(s.T(), store.Exists("tic-gc-del"))
+	require.False(s.T(), store.Archive().Exists("tic-gc-del"))
+}
@@ -18,6 +18,8 @@ var createFlags struct {
 	acceptance  string
 	ticketType  string
 	priority    int
+	estimate    float64
+	due         string
 	assignee    string
 	externalRef string
 	parent      string
@@ -32,32 +34,40 @@ var createCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Validate priority
 		if createFlags.priority < domain.MinPriority || createFlags.priority > domain.MaxPriority {
-			return fmt.Errorf("invalid priority %d: must be between %d and %d (%d=highest)", createFlags.priority, domain.MinPriority, domain.MaxPriority, domain.MinPriority)
+			return trError(msgInvalidPriority, map[string]any{"Priority": createFlags.priority, "Min": domain.MinPriority, "Max": domain.MaxPriority}, nil)
+		}
+
+		if err := validateEstimate(createFlags.estimate); err != nil {
+			return err
+		}
+
+		var due time.Time
+		if createFlags.due != "" {
+			var err error
+			if due, err = parseDueDate(createFlags.due, time.Now().UTC()); err != nil {
+				return err
+			}
 		}
 
 		// Validate parent exists if specified
 		if createFlags.parent != "" {
-			resolvedParent, err := store.ResolveID(createFlags.parent)
+			resolvedParent, err := resolveID(store, createFlags.parent)
 			if err != nil {
-				return fmt.Errorf("parent ticket not found: %s", createFlags.parent)
+				return trError(msgParentNotFound, map[string]any{"ID": createFlags.parent}, nil)
 			}
 			createFlags.parent = resolvedParent
 		}
 
-		id, err := storage.GenerateID()
-		if err != nil {
-			return fmt.Errorf("failed to generate ID: %w", err)
-		}
-
 		assignee := createFlags.assignee
 		if assignee == "" {
-			assignee = getGitUserName()
+			assignee = currentUser()
 		}
 
 		ticket := &domain.Ticket{
-			ID:          id,
 			Status:      domain.StatusOpen,
 			Priority:    createFlags.priority,
+			Estimate:    createFlags.estimate,
+			Due:         due,
 			Assignee:    assignee,
 			ExternalRef: createFlags.externalRef,
 			Parent:      createFlags.parent,
@@ -82,6 +92,19 @@ var createCmd = &cobra.Command{
 			ticket.Type = domain.TypeTask
 		}
 
+		prefix, err := cfg.IDPrefix(ticket.Type)
+		if err != nil {
+			return err
+		}
+		ticket.ID, err = storage.GenerateID(prefix)
+		if err != nil {
+			return fmt.Errorf("failed to generate ID: %w", err)
+		}
+
+		if err := enforcePolicy(ticket); err != nil {
+			return trWrap(msgCannotCreate, nil, err)
+		}
+
 		if err := store.EnsureDir(); err != nil {
 			return fmt.Errorf("failed to create tickets directory: %w", err)
 		}
@@ -90,7 +113,7 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to write ticket: %w", err)
 		}
 
-		fmt.Println(id)
+		fmt.Println(ticket.ID)
 		return nil
 	},
 }
@@ -111,6 +134,8 @@ func init() {
 	createCmd.Flags().StringVar(&createFlags.acceptance, "acceptance", "", "Acceptance criteria")
 	createCmd.Flags().StringVarP(&createFlags.ticketType, "type", "t", "task", "Type (bug|feature|task|epic|chore)")
 	createCmd.Flags().IntVarP(&createFlags.priority, "priority", "p", domain.DefaultPriority, fmt.Sprintf("Priority %d-%d, %d=highest", domain.MinPriority, domain.MaxPriority, domain.MinPriority))
+	createCmd.Flags().Float64VarP(&createFlags.estimate, "estimate", "e", 0, "Estimate in points (or any unit the project uses)")
+	createCmd.Flags().StringVar(&createFlags.due, "due", "", "Due date (YYYY-MM-DD, or from now like 3d or 2w)")
 	createCmd.Flags().StringVarP(&createFlags.assignee, "assignee", "a", "", "Assignee")
 	createCmd.Flags().StringVar(&createFlags.externalRef, "external-ref", "", "External reference (e.g., gh-123, JIRA-456)")
 	createCmd.Flags().StringVar(&createFlags.parent, "parent", "", "Parent ticket ID")
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -18,21 +20,22 @@ var depCmd = &cobra.Command{
 var depAddCmd = &cobra.Command{
 	Use:   "add <ticket-id> <dep-id>",
 	Short: "Add a dependency to a ticket",
-	Long:  `Add a dependency from ticket to dep-id. The ticket will be blocked until dep-id is closed.`,
-	Args:  cobra.ExactArgs(2),
+	Long: `Add a dependency from ticket to dep-id. The ticket will be blocked until dep-id is closed.
+Adding a dependency on an already closed ticket is refused unless --force is given.`,
+	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ticketID, err := store.ResolveID(args[0])
+		ticketID, err := resolveID(store, args[0])
 		if err != nil {
-			return fmt.Errorf("invalid ticket: %w", err)
+			return trWrap(msgDepInvalidTicket, nil, err)
 		}
 
-		depID, err := store.ResolveID(args[1])
+		depID, err := resolveID(store, args[1])
 		if err != nil {
-			return fmt.Errorf("invalid dependency: %w", err)
+			return trWrap(msgDepInvalid, nil, err)
 		}
 
 		if ticketID == depID {
-			return fmt.Errorf("ticket cannot depend on itself")
+			return trError(msgDepSelf, nil, nil)
 		}
 
 		ticket, err := store.Read(ticketID)
@@ -43,7 +46,7 @@ var depAddCmd = &cobra.Command{
 		// Check if dependency already exists
 		for _, d := range ticket.Deps {
 			if d == depID {
-				return fmt.Errorf("dependency %s already exists", depID)
+				return trError(msgDepExists, map[string]any{"Dep": depID}, nil)
 			}
 		}
 
@@ -52,12 +55,24 @@ var depAddCmd = &cobra.Command{
 			return err
 		}
 
+		// A closed dependency never blocks anything
+		dep, err := store.Read(depID)
+		if err != nil {
+			return err
+		}
+		if dep.Status == domain.StatusClosed {
+			if !forceFlag {
+				return trError(msgDepClosed, map[string]any{"Dep": depID, "ID": ticketID}, nil)
+			}
+			fmt.Fprintln(os.Stderr, tr(msgWarningPrefix, nil), tr(msgDepClosedWarning, map[string]any{"Dep": depID, "ID": ticketID}))
+		}
+
 		ticket.Deps = append(ticket.Deps, depID)
 		if err := store.Write(ticket); err != nil {
 			return err
 		}
 
-		fmt.Printf("Added dependency: %s -> %s\n", ticketID, depID)
+		fmt.Println(tr(msgAddedDep, map[string]any{"ID": ticketID, "Dep": depID}))
 		return nil
 	},
 }
@@ -69,14 +84,14 @@ var depRemoveCmd = &cobra.Command{
 	Long:    `Remove a dependency from a ticket.`,
 	Args:    cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ticketID, err := store.ResolveID(args[0])
+		ticketID, err := resolveID(store, args[0])
 		if err != nil {
-			return fmt.Errorf("invalid ticket: %w", err)
+			return trWrap(msgDepInvalidTicket, nil, err)
 		}
 
-		depID, err := store.ResolveID(args[1])
+		depID, err := resolveID(store, args[1])
 		if err != nil {
-			return fmt.Errorf("invalid dependency: %w", err)
+			return trWrap(msgDepInvalid, nil, err)
 		}
 
 		ticket, err := store.Read(ticketID)
@@ -86,7 +101,7 @@ var depRemoveCmd = &cobra.Command{
 
 		newDeps, found := removeFromSlice(ticket.Deps, depID)
 		if !found {
-			return fmt.Errorf("dependency %s not found on %s", depID, ticketID)
+			return trError(msgDepNotOn, map[string]any{"Dep": depID, "ID": ticketID}, nil)
 		}
 
 		ticket.Deps = newDeps
@@ -94,7 +109,7 @@ var depRemoveCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Printf("Removed dependency: %s -> %s\n", ticketID, depID)
+		fmt.Println(tr(msgRemovedDep, map[string]any{"ID": ticketID, "Dep": depID}))
 		return nil
 	},
 }
@@ -137,14 +152,14 @@ var depTreeCmd = &cobra.Command{
 			return nil
 		}
 
-		ticketID, err := store.ResolveID(args[0])
+		ticketID, err := resolveID(store, args[0])
 		if err != nil {
 			return err
 		}
 
 		ticket, ok := ticketMap[ticketID]
 		if !ok {
-			return fmt.Errorf("ticket not found: %s", ticketID)
+			return trError(msgNotFound, map[string]any{"ID": ticketID}, nil)
 		}
 
 		printDepTree(ticket, ticketMap, "", true)
@@ -152,8 +167,82 @@ var depTreeCmd = &cobra.Command{
 	},
 }
 
-// checkCycle checks if adding depID as a dependency of ticketID would create a cycle.
-func checkCycle(ticketID, depID string) error {
+var depGraphFlags struct {
+	format string
+}
+
+// depGraph is the dependency graph as emitted by tk dep graph.
+type depGraph struct {
+	Nodes []depGraphNode `json:"nodes"`
+	Edges []depGraphEdge `json:"edges"`
+}
+
+// depGraphNode is one ticket in the dependency graph.
+type depGraphNode struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Type     string `json:"type,omitempty"`
+	Priority int    `json:"priority"`
+	Assignee string `json:"assignee,omitempty"`
+}
+
+// depGraphEdge records that From depends on To.
+type depGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+var depGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the dependency graph",
+	Long: `Print the dependency graph of all tickets as adjacency JSON: one node per
+ticket with its status and priority, and one edge per dependency, from the
+dependent ticket to the ticket it depends on. An edge may point to a ticket
+that is not a node, e.g. one that was archived.
+
+Examples:
+  tk dep graph --format json > graph.json
+  tk dep graph | jq '.edges[] | select(.to == "tic-a1b2") | .from'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if depGraphFlags.format != "json" {
+			return trError(msgDepBadFormat, map[string]any{"Format": depGraphFlags.format}, nil)
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+		return exportJSON(os.Stdout, buildDepGraph(tickets))
+	},
+}
+
+// buildDepGraph returns the nodes and dependency edges of tickets, in ticket
+// order with each ticket's edges in dependency order.
+func buildDepGraph(tickets []*domain.Ticket) depGraph {
+	graph := depGraph{
+		Nodes: make([]depGraphNode, 0, len(tickets)),
+		Edges: []depGraphEdge{},
+	}
+	for _, t := range tickets {
+		graph.Nodes = append(graph.Nodes, depGraphNode{
+			ID:       t.ID,
+			Title:    t.Title,
+			Status:   string(t.Status),
+			Type:     string(t.Type),
+			Priority: t.Priority,
+			Assignee: t.Assignee,
+		})
+		for _, dep := range t.Deps {
+			graph.Edges = append(graph.Edges, depGraphEdge{From: t.ID, To: dep})
+		}
+	}
+	return graph
+}
+
+// checkCycle checks if adding depIDs as dependencies of ticketID would create a cycle.
+func checkCycle(ticketID string, depIDs ...string) error {
 	tickets, err := store.List()
 	if err != nil {
 		return err
@@ -164,14 +253,14 @@ func checkCycle(ticketID, depID string) error {
 		ticketMap[t.ID] = t
 	}
 
-	// Build adjacency list including the proposed new edge
+	// Build adjacency list including the proposed new edges
 	deps := make(map[string][]string)
 	for _, t := range tickets {
 		deps[t.ID] = t.Deps
 	}
 
-	// Add proposed dependency
-	deps[ticketID] = append(deps[ticketID], depID)
+	// Add proposed dependencies
+	deps[ticketID] = append(slices.Clone(deps[ticketID]), depIDs...)
 
 	// Check if ticketID is reachable from depID (which would mean a cycle)
 	visited := make(map[string]bool)
@@ -193,8 +282,11 @@ func checkCycle(ticketID, depID string) error {
 		return false
 	}
 
-	if hasCycle(depID, ticketID) {
-		return fmt.Errorf("adding dependency would create a cycle: %s -> %s", ticketID, depID)
+	for _, depID := range depIDs {
+		clear(visited)
+		if hasCycle(depID, ticketID) {
+			return trError(msgDepCycle, map[string]any{"ID": ticketID, "Dep": depID}, nil)
+		}
 	}
 
 	return nil
@@ -228,7 +320,7 @@ func formatTreeNode(ticket *domain.Ticket) string {
 
 // formatMissingNode returns a formatted string for a missing dependency.
 func formatMissingNode(depID string) string {
-	return fmt.Sprintf("[?] %s - (not found)", depID)
+	return fmt.Sprintf("%s %s - (not found)", statusSymbols[symbolUnknown], depID)
 }
 
 // buildDepTreeString builds a dependency tree string recursively.
@@ -236,9 +328,9 @@ func buildDepTreeString(ticket *domain.Ticket, ticketMap map[string]*domain.Tick
 	var sb strings.Builder
 
 	// Determine connector
-	connector := "├── "
+	connector := treeConnectors.branch
 	if isLast {
-		connector = "└── "
+		connector = treeConnectors.last
 	}
 
 	// Format this ticket
@@ -255,7 +347,7 @@ func buildDepTreeString(ticket *domain.Ticket, ticketMap map[string]*domain.Tick
 		if isLast {
 			childPrefix += "    "
 		} else {
-			childPrefix += "│   "
+			childPrefix += treeConnectors.pipe
 		}
 	}
 
@@ -265,9 +357,9 @@ func buildDepTreeString(ticket *domain.Ticket, ticketMap map[string]*domain.Tick
 		dep, ok := ticketMap[depID]
 		if !ok {
 			// Dependency ticket not found, show as missing
-			depConnector := "├── "
+			depConnector := treeConnectors.branch
 			if i == len(deps)-1 {
-				depConnector = "└── "
+				depConnector = treeConnectors.last
 			}
 			sb.WriteString(childPrefix + depConnector + formatMissingNode(depID) + "\n")
 			continue
@@ -284,14 +376,6 @@ func printDepTree(ticket *domain.Ticket, ticketMap map[string]*domain.Ticket, pr
 	fmt.Print(buildDepTreeString(ticket, ticketMap, prefix, isLast))
 }
 
-// statusIndicator returns a status indicator for display.
-func statusIndicator(status domain.Status) string {
-	if symbol, ok := domain.StatusSymbols[status]; ok {
-		return symbol
-	}
-	return "[?]"
-}
-
 // TopologicalSort returns tickets in topological order based on dependencies.
 // Dependencies come before dependents in the returned slice.
 func TopologicalSort(tickets []*domain.Ticket) ([]*domain.Ticket, error) {
@@ -345,7 +429,7 @@ func TopologicalSort(tickets []*domain.Ticket) ([]*domain.Ticket, error) {
 	}
 
 	if len(sorted) != len(tickets) {
-		return nil, fmt.Errorf("cycle detected in dependencies")
+		return nil, trError(msgDepCycleDetected, nil, nil)
 	}
 
 	return sorted, nil
@@ -421,24 +505,27 @@ var depCheckCmd = &cobra.Command{
 
 		cycles := DetectCycles(tickets)
 		if len(cycles) == 0 {
-			fmt.Println("No cycles detected")
+			fmt.Println(tr(msgNoCyclesFound, nil))
 			return nil
 		}
 
-		fmt.Printf("Found %d cycle(s):\n", len(cycles))
+		fmt.Println(tr(msgDepCyclesFound, map[string]any{"Count": len(cycles)}))
 		for i, cycle := range cycles {
 			fmt.Printf("  %d: %s\n", i+1, strings.Join(cycle, " -> "))
 		}
 
-		return fmt.Errorf("dependency cycles detected")
+		return trError(msgDepCyclesError, nil, nil)
 	},
 }
 
 func init() {
 	depTreeCmd.Flags().BoolVar(&depTreeFlags.full, "full", false, "Show full dependency tree for all tickets")
+	depGraphCmd.Flags().StringVar(&depGraphFlags.format, "format", "json", "Output format (json)")
 
 	depCmd.AddCommand(depAddCmd)
 	depCmd.AddCommand(depRemoveCmd)
+	depCmd.AddCommand(depEditCmd)
 	depCmd.AddCommand(depTreeCmd)
 	depCmd.AddCommand(depCheckCmd)
+	depCmd.AddCommand(depGraphCmd)
 }
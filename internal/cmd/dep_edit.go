@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+	"github.com/radutopala/ticket/internal/storage"
+)
+
+var depEditCmd = &cobra.Command{
+	Use:   "edit <ticket-id>",
+	Short: "Edit a ticket's dependencies in $EDITOR",
+	Long: `Open the ticket's dependencies in $EDITOR, one ID per line with its title as a
+comment, and apply the additions and removals on save. Partial IDs may be
+added. The new dependencies are checked for cycles and, like dep add, closed
+tickets are refused unless --force is given; nothing is changed on error.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgDepInvalidTicket, nil, err)
+		}
+		if ticket.Locked {
+			return storage.LockedError(ticket)
+		}
+
+		tmp, err := os.CreateTemp("", ticket.ID+"-deps-*.txt")
+		if err != nil {
+			return fmt.Errorf("failed to create temp file: %w", err)
+		}
+		defer func() { _ = os.Remove(tmp.Name()) }()
+		if _, err := tmp.WriteString(formatDepList(ticket)); err != nil {
+			_ = tmp.Close()
+			return fmt.Errorf("failed to write temp file: %w", err)
+		}
+		if err := tmp.Close(); err != nil {
+			return fmt.Errorf("failed to write temp file: %w", err)
+		}
+
+		if err := runEditor(tmp.Name()); err != nil {
+			return err
+		}
+		edited, err := os.ReadFile(tmp.Name())
+		if err != nil {
+			return fmt.Errorf("failed to read edited dependencies: %w", err)
+		}
+
+		deps, err := parseDepList(ticket, string(edited))
+		if err != nil {
+			return err
+		}
+
+		var added, removed []string
+		for _, id := range deps {
+			if !slices.Contains(ticket.Deps, id) {
+				added = append(added, id)
+			}
+		}
+		for _, id := range ticket.Deps {
+			if !slices.Contains(deps, id) {
+				removed = append(removed, id)
+			}
+		}
+		if len(added) == 0 && len(removed) == 0 {
+			fmt.Println(tr(msgDepNoChanges, nil))
+			return nil
+		}
+
+		if err := checkCycle(ticket.ID, added...); err != nil {
+			return err
+		}
+		for _, id := range added {
+			dep, err := store.Read(id)
+			if err != nil {
+				return err
+			}
+			if dep.Status == domain.StatusClosed {
+				if !forceFlag {
+					return trError(msgDepClosed, map[string]any{"Dep": id, "ID": ticket.ID}, nil)
+				}
+				fmt.Fprintln(os.Stderr, tr(msgWarningPrefix, nil), tr(msgDepClosedWarning, map[string]any{"Dep": id, "ID": ticket.ID}))
+			}
+		}
+
+		ticket.Deps = deps
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+
+		for _, id := range added {
+			fmt.Println(tr(msgAddedDep, map[string]any{"ID": ticket.ID, "Dep": id}))
+		}
+		for _, id := range removed {
+			fmt.Println(tr(msgRemovedDep, map[string]any{"ID": ticket.ID, "Dep": id}))
+		}
+		return nil
+	},
+}
+
+// formatDepList renders the dependencies of t for editing, with each
+// dependency's status and title as a comment.
+func formatDepList(t *domain.Ticket) string {
+	var buf strings.Builder
+	buf.WriteString(tr(msgDepEditHeader, map[string]any{"ID": t.ID, "Title": t.Title}) + "\n")
+	for _, id := range t.Deps {
+		dep, err := store.Read(id)
+		if err != nil {
+			fmt.Fprintf(&buf, "%s  # %s\n", id, tr(msgDepEditMissing, nil))
+			continue
+		}
+		fmt.Fprintf(&buf, "%s  # [%s] %s\n", id, dep.Status, dep.Title)
+	}
+	return buf.String()
+}
+
+// parseDepList reads the edited dependency list of t, resolving partial IDs.
+// Existing dependencies are kept as written even if the ticket is missing.
+func parseDepList(t *domain.Ticket, text string) ([]string, error) {
+	var deps []string
+	for i, line := range strings.Split(text, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 1 {
+			return nil, trError(msgDepEditLineIDs, map[string]any{"Line": i + 1, "Text": strconv.Quote(strings.TrimSpace(line))}, nil)
+		}
+
+		id := fields[0]
+		if !slices.Contains(t.Deps, id) {
+			resolved, err := resolveID(store, id)
+			if err != nil {
+				return nil, trWrap(msgDepEditLineDep, map[string]any{"Line": i + 1}, err)
+			}
+			id = resolved
+		}
+		if id == t.ID {
+			return nil, trError(msgDepEditLineSelf, map[string]any{"Line": i + 1}, nil)
+		}
+		if !slices.Contains(deps, id) {
+			deps = append(deps, id)
+		}
+	}
+	return deps, nil
+}
@@ -178,6 +178,48 @@ func (s *DepSuite) TestStatusIndicator() {
 	require.Equal(s.T(), "[?]", statusIndicator(domain.Status("unknown")))
 }
 
+func (s *DepSuite) TestSetStatusSymbols() {
+	defer func() { require.NoError(s.T(), setStatusSymbols("", nil)) }()
+
+	require.NoError(s.T(), setStatusSymbols(SymbolSetEmoji, map[string]string{"in_progress": "🚧"}))
+	require.Equal(s.T(), "⚪", statusIndicator(domain.StatusOpen))
+	require.Equal(s.T(), "🚧", statusIndicator(domain.StatusInProgress))
+	require.Equal(s.T(), "❓", statusIndicator(domain.Status("review")))
+	require.Equal(s.T(), "❓ tic-404 - (not found)", formatMissingNode("tic-404"))
+
+	require.NoError(s.T(), setStatusSymbols("", map[string]string{"unknown": "(?)"}))
+	require.Equal(s.T(), "[x]", statusIndicator(domain.StatusClosed))
+	require.Equal(s.T(), "(?)", statusIndicator(domain.Status("review")))
+
+	require.ErrorContains(s.T(), setStatusSymbols("fancy", nil), `invalid symbol_set "fancy": must be one of ascii, emoji, nerd`)
+	require.ErrorContains(s.T(), setStatusSymbols("", map[string]string{"done": "v"}), `invalid status_symbols key "done"`)
+}
+
+func (s *DepSuite) TestSetASCIIOutput() {
+	defer func() {
+		setASCIIOutput(false)
+		require.NoError(s.T(), setStatusSymbols("", nil))
+	}()
+
+	require.NoError(s.T(), setStatusSymbols(SymbolSetEmoji, nil))
+	setASCIIOutput(true)
+	require.Equal(s.T(), "[open]", statusIndicator(domain.StatusOpen))
+	require.Equal(s.T(), "[in_progress]", statusIndicator(domain.StatusInProgress))
+	require.Equal(s.T(), "[unknown] tic-404 - (not found)", formatMissingNode("tic-404"))
+
+	parent := &domain.Ticket{ID: "tic-001", Title: "Middle", Status: domain.StatusOpen, Deps: []string{"tic-002", "tic-404"}}
+	ticketMap := map[string]*domain.Ticket{
+		"tic-002": {ID: "tic-002", Title: "Child", Status: domain.StatusClosed},
+	}
+	require.Equal(s.T(), "|   |-- [open] tic-001 - Middle\n"+
+		"|   |   |-- [closed] tic-002 - Child\n"+
+		"|   |   `-- [unknown] tic-404 - (not found)\n",
+		buildDepTreeString(parent, ticketMap, "|   ", false))
+
+	setASCIIOutput(false)
+	require.Equal(s.T(), "    └── [closed] tic-002 - Child\n", buildDepTreeString(ticketMap["tic-002"], nil, "    ", true))
+}
+
 func (s *DepSuite) TestFindRootTickets() {
 	now := time.Now()
 	tickets := []*domain.Ticket{
@@ -430,3 +472,21 @@ func (s *DepSuite) TestPrintDepTree() {
 		})
 	}
 }
+
+func (s *DepSuite) TestBuildDepGraph() {
+	tickets := []*domain.Ticket{
+		{ID: "tic-a", Title: "A", Status: domain.StatusOpen, Type: domain.TypeTask, Priority: 1, Deps: []string{"tic-b", "tic-gone"}},
+		{ID: "tic-b", Title: "B", Status: domain.StatusClosed, Priority: 2},
+	}
+
+	graph := buildDepGraph(tickets)
+	require.Equal(s.T(), []depGraphNode{
+		{ID: "tic-a", Title: "A", Status: "open", Type: "task", Priority: 1},
+		{ID: "tic-b", Title: "B", Status: "closed", Priority: 2},
+	}, graph.Nodes)
+	require.Equal(s.T(), []depGraphEdge{{From: "tic-a", To: "tic-b"}, {From: "tic-a", To: "tic-gone"}}, graph.Edges)
+
+	var buf bytes.Buffer
+	require.NoError(s.T(), exportJSON(&buf, buildDepGraph(nil)))
+	require.JSONEq(s.T(), `{"nodes": [], "edges": []}`, buf.String())
+}
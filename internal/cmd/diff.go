@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// showDiffFlag prints a unified diff of every ticket file a command changes.
+var showDiffFlag bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <id>",
+	Short: "Show changes to a ticket since the last git commit",
+	Long: `Print a unified diff of the ticket file against its version in git HEAD.
+A ticket that has never been committed is shown as entirely added.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := resolveID(store, args[0])
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(store.TicketsDir(), id+".md")
+		current, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read ticket file: %w", err)
+		}
+
+		committed, err := gitCommittedFile(path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(unifiedDiff(displayPath(path), committed, current))
+		return nil
+	},
+}
+
+// gitCommittedFile returns the contents of path in git HEAD, or nil if the
+// file is not in HEAD.
+func gitCommittedFile(path string) ([]byte, error) {
+	dir, name := filepath.Split(path)
+	if err := checkGitWorkTree(dir); err != nil {
+		return nil, err
+	}
+
+	show := exec.Command("git", "show", "HEAD:./"+name)
+	show.Dir = dir
+	data, err := show.Output()
+	if err != nil {
+		// Uncommitted file, or a repository without commits
+		return nil, nil
+	}
+	return data, nil
+}
+
+// checkGitWorkTree fails if dir is not inside a git working tree.
+func checkGitWorkTree(dir string) error {
+	check := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	check.Dir = dir
+	if err := check.Run(); err != nil {
+		return trError(msgDiffNotGit, map[string]any{"Dir": dir}, nil)
+	}
+	return nil
+}
+
+// onTicketChange is the storage change callback: it prints the diff of the
+// change with --show-diff and queues the notify command for watched tickets,
+// which runs once the command finishes and its locks are released.
+func onTicketChange(id, path string, before, after []byte) {
+	if showDiffFlag {
+		printChangeDiff(path, before, after)
+	}
+	if cfg.NotifyCommand != "" {
+		pendingNotifications = append(pendingNotifications, ticketChange{id: id, path: path, before: before, after: after})
+	}
+}
+
+// printChangeDiff prints the diff of a change to the ticket file at path for --show-diff.
+func printChangeDiff(path string, before, after []byte) {
+	fmt.Print(unifiedDiff(displayPath(path), before, after))
+}
+
+// diffOp is one line of an edit script: ' ' kept, '-' removed or '+' added.
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// unifiedDiff returns a unified diff of the file at path from before to
+// after, or an empty string if they are equal. nil contents are shown as
+// /dev/null, as git does for added and deleted files.
+func unifiedDiff(path string, before, after []byte) string {
+	if bytes.Equal(before, after) {
+		return ""
+	}
+
+	var b strings.Builder
+	from, to := "a/"+path, "b/"+path
+	if before == nil {
+		from = "/dev/null"
+	}
+	if after == nil {
+		to = "/dev/null"
+	}
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", from, to)
+
+	ops := diffLines(splitLines(before), splitLines(after))
+	for start := 0; start < len(ops); {
+		// Find the next change and extend the hunk while changes are close
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		last := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				if i-last > 2*diffContext+1 {
+					break
+				}
+				last = i
+			}
+		}
+
+		lo := max(first-diffContext, start)
+		hi := min(last+diffContext+1, len(ops))
+		writeHunk(&b, ops, lo, hi)
+		start = hi
+	}
+	return b.String()
+}
+
+// writeHunk writes ops[lo:hi] as one hunk with its @@ header.
+func writeHunk(b *strings.Builder, ops []diffOp, lo, hi int) {
+	aPos, bPos := 0, 0
+	for _, op := range ops[:lo] {
+		if op.kind != '+' {
+			aPos++
+		}
+		if op.kind != '-' {
+			bPos++
+		}
+	}
+	aCount, bCount := 0, 0
+	for _, op := range ops[lo:hi] {
+		if op.kind != '+' {
+			aCount++
+		}
+		if op.kind != '-' {
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(aPos, aCount), hunkRange(bPos, bCount))
+	for _, op := range ops[lo:hi] {
+		b.WriteByte(op.kind)
+		b.WriteString(op.line)
+		b.WriteByte('\n')
+	}
+}
+
+// hunkRange formats a hunk's start line and length; pos is the number of
+// lines before the hunk.
+func hunkRange(pos, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", pos)
+	case 1:
+		return fmt.Sprintf("%d", pos+1)
+	default:
+		return fmt.Sprintf("%d,%d", pos+1, count)
+	}
+}
+
+// diffLines returns an edit script turning a into b using a longest common
+// subsequence, which is plenty fast for ticket-sized files.
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+// splitLines splits data into lines without their trailing newlines.
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
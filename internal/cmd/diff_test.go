@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffSuite struct {
+	suite.Suite
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(DiffSuite))
+}
+
+func (s *DiffSuite) TestUnifiedDiff() {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			name:   "equal",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "",
+		},
+		{
+			name:   "single change with context",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n",
+			after:  "1\n2\n3\n4\nfive\n6\n7\n8\n",
+			want: "--- a/t.md\n+++ b/t.md\n" +
+				"@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name:   "distant changes make separate hunks",
+			before: "a\n1\n2\n3\n4\n5\n6\n7\nb\n",
+			after:  "A\n1\n2\n3\n4\n5\n6\n7\nB\n",
+			want: "--- a/t.md\n+++ b/t.md\n" +
+				"@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n" +
+				"@@ -6,4 +6,4 @@\n 5\n 6\n 7\n-b\n+B\n",
+		},
+		{
+			name:   "nearby changes share a hunk",
+			before: "a\n1\n2\n3\n4\n5\n6\nb\n",
+			after:  "A\n1\n2\n3\n4\n5\n6\nB\n",
+			want: "--- a/t.md\n+++ b/t.md\n" +
+				"@@ -1,8 +1,8 @@\n-a\n+A\n 1\n 2\n 3\n 4\n 5\n 6\n-b\n+B\n",
+		},
+		{
+			name:   "insertion",
+			before: "a\nb\n",
+			after:  "a\nnew\nb\n",
+			want:   "--- a/t.md\n+++ b/t.md\n@@ -1,2 +1,3 @@\n a\n+new\n b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			require.Equal(s.T(), tt.want, unifiedDiff("t.md", []byte(tt.before), []byte(tt.after)))
+		})
+	}
+}
+
+func (s *DiffSuite) TestUnifiedDiffCreateAndDelete() {
+	require.Equal(s.T(), "--- /dev/null\n+++ b/t.md\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		unifiedDiff("t.md", nil, []byte("a\nb\n")))
+	require.Equal(s.T(), "--- a/t.md\n+++ /dev/null\n@@ -1 +0,0 @@\n-a\n",
+		unifiedDiff("t.md", []byte("a\n"), nil))
+}
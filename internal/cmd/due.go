@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var dueCmd = &cobra.Command{
+	Use:   "due <id> [date]",
+	Short: "Set or clear a ticket's due date",
+	Long: `Set the due date of a ticket as YYYY-MM-DD, RFC3339, or a duration from now
+like 3d or 2w. Without a date the due date is cleared.
+
+Examples:
+  tk due abc1 2025-03-01
+  tk due abc1 2w
+  tk due abc1             # Clear the due date`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var due time.Time
+		if len(args) == 2 {
+			var err error
+			if due, err = parseDueDate(args[1], time.Now().UTC()); err != nil {
+				return err
+			}
+		}
+
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgResolveFailed, nil, err)
+		}
+
+		ticket.Due = due
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+
+		if due.IsZero() {
+			fmt.Println(tr(msgDueCleared, map[string]any{"ID": ticket.ID}))
+		} else {
+			fmt.Println(tr(msgDueSet, map[string]any{"ID": ticket.ID, "Due": formatDate(due)}))
+		}
+		return nil
+	},
+}
@@ -1,41 +1,150 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+	"github.com/radutopala/ticket/internal/storage"
 )
 
+var editFlags struct {
+	section string
+}
+
 var editCmd = &cobra.Command{
 	Use:   "edit <id>",
 	Short: "Open ticket in editor",
-	Long:  `Open the ticket file in $EDITOR for editing. Supports partial ID matching.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Open the ticket file in $EDITOR for editing. Supports partial ID matching.
+The edit is saved like any other update: it is journaled (so tk undo and
+tk history see it) and refused if the ticket changed while the editor was open.
+The file is saved as typed, apart from its revision and update stamps. If it
+cannot be saved, e.g. because it no longer parses, the edit is kept in a
+temporary file whose path is printed.
+
+With --section, only that body section is opened and written back, e.g.
+tk edit abc1 --section "Test Plan". A custom section that does not exist yet
+is added.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id, err := store.ResolveID(args[0])
+		id, err := resolveID(store, args[0])
 		if err != nil {
 			return err
 		}
 
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			editor = "vi"
+		ticket, err := store.Read(id)
+		if err != nil {
+			return err
+		}
+		if ticket.Locked {
+			return storage.LockedError(ticket)
 		}
 
-		ticketPath := filepath.Join(store.TicketsDir(), id+".md")
+		if editFlags.section == "" {
+			return editTicketFile(ticket)
+		}
+
+		if err := checkSectionName(editFlags.section); err != nil {
+			return err
+		}
+		content, _ := ticket.Section(editFlags.section)
 
-		editorCmd := exec.Command(editor, ticketPath)
-		editorCmd.Stdin = os.Stdin
-		editorCmd.Stdout = os.Stdout
-		editorCmd.Stderr = os.Stderr
+		return editAndSave(id+"-*.md", content+"\n", func(edited []byte) error {
+			updated := strings.TrimSpace(string(edited))
+			if updated == content {
+				return nil
+			}
+			ticket.SetSection(strings.TrimSpace(editFlags.section), updated)
+			return store.Write(ticket)
+		})
+	},
+}
 
-		if err := editorCmd.Run(); err != nil {
-			return fmt.Errorf("editor failed: %w", err)
+// editTicketFile opens a copy of ticket's file in $EDITOR and saves the edited
+// file as it is through the store, so the edit is journaled and checked for
+// concurrent changes like any other update.
+func editTicketFile(ticket *domain.Ticket) error {
+	original, err := os.ReadFile(filepath.Join(store.TicketsDir(), ticket.ID+".md"))
+	if err != nil {
+		return fmt.Errorf("failed to read ticket file: %w", err)
+	}
+	return editAndSave(ticket.ID+"-*.md", string(original), func(edited []byte) error {
+		if bytes.Equal(edited, original) {
+			return nil
 		}
+		return store.WriteFile(ticket.ID, edited, ticket.Revision)
+	})
+}
 
+// editInTempFile opens content in $EDITOR in a temporary file named after
+// pattern and returns the edited contents.
+func editInTempFile(pattern, content string) ([]byte, error) {
+	var edited []byte
+	err := editAndSave(pattern, content, func(data []byte) error {
+		edited = data
 		return nil
-	},
+	})
+	return edited, err
+}
+
+// editAndSave opens content in $EDITOR in a temporary file named after pattern
+// and passes the edited contents to save. If save fails, the file is kept so
+// the edit is not lost, and the error says where it is.
+func editAndSave(pattern, content string, save func([]byte) error) error {
+	tmp, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err := tmp.WriteString(content); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	if err := runEditor(tmp.Name()); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	edited, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return fmt.Errorf("failed to read edited file %s: %w", tmp.Name(), err)
+	}
+	if err := save(edited); err != nil {
+		return fmt.Errorf("%w\n%s", err, tr(msgEditKept, map[string]any{"Path": tmp.Name()}))
+	}
+	_ = os.Remove(tmp.Name())
+	return nil
+}
+
+// runEditor opens path in $EDITOR (vi by default) attached to the terminal.
+func runEditor(path string) error {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	editorCmd := exec.Command(editor, path)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+
+	if err := editorCmd.Run(); err != nil {
+		return trWrap(msgEditorFailed, nil, err)
+	}
+
+	return nil
+}
+
+func init() {
+	editCmd.Flags().StringVar(&editFlags.section, "section", "", "Edit only this body section (added if missing)")
 }
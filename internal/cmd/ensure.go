@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/radutopala/ticket/internal/domain"
+	"github.com/radutopala/ticket/internal/storage"
+)
+
+var ensureFlags struct {
+	externalRef string
+	title       string
+	status      string
+	description string
+	design      string
+	acceptance  string
+	ticketType  string
+	priority    int
+	estimate    float64
+	due         string
+	assignee    string
+	parent      string
+	tags        []string
+}
+
+var ensureCmd = &cobra.Command{
+	Use:   "ensure --external-ref <ref>",
+	Short: "Create or update the ticket with an external reference",
+	Long: `Make the ticket with the given external reference match the given fields,
+creating it if no ticket has that reference, and print its ID.
+
+Only the fields passed as flags are set; others keep their values, or the
+defaults of tk create for a new ticket. --tags replaces the ticket's tags, and
+an empty --due or --parent clears it. A ticket that already matches is not
+written at all, so sync scripts can run again and again without bumping
+revisions or filling the journal.
+
+Examples:
+  tk ensure --external-ref gh-123 --title "Fix login" --status open
+  tk ensure --external-ref gh-123 --status closed
+  id=$(tk ensure --external-ref JIRA-456 --title "Migrate DB" -p 1 --tags db)`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if ensureFlags.externalRef == "" {
+			return trError(msgEnsureNeedsRef, nil, nil)
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+		var matches []string
+		var ticket *domain.Ticket
+		for _, t := range tickets {
+			if t.ExternalRef == ensureFlags.externalRef {
+				matches = append(matches, t.ID)
+				ticket = t
+			}
+		}
+		if len(matches) > 1 {
+			return trError(msgEnsureAmbiguous, map[string]any{"Ref": ensureFlags.externalRef, "Count": len(matches), "IDs": strings.Join(matches, ", ")}, nil)
+		}
+
+		now := time.Now().UTC()
+		if ticket == nil {
+			return createEnsured(cmd.Flags(), now)
+		}
+
+		before := *ticket
+		if err := applyEnsureFlags(cmd.Flags(), ticket, now); err != nil {
+			return err
+		}
+		if len(storage.Changes(&before, ticket)) > 0 {
+			if err := enforcePolicy(ticket); err != nil {
+				return trWrap(msgCannotUpdate, map[string]any{"ID": ticket.ID}, err)
+			}
+			if err := store.Write(ticket); err != nil {
+				return fmt.Errorf("failed to update ticket: %w", err)
+			}
+		}
+
+		fmt.Println(ticket.ID)
+		return nil
+	},
+}
+
+// createEnsured creates a ticket with the tk create defaults and the ensure
+// flags applied on top, and prints its ID.
+func createEnsured(flags *pflag.FlagSet, now time.Time) error {
+	ticket := &domain.Ticket{
+		Status:      domain.StatusOpen,
+		Type:        domain.TypeTask,
+		Priority:    domain.DefaultPriority,
+		Assignee:    currentUser(),
+		ExternalRef: ensureFlags.externalRef,
+		Created:     now,
+	}
+	if err := applyEnsureFlags(flags, ticket, now); err != nil {
+		return err
+	}
+
+	prefix, err := cfg.IDPrefix(ticket.Type)
+	if err != nil {
+		return err
+	}
+	ticket.ID, err = storage.GenerateID(prefix)
+	if err != nil {
+		return fmt.Errorf("failed to generate ID: %w", err)
+	}
+
+	if err := enforcePolicy(ticket); err != nil {
+		return trWrap(msgCannotCreate, nil, err)
+	}
+	if err := store.EnsureDir(); err != nil {
+		return fmt.Errorf("failed to create tickets directory: %w", err)
+	}
+	if err := store.Write(ticket); err != nil {
+		return fmt.Errorf("failed to write ticket: %w", err)
+	}
+
+	fmt.Println(ticket.ID)
+	return nil
+}
+
+// applyEnsureFlags sets the fields of ticket whose flags were passed.
+func applyEnsureFlags(flags *pflag.FlagSet, ticket *domain.Ticket, now time.Time) error {
+	if flags.Changed("title") {
+		ticket.Title = ensureFlags.title
+	}
+	if flags.Changed("description") {
+		ticket.Description = ensureFlags.description
+	}
+	if flags.Changed("design") {
+		ticket.Design = ensureFlags.design
+	}
+	if flags.Changed("acceptance") {
+		ticket.Acceptance = ensureFlags.acceptance
+	}
+	if flags.Changed("assignee") {
+		ticket.Assignee = ensureFlags.assignee
+	}
+
+	if flags.Changed("type") {
+		t, err := domain.ParseType(ensureFlags.ticketType)
+		if err != nil {
+			return err
+		}
+		ticket.Type = t
+	}
+	if flags.Changed("priority") {
+		if ensureFlags.priority < domain.MinPriority || ensureFlags.priority > domain.MaxPriority {
+			return trError(msgInvalidPriority, map[string]any{"Priority": ensureFlags.priority, "Min": domain.MinPriority, "Max": domain.MaxPriority}, nil)
+		}
+		ticket.Priority = ensureFlags.priority
+	}
+	if flags.Changed("estimate") {
+		if err := validateEstimate(ensureFlags.estimate); err != nil {
+			return err
+		}
+		ticket.Estimate = ensureFlags.estimate
+	}
+	if flags.Changed("due") {
+		ticket.Due = time.Time{}
+		if ensureFlags.due != "" {
+			due, err := parseDueDate(ensureFlags.due, now)
+			if err != nil {
+				return err
+			}
+			ticket.Due = due
+		}
+	}
+	if flags.Changed("parent") {
+		ticket.Parent = ""
+		if ensureFlags.parent != "" {
+			parent, err := resolveID(store, ensureFlags.parent)
+			if err != nil {
+				return trError(msgParentNotFound, map[string]any{"ID": ensureFlags.parent}, nil)
+			}
+			ticket.Parent = parent
+		}
+	}
+	if flags.Changed("tags") {
+		ticket.Tags = ensureFlags.tags
+	}
+
+	if flags.Changed("status") {
+		status, err := domain.ParseStatus(ensureFlags.status)
+		if err != nil {
+			return err
+		}
+		if status != ticket.Status {
+			ticket.SetStatus(status, now)
+		}
+	}
+	return nil
+}
+
+func init() {
+	ensureCmd.Flags().StringVar(&ensureFlags.externalRef, "external-ref", "", "External reference identifying the ticket (e.g., gh-123)")
+	ensureCmd.Flags().StringVar(&ensureFlags.title, "title", "", "Title")
+	ensureCmd.Flags().StringVar(&ensureFlags.status, "status", "", "Status (open|in_progress|closed)")
+	ensureCmd.Flags().StringVarP(&ensureFlags.description, "description", "d", "", "Description text")
+	ensureCmd.Flags().StringVar(&ensureFlags.design, "design", "", "Design notes")
+	ensureCmd.Flags().StringVar(&ensureFlags.acceptance, "acceptance", "", "Acceptance criteria")
+	ensureCmd.Flags().StringVarP(&ensureFlags.ticketType, "type", "t", "", "Type (bug|feature|task|epic|chore)")
+	ensureCmd.Flags().IntVarP(&ensureFlags.priority, "priority", "p", domain.DefaultPriority, fmt.Sprintf("Priority %d-%d, %d=highest", domain.MinPriority, domain.MaxPriority, domain.MinPriority))
+	ensureCmd.Flags().Float64VarP(&ensureFlags.estimate, "estimate", "e", 0, "Estimate in points (or any unit the project uses)")
+	ensureCmd.Flags().StringVar(&ensureFlags.due, "due", "", "Due date (YYYY-MM-DD, or from now like 3d or 2w); empty clears it")
+	ensureCmd.Flags().StringVarP(&ensureFlags.assignee, "assignee", "a", "", "Assignee")
+	ensureCmd.Flags().StringVar(&ensureFlags.parent, "parent", "", "Parent ticket ID; empty clears it")
+	ensureCmd.Flags().StringSliceVar(&ensureFlags.tags, "tags", nil, "Comma-separated tags, replacing the current ones")
+}
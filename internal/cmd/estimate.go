@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// estimateRollup is a ticket's estimate summed over its descendants.
+type estimateRollup struct {
+	// Total sums the estimates of all leaf descendants.
+	Total float64
+	// Remaining sums the estimates of leaf descendants that are not closed.
+	Remaining float64
+	// Children is the number of direct children.
+	Children int
+}
+
+var estimateCmd = &cobra.Command{
+	Use:   "estimate <id> <points>",
+	Short: "Set a ticket's estimate",
+	Long: `Set the estimate of a ticket, in points or whatever unit the project uses.
+An estimate of 0 clears it.
+
+A parent's own estimate is replaced by the sum of its children's estimates in
+tk show and tk export, so epic sizing follows its decomposition.
+
+Examples:
+  tk estimate abc1 3
+  tk estimate abc1 0.5`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		estimate, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return trError(msgEstimateNotNumber, map[string]any{"Estimate": strconv.Quote(args[1])}, nil)
+		}
+		if err := validateEstimate(estimate); err != nil {
+			return err
+		}
+
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgResolveFailed, nil, err)
+		}
+
+		ticket.Estimate = estimate
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+
+		fmt.Println(tr(msgEstimated, map[string]any{"ID": ticket.ID, "Estimate": formatEstimate(estimate)}))
+		return nil
+	},
+}
+
+// validateEstimate rejects negative and non-finite estimates.
+func validateEstimate(estimate float64) error {
+	if estimate < 0 || math.IsNaN(estimate) || math.IsInf(estimate, 0) {
+		return trError(msgEstimateInvalid, map[string]any{"Estimate": fmt.Sprint(estimate)}, nil)
+	}
+	return nil
+}
+
+// rollupEstimates computes the estimate rollup of every ticket that has
+// children. A ticket without children counts with its own estimate; a parent's
+// own estimate is ignored in favour of its children's.
+func rollupEstimates(tickets []*domain.Ticket) map[string]estimateRollup {
+	ticketMap := make(map[string]*domain.Ticket, len(tickets))
+	children := make(map[string][]string)
+	for _, t := range tickets {
+		ticketMap[t.ID] = t
+		if t.Parent != "" {
+			children[t.Parent] = append(children[t.Parent], t.ID)
+		}
+	}
+
+	rollups := make(map[string]estimateRollup)
+	visiting := make(map[string]bool)
+	var rollup func(id string) (total, remaining float64)
+	rollup = func(id string) (float64, float64) {
+		t := ticketMap[id]
+		if r, ok := rollups[id]; ok {
+			return r.Total, r.Remaining
+		}
+		if len(children[id]) == 0 || visiting[id] {
+			if t.Status == domain.StatusClosed {
+				return t.Estimate, 0
+			}
+			return t.Estimate, t.Estimate
+		}
+
+		visiting[id] = true
+		var r estimateRollup
+		for _, child := range children[id] {
+			total, remaining := rollup(child)
+			r.Total += total
+			r.Remaining += remaining
+		}
+		visiting[id] = false
+
+		r.Children = len(children[id])
+		rollups[id] = r
+		return r.Total, r.Remaining
+	}
+
+	for parent := range children {
+		if _, ok := ticketMap[parent]; ok {
+			rollup(parent)
+		}
+	}
+	return rollups
+}
+
+// formatEstimate renders an estimate without trailing zeros.
+func formatEstimate(estimate float64) string {
+	return strconv.FormatFloat(estimate, 'f', -1, 64)
+}
@@ -1,39 +1,86 @@
 package cmd
 
 import (
+	"cmp"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
 )
 
 var exportFlags struct {
-	format string
-	output string
+	format  string
+	output  string
+	splitBy string
 }
 
+// Groupings accepted by tk export --split-by.
+const (
+	SplitByStatus   = "status"
+	SplitByAssignee = "assignee"
+	SplitByTag      = "tag"
+)
+
+// unsafeFileChars matches characters replaced in file names written by
+// tk export --split-by.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._@-]+`)
+
 var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export tickets to JSON or CSV format",
 	Long: `Export all tickets to a specified format (JSON or CSV).
 Output goes to stdout by default, or to a file with --output.
 
+With --split-by status, assignee or tag, one file per group is written to the
+--output directory (default: the current directory), named after the group
+with the format as extension, e.g. open.json or alice.csv. Tickets without an
+assignee or tag go to unassigned or untagged, and a ticket with several tags
+is written to each tag's file.
+
+JSON output adds computed relationship fields next to the raw Deps, the same
+as tk query:
+  Ready              Not closed and no dependency open, as listed by tk ready
+  Blocked            Not closed and some dependency open, as listed by tk blocked
+  BlockedBy          IDs of dependencies that are not closed yet
+  Blocks             IDs of tickets that depend on this ticket
+  Children           IDs of tickets whose parent is this ticket
+  Depth              Length of the longest dependency chain below this ticket
+  EstimateTotal      Estimate summed over child tickets, or the own estimate
+  EstimateRemaining  Same, counting only tickets that are not closed
+  Blocking and BlockedByOpen are older names of Blocks and BlockedBy.
+
 Examples:
   tk export                              # Export as JSON to stdout
   tk export --format=json > tickets.json # Export as JSON, redirect to file
   tk export --format=csv > tickets.csv   # Export as CSV
   tk export --output=backup.json         # Export to file directly
-  tk export --format=csv --output=t.csv  # Export CSV to file`,
+  tk export --format=csv --output=t.csv  # Export CSV to file
+  tk export --split-by assignee -o out/  # out/alice.json, out/unassigned.json, ...`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if exportFlags.format != "json" && exportFlags.format != "csv" {
+			return trError(msgExportBadFormat, map[string]any{"Format": exportFlags.format}, nil)
+		}
+
 		tickets, err := store.List()
 		if err != nil {
 			return err
 		}
 
+		if exportFlags.splitBy != "" {
+			return exportSplit(tickets, exportFlags.splitBy, exportFlags.format, cmp.Or(exportFlags.output, "."))
+		}
+
 		var w io.Writer = os.Stdout
 		if exportFlags.output != "" {
 			f, err := os.Create(exportFlags.output)
@@ -44,17 +91,199 @@ Examples:
 			w = f
 		}
 
-		switch exportFlags.format {
-		case "json":
-			return exportJSON(w, tickets)
-		case "csv":
+		if exportFlags.format == "csv" {
 			return exportCSV(w, tickets)
-		default:
-			return fmt.Errorf("unsupported format: %s (use json or csv)", exportFlags.format)
 		}
+		return exportJSON(w, buildExportTickets(tickets))
 	},
 }
 
+// exportSplit writes one file per group of tickets to dir. Relationship
+// fields are computed over all tickets, not just the group.
+func exportSplit(tickets []*domain.Ticket, by, format, dir string) error {
+	groups, err := splitTickets(tickets, by)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	exported := make(map[string]exportTicket, len(tickets))
+	for _, t := range buildExportTickets(tickets) {
+		exported[t.ID] = t
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(groups)) {
+		path := filepath.Join(dir, name+"."+format)
+		if err := writeExportFile(path, format, groups[name], exported); err != nil {
+			return err
+		}
+		fmt.Println(tr(msgExportWrote, map[string]any{"Count": len(groups[name]), "Path": path}))
+	}
+	return nil
+}
+
+func writeExportFile(path, format string, tickets []*domain.Ticket, exported map[string]exportTicket) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write output file: %w", closeErr)
+		}
+	}()
+
+	if format == "csv" {
+		return exportCSV(f, tickets)
+	}
+	group := make([]exportTicket, 0, len(tickets))
+	for _, t := range tickets {
+		group = append(group, exported[t.ID])
+	}
+	return exportJSON(f, group)
+}
+
+// splitTickets groups tickets by status, assignee or tag, keyed by the file
+// name each group is written to.
+func splitTickets(tickets []*domain.Ticket, by string) (map[string][]*domain.Ticket, error) {
+	var names func(t *domain.Ticket) []string
+	switch by {
+	case SplitByStatus:
+		names = func(t *domain.Ticket) []string { return []string{string(t.Status)} }
+	case SplitByAssignee:
+		names = func(t *domain.Ticket) []string { return []string{cmp.Or(t.Assignee, "unassigned")} }
+	case SplitByTag:
+		names = func(t *domain.Ticket) []string {
+			if len(t.Tags) == 0 {
+				return []string{"untagged"}
+			}
+			return t.Tags
+		}
+	default:
+		return nil, trError(msgExportInvalidSplit, map[string]any{"Value": strconv.Quote(by), "Status": SplitByStatus, "Assignee": SplitByAssignee, "Tag": SplitByTag}, nil)
+	}
+
+	groups := make(map[string][]*domain.Ticket)
+	for _, t := range tickets {
+		for _, name := range names(t) {
+			file := splitFileName(name)
+			if !slices.Contains(groups[file], t) {
+				groups[file] = append(groups[file], t)
+			}
+		}
+	}
+	return groups, nil
+}
+
+// splitFileName turns a group name into a safe file name stem.
+func splitFileName(name string) string {
+	stem := strings.Trim(unsafeFileChars.ReplaceAllString(name, "-"), "-.")
+	return cmp.Or(stem, "_")
+}
+
+// exportTicket is a ticket with computed relationship fields for JSON export
+// and tk query.
+type exportTicket struct {
+	*domain.Ticket
+	// Ready and Blocked say whether tk ready or tk blocked lists the ticket;
+	// both are false for closed tickets.
+	Ready     bool
+	Blocked   bool
+	BlockedBy []string
+	Blocks    []string
+	Children  []string
+	Depth     int
+
+	// Blocking and BlockedByOpen are the earlier names of Blocks and
+	// BlockedBy, kept for existing scripts.
+	Blocking      []string
+	BlockedByOpen []string
+
+	EstimateTotal     float64
+	EstimateRemaining float64
+}
+
+// buildExportTickets computes relationship fields for every ticket.
+func buildExportTickets(tickets []*domain.Ticket) []exportTicket {
+	ticketMap := make(map[string]*domain.Ticket)
+	dependents := make(map[string][]string)
+	children := make(map[string][]string)
+	for _, t := range tickets {
+		ticketMap[t.ID] = t
+		for _, dep := range t.Deps {
+			dependents[dep] = append(dependents[dep], t.ID)
+		}
+		if t.Parent != "" {
+			children[t.Parent] = append(children[t.Parent], t.ID)
+		}
+	}
+
+	rollups := rollupEstimates(tickets)
+	depths := make(map[string]int)
+	result := make([]exportTicket, 0, len(tickets))
+	for _, t := range tickets {
+		var blockedBy []string
+		for _, dep := range t.Deps {
+			if d, ok := ticketMap[dep]; ok && d.Status != domain.StatusClosed {
+				blockedBy = append(blockedBy, dep)
+			}
+		}
+
+		rollup, ok := rollups[t.ID]
+		if !ok {
+			rollup.Total = t.Estimate
+			if t.Status != domain.StatusClosed {
+				rollup.Remaining = t.Estimate
+			}
+		}
+
+		open := t.Status != domain.StatusClosed
+		result = append(result, exportTicket{
+			Ticket:    t,
+			Ready:     open && len(blockedBy) == 0,
+			Blocked:   open && len(blockedBy) > 0,
+			BlockedBy: blockedBy,
+			Blocks:    dependents[t.ID],
+			Children:  children[t.ID],
+			Depth:     dependencyDepth(t.ID, ticketMap, depths, make(map[string]bool)),
+
+			Blocking:      dependents[t.ID],
+			BlockedByOpen: blockedBy,
+
+			EstimateTotal:     rollup.Total,
+			EstimateRemaining: rollup.Remaining,
+		})
+	}
+
+	return result
+}
+
+// dependencyDepth returns the length of the longest dependency chain below id.
+// Results are memoized in depths; visiting guards against cycles.
+func dependencyDepth(id string, ticketMap map[string]*domain.Ticket, depths map[string]int, visiting map[string]bool) int {
+	if d, ok := depths[id]; ok {
+		return d
+	}
+	t, ok := ticketMap[id]
+	if !ok || visiting[id] {
+		return 0
+	}
+
+	visiting[id] = true
+	depth := 0
+	for _, dep := range t.Deps {
+		if _, ok := ticketMap[dep]; ok {
+			depth = max(depth, dependencyDepth(dep, ticketMap, depths, visiting)+1)
+		}
+	}
+	visiting[id] = false
+
+	depths[id] = depth
+	return depth
+}
+
 func exportJSON(w io.Writer, tickets any) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
@@ -159,5 +388,6 @@ func joinStrings(m map[string]any, key string) string {
 
 func init() {
 	exportCmd.Flags().StringVar(&exportFlags.format, "format", "json", "Output format (json or csv)")
-	exportCmd.Flags().StringVarP(&exportFlags.output, "output", "o", "", "Output file (default: stdout)")
+	exportCmd.Flags().StringVarP(&exportFlags.output, "output", "o", "", "Output file (default: stdout), or directory with --split-by")
+	exportCmd.Flags().StringVar(&exportFlags.splitBy, "split-by", "", "Write one file per status, assignee or tag")
 }
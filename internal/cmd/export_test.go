@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/radutopala/ticket/internal/domain"
 )
 
 type ExportSuite struct {
@@ -130,3 +133,116 @@ func (s *ExportSuite) TestExportCSV_EmptySlice() {
 	// Should contain just the header
 	require.Contains(s.T(), buf.String(), "ID,Status,Type")
 }
+
+func (s *ExportSuite) TestBuildExportTickets() {
+	tickets := []*domain.Ticket{
+		{ID: "epic", Status: domain.StatusOpen},
+		{ID: "a", Status: domain.StatusOpen, Parent: "epic", Deps: []string{"b", "c"}},
+		{ID: "b", Status: domain.StatusClosed, Parent: "epic", Deps: []string{"c"}},
+		{ID: "c", Status: domain.StatusOpen, Deps: []string{"missing"}},
+	}
+
+	result := buildExportTickets(tickets)
+	byID := make(map[string]exportTicket)
+	for _, t := range result {
+		byID[t.ID] = t
+	}
+
+	require.Equal(s.T(), []string{"a", "b"}, byID["epic"].Children)
+	require.Equal(s.T(), []string{"c"}, byID["a"].BlockedByOpen)
+	require.Equal(s.T(), []string{"a"}, byID["b"].Blocking)
+	require.Equal(s.T(), []string{"a", "b"}, byID["c"].Blocking)
+	require.Equal(s.T(), []string{"c"}, byID["a"].BlockedBy)
+	require.Equal(s.T(), []string{"a", "b"}, byID["c"].Blocks)
+	require.True(s.T(), byID["a"].Blocked)
+	require.False(s.T(), byID["a"].Ready)
+	require.True(s.T(), byID["c"].Ready)
+	require.False(s.T(), byID["c"].Blocked)
+	require.False(s.T(), byID["b"].Ready)
+	require.False(s.T(), byID["b"].Blocked)
+	require.Equal(s.T(), 2, byID["a"].Depth)
+	require.Equal(s.T(), 1, byID["b"].Depth)
+	require.Equal(s.T(), 0, byID["c"].Depth)
+}
+
+func (s *ExportSuite) TestBuildExportTicketsWithCycle() {
+	tickets := []*domain.Ticket{
+		{ID: "a", Status: domain.StatusOpen, Deps: []string{"b"}},
+		{ID: "b", Status: domain.StatusOpen, Deps: []string{"a"}},
+	}
+
+	result := buildExportTickets(tickets)
+	require.Len(s.T(), result, 2)
+}
+
+func (s *ExportSuite) TestExportJSONIncludesComputedFields() {
+	tickets := []*domain.Ticket{
+		{ID: "a", Status: domain.StatusOpen, Deps: []string{"b"}},
+		{ID: "b", Status: domain.StatusOpen},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(s.T(), exportJSON(&buf, buildExportTickets(tickets)))
+
+	var decoded []map[string]any
+	require.NoError(s.T(), json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(s.T(), "a", decoded[0]["ID"])
+	require.Equal(s.T(), []any{"b"}, decoded[0]["Deps"])
+	require.Equal(s.T(), []any{"b"}, decoded[0]["BlockedByOpen"])
+	require.Equal(s.T(), float64(1), decoded[0]["Depth"])
+	require.Equal(s.T(), []any{"a"}, decoded[1]["Blocking"])
+	require.Equal(s.T(), []any{"b"}, decoded[0]["BlockedBy"])
+	require.Equal(s.T(), []any{"a"}, decoded[1]["Blocks"])
+	require.Equal(s.T(), true, decoded[0]["Blocked"])
+	require.Equal(s.T(), true, decoded[1]["Ready"])
+}
+
+func (s *ExportSuite) TestBuildExportTicketsEstimateRollup() {
+	tickets := []*domain.Ticket{
+		{ID: "tic-epic", Status: domain.StatusOpen, Estimate: 100},
+		{ID: "tic-story", Status: domain.StatusOpen, Parent: "tic-epic"},
+		{ID: "tic-a", Status: domain.StatusClosed, Parent: "tic-story", Estimate: 2},
+		{ID: "tic-b", Status: domain.StatusOpen, Parent: "tic-story", Estimate: 3},
+		{ID: "tic-c", Status: domain.StatusOpen, Parent: "tic-epic", Estimate: 1.5},
+	}
+
+	exported := buildExportTickets(tickets)
+	require.Equal(s.T(), 6.5, exported[0].EstimateTotal)
+	require.Equal(s.T(), 4.5, exported[0].EstimateRemaining)
+	require.Equal(s.T(), 5.0, exported[1].EstimateTotal)
+	require.Equal(s.T(), 3.0, exported[1].EstimateRemaining)
+	require.Equal(s.T(), 2.0, exported[2].EstimateTotal)
+	require.Zero(s.T(), exported[2].EstimateRemaining)
+}
+
+func (s *ExportSuite) TestSplitTickets() {
+	tickets := []*domain.Ticket{
+		{ID: "a", Status: domain.StatusOpen, Assignee: "Jane Doe", Tags: []string{"api", "ui/web"}},
+		{ID: "b", Status: domain.StatusClosed, Tags: []string{"api"}},
+		{ID: "c", Status: domain.StatusOpen},
+	}
+	ids := func(groups map[string][]*domain.Ticket) map[string][]string {
+		result := make(map[string][]string)
+		for name, group := range groups {
+			for _, t := range group {
+				result[name] = append(result[name], t.ID)
+			}
+		}
+		return result
+	}
+
+	groups, err := splitTickets(tickets, SplitByStatus)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[string][]string{"open": {"a", "c"}, "closed": {"b"}}, ids(groups))
+
+	groups, err = splitTickets(tickets, SplitByAssignee)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[string][]string{"Jane-Doe": {"a"}, "unassigned": {"b", "c"}}, ids(groups))
+
+	groups, err = splitTickets(tickets, SplitByTag)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[string][]string{"api": {"a", "b"}, "ui-web": {"a"}, "untagged": {"c"}}, ids(groups))
+
+	_, err = splitTickets(tickets, "type")
+	require.ErrorContains(s.T(), err, `invalid --split-by "type"`)
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// maxForecastWeeks caps a single simulation run so low throughput cannot loop forever.
+const maxForecastWeeks = 520
+
+// Forecast holds the result of a Monte Carlo completion forecast.
+type Forecast struct {
+	Remaining  int       `json:"remaining"`
+	Weeks      int       `json:"history_weeks"`
+	Throughput float64   `json:"throughput_per_week"`
+	P50Weeks   int       `json:"p50_weeks"`
+	P85Weeks   int       `json:"p85_weeks"`
+	P50Date    time.Time `json:"p50_date"`
+	P85Date    time.Time `json:"p85_date"`
+}
+
+var forecastFlags struct {
+	weeks  int
+	trials int
+	seed   uint64
+	json   bool
+}
+
+var forecastCmd = &cobra.Command{
+	Use:   "forecast",
+	Short: "Forecast when the remaining tickets will be done",
+	Long: `Forecast completion of the open and in_progress tickets matching the filters
+with a Monte Carlo simulation over historical throughput.
+
+Throughput is the number of tickets closed per week (from closed-at, including
+archived tickets) over the last --weeks weeks, across the whole project. Each
+trial draws a random historical week until the remaining work is done; the
+P50 and P85 completion dates are reported.
+
+Examples:
+  tk forecast --tag release-2
+  tk forecast -a @me --weeks 8`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listFlags.Validate(); err != nil {
+			return err
+		}
+		if forecastFlags.weeks < 1 || forecastFlags.trials < 1 {
+			return trError(msgForecastInvalidFlags, nil, nil)
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+		archived, err := store.Archive().List()
+		if err != nil {
+			return err
+		}
+
+		remaining := 0
+		for _, t := range tickets {
+			if t.Status != domain.StatusClosed && listFlags.Matches(t) {
+				remaining++
+			}
+		}
+
+		now := time.Now().UTC()
+		samples := weeklyThroughput(append(tickets, archived...), now, forecastFlags.weeks)
+
+		seed := forecastFlags.seed
+		if seed == 0 {
+			seed = uint64(now.UnixNano())
+		}
+		rng := rand.New(rand.NewPCG(seed, seed))
+
+		forecast, err := computeForecast(remaining, samples, forecastFlags.trials, now, rng)
+		if err != nil {
+			return err
+		}
+
+		if forecastFlags.json {
+			data, err := json.MarshalIndent(forecast, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal forecast: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		return runWithPager(func(w io.Writer) error {
+			return outputForecastText(w, forecast)
+		})
+	},
+}
+
+// weeklyThroughput returns the number of tickets closed in each of the last
+// weeks weeks before now, oldest first.
+func weeklyThroughput(tickets []*domain.Ticket, now time.Time, weeks int) []int {
+	samples := make([]int, weeks)
+	start := now.Add(-time.Duration(weeks) * 7 * 24 * time.Hour)
+	for _, t := range tickets {
+		if t.Status != domain.StatusClosed || t.ClosedAt.IsZero() {
+			continue
+		}
+		if t.ClosedAt.Before(start) || t.ClosedAt.After(now) {
+			continue
+		}
+		week := int(t.ClosedAt.Sub(start) / (7 * 24 * time.Hour))
+		samples[min(week, weeks-1)]++
+	}
+	return samples
+}
+
+// computeForecast simulates trials completions of remaining tickets by drawing
+// weekly throughput from samples, and returns the P50 and P85 outcomes.
+func computeForecast(remaining int, samples []int, trials int, now time.Time, rng *rand.Rand) (Forecast, error) {
+	total := 0
+	for _, n := range samples {
+		total += n
+	}
+
+	forecast := Forecast{
+		Remaining:  remaining,
+		Weeks:      len(samples),
+		Throughput: float64(total) / float64(len(samples)),
+		P50Date:    now,
+		P85Date:    now,
+	}
+	if remaining == 0 {
+		return forecast, nil
+	}
+	if total == 0 {
+		return forecast, trError(msgForecastNoHistory, nil, nil)
+	}
+
+	results := make([]int, trials)
+	for i := range results {
+		left, weeks := remaining, 0
+		for left > 0 && weeks < maxForecastWeeks {
+			left -= samples[rng.IntN(len(samples))]
+			weeks++
+		}
+		results[i] = weeks
+	}
+	slices.Sort(results)
+
+	forecast.P50Weeks = percentile(results, 50)
+	forecast.P85Weeks = percentile(results, 85)
+	forecast.P50Date = now.AddDate(0, 0, 7*forecast.P50Weeks)
+	forecast.P85Date = now.AddDate(0, 0, 7*forecast.P85Weeks)
+	return forecast, nil
+}
+
+// percentile returns the p-th percentile of sorted values (nearest rank).
+func percentile(sorted []int, p int) int {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+func outputForecastText(w io.Writer, f Forecast) error {
+	if _, err := fmt.Fprintln(w, tr(msgForecastRemaining, map[string]any{"Count": f.Remaining})); err != nil {
+		return err
+	}
+	throughput := map[string]any{"Throughput": fmt.Sprintf("%.1f", f.Throughput), "Weeks": f.Weeks}
+	if _, err := fmt.Fprintln(w, tr(msgForecastThroughput, throughput)); err != nil {
+		return err
+	}
+	if f.Remaining == 0 {
+		_, err := fmt.Fprintln(w, tr(msgForecastNothing, nil))
+		return err
+	}
+	p50 := map[string]any{"Date": f.P50Date.Format(time.DateOnly), "Weeks": f.P50Weeks}
+	if _, err := fmt.Fprintln(w, tr(msgForecastP50, p50)); err != nil {
+		return err
+	}
+	p85 := map[string]any{"Date": f.P85Date.Format(time.DateOnly), "Weeks": f.P85Weeks}
+	_, err := fmt.Fprintln(w, tr(msgForecastP85, p85))
+	return err
+}
+
+func init() {
+	addMatchFlags(forecastCmd, false)
+	forecastCmd.Flags().IntVar(&forecastFlags.weeks, "weeks", 12, "Weeks of closing history to sample throughput from")
+	forecastCmd.Flags().IntVar(&forecastFlags.trials, "trials", 10000, "Number of simulation runs")
+	forecastCmd.Flags().Uint64Var(&forecastFlags.seed, "seed", 0, "Random seed for reproducible forecasts (0 for random)")
+	forecastCmd.Flags().BoolVar(&forecastFlags.json, "json", false, "Output as JSON")
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+type ForecastSuite struct {
+	suite.Suite
+}
+
+func TestForecastSuite(t *testing.T) {
+	suite.Run(t, new(ForecastSuite))
+}
+
+func (s *ForecastSuite) TestWeeklyThroughput() {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	closed := func(ago time.Duration) *domain.Ticket {
+		return &domain.Ticket{Status: domain.StatusClosed, ClosedAt: now.Add(-ago)}
+	}
+	day := 24 * time.Hour
+
+	tickets := []*domain.Ticket{
+		closed(1 * day),
+		closed(2 * day),
+		closed(10 * day),
+		closed(30 * day), // outside a 3-week window
+		{Status: domain.StatusOpen},
+		{Status: domain.StatusClosed}, // closed before closed-at existed
+	}
+
+	require.Equal(s.T(), []int{0, 1, 2}, weeklyThroughput(tickets, now, 3))
+}
+
+func (s *ForecastSuite) TestComputeForecast() {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rng := rand.New(rand.NewPCG(1, 1))
+
+	// Constant throughput makes the outcome deterministic.
+	f, err := computeForecast(10, []int{2, 2, 2}, 100, now, rng)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 5, f.P50Weeks)
+	require.Equal(s.T(), 5, f.P85Weeks)
+	require.Equal(s.T(), now.AddDate(0, 0, 35), f.P50Date)
+	require.InDelta(s.T(), 2.0, f.Throughput, 0.001)
+
+	f, err = computeForecast(10, []int{0, 1, 5}, 1000, now, rng)
+	require.NoError(s.T(), err)
+	require.LessOrEqual(s.T(), f.P50Weeks, f.P85Weeks)
+	require.GreaterOrEqual(s.T(), f.P50Weeks, 2)
+
+	f, err = computeForecast(0, []int{0, 0}, 100, now, rng)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 0, f.P50Weeks)
+
+	_, err = computeForecast(3, []int{0, 0}, 100, now, rng)
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "no tickets closed")
+}
+
+func (s *ForecastSuite) TestPercentile() {
+	values := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	require.Equal(s.T(), 5, percentile(values, 50))
+	require.Equal(s.T(), 9, percentile(values, 85))
+	require.Equal(s.T(), 1, percentile([]int{1}, 85))
+}
+
+func (s *ForecastSuite) TestOutputForecastText() {
+	var buf bytes.Buffer
+	f := Forecast{
+		Remaining:  4,
+		Weeks:      12,
+		Throughput: 1.5,
+		P50Weeks:   3,
+		P85Weeks:   4,
+		P50Date:    time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC),
+		P85Date:    time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(s.T(), outputForecastText(&buf, f))
+	require.Contains(s.T(), buf.String(), "Remaining:  4 tickets")
+	require.Contains(s.T(), buf.String(), "Throughput: 1.5/week over the last 12 weeks")
+	require.Contains(s.T(), buf.String(), "P50:        2026-03-22 (3 weeks)")
+	require.Contains(s.T(), buf.String(), "P85:        2026-03-29 (4 weeks)")
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/config"
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// Retention actions.
+const (
+	RetentionArchive = "archive"
+	RetentionDelete  = "delete"
+)
+
+var gcFlags struct {
+	dryRun bool
+}
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Archive or delete closed tickets past the retention window",
+	Long: `Enforce the closed-ticket retention policy from the config file: tickets
+closed longer ago than retention.closed are moved to .tickets/archive/ or, with
+action: delete, removed. Tickets without a closed-at timestamp are kept.
+
+  retention:
+    closed: 180d
+    action: archive   # or delete
+
+Examples:
+  tk gc --dry-run   # Show what would be cleaned up
+  tk gc`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		window, action, err := retentionPolicy(cfg.Retention)
+		if err != nil {
+			return err
+		}
+		if window == 0 {
+			return trError(msgGCNoPolicy, map[string]any{"File": config.FileName}, nil)
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+
+		wouldMsg, doneMsg := gcMessages(action)
+		expired := expiredTickets(tickets, time.Now().UTC().Add(-window))
+		for _, t := range expired {
+			data := map[string]any{"ID": t.ID, "Age": formatAge(t.ClosedAt, time.Now())}
+			if gcFlags.dryRun {
+				fmt.Println(tr(wouldMsg, data))
+				continue
+			}
+
+			if action == RetentionDelete {
+				err = store.Delete(t.ID)
+			} else {
+				err = store.ArchiveTicket(t.ID)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Println(tr(doneMsg, data))
+		}
+
+		if len(expired) == 0 {
+			fmt.Println(tr(msgGCNothing, nil))
+		}
+		return nil
+	},
+}
+
+// retentionPolicy parses and validates the retention config. A zero window
+// means no policy is configured.
+func retentionPolicy(r config.Retention) (time.Duration, string, error) {
+	action := r.Action
+	if action == "" {
+		action = RetentionArchive
+	}
+	if action != RetentionArchive && action != RetentionDelete {
+		return 0, "", trError(msgGCInvalidAction, map[string]any{"Action": strconv.Quote(r.Action), "File": config.FileName, "Archive": RetentionArchive, "Delete": RetentionDelete}, nil)
+	}
+	if r.Closed == "" {
+		return 0, action, nil
+	}
+
+	window, err := parseDuration(r.Closed)
+	if err != nil {
+		return 0, "", trWrap(msgGCInvalidClosed, map[string]any{"File": config.FileName}, err)
+	}
+	return window, action, nil
+}
+
+// expiredTickets returns the closed tickets closed before cutoff, oldest first.
+// Locked tickets are always retained.
+func expiredTickets(tickets []*domain.Ticket, cutoff time.Time) []*domain.Ticket {
+	var expired []*domain.Ticket
+	for _, t := range tickets {
+		if t.Status == domain.StatusClosed && !t.Locked && !t.ClosedAt.IsZero() && t.ClosedAt.Before(cutoff) {
+			expired = append(expired, t)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].ClosedAt.Before(expired[j].ClosedAt) })
+	return expired
+}
+
+// gcMessages returns the dry-run and done messages for a retention action.
+func gcMessages(action string) (would, done *i18n.Message) {
+	if action == RetentionDelete {
+		return msgGCWouldDelete, msgGCDeleted
+	}
+	return msgGCWouldArchive, msgGCArchived
+}
+
+func init() {
+	gcCmd.Flags().BoolVar(&gcFlags.dryRun, "dry-run", false, "Show what would be archived or deleted without changing anything")
+}
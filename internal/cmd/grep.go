@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var grepFlags struct {
+	ignoreCase bool
+	lineNumber bool
+	filesOnly  bool
+}
+
+var grepCmd = &cobra.Command{
+	Use:   "grep <pattern>",
+	Short: "Search raw ticket files with grep-style output",
+	Long: `Search the raw ticket files (frontmatter and body) for a Go regular
+expression and print matches in grep format: "path:text", or "path:line:text"
+with -n. Paths are relative to the current directory when possible, so the
+output can feed editor quickfix lists.
+
+Examples:
+  tk grep -n 'TODO'               # path:line:text for every TODO
+  tk grep -il 'oauth'             # Files mentioning oauth, any case
+  vim -q <(tk grep -n 'panic')    # Jump through matches in vim`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		expr := args[0]
+		if grepFlags.ignoreCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return trWrap(msgGrepInvalidPattern, nil, err)
+		}
+
+		var paths []string
+		for _, s := range scopedStores() {
+			ids, err := s.ListIDs()
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				paths = append(paths, filepath.Join(s.TicketsDir(), id+".md"))
+			}
+		}
+		sort.Strings(paths)
+
+		return runWithPager(func(w io.Writer) error {
+			for _, path := range paths {
+				if err := grepFile(w, path, re); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+// grepFile writes the lines of path matching re in grep format.
+func grepFile(w io.Writer, path string, re *regexp.Regexp) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open ticket file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	display := displayPath(path)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if !re.MatchString(text) {
+			continue
+		}
+
+		switch {
+		case grepFlags.filesOnly:
+			_, err := fmt.Fprintln(w, display)
+			return err
+		case grepFlags.lineNumber:
+			_, err = fmt.Fprintf(w, "%s:%d:%s\n", display, line, text)
+		default:
+			_, err = fmt.Fprintf(w, "%s:%s\n", display, text)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// displayPath returns path relative to the current directory when it is below it.
+func displayPath(path string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return path
+	}
+	return rel
+}
+
+func init() {
+	grepCmd.Flags().BoolVarP(&grepFlags.ignoreCase, "ignore-case", "i", false, "Ignore case distinctions")
+	grepCmd.Flags().BoolVarP(&grepFlags.lineNumber, "line-number", "n", false, "Prefix each match with its line number")
+	grepCmd.Flags().BoolVarP(&grepFlags.filesOnly, "files-with-matches", "l", false, "Print only the paths of matching files")
+	addArchiveFlags(grepCmd)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/radutopala/ticket/internal/config"
+)
+
+// largeStoreTickets is the number of ticket files above which every command
+// warns that the working set is slow to load.
+var largeStoreTickets = 10000
+
+// storeHealthWarnings returns one-line warnings, each naming its fix, about a
+// tickets directory that is missing or too large. fromEnv tells whether
+// ticketsDir came from TICKETS_DIR rather than the default .tickets in cwd.
+// Only directory entries are read, so the checks stay cheap.
+func storeHealthWarnings(ticketsDir string, fromEnv bool, cwd string) []string {
+	info, err := os.Stat(ticketsDir)
+	if err != nil || !info.IsDir() {
+		if fromEnv {
+			return []string{fmt.Sprintf("%s=%s does not exist; fix the variable, or create the directory with: mkdir -p %s",
+				config.EnvTicketsDir, ticketsDir, ticketsDir)}
+		}
+		if parent, levels := findParentTicketsDir(cwd); parent != "" {
+			return []string{fmt.Sprintf("no %s here, but %s is %d %s up; run tk from %s or set %s=%s",
+				config.DefaultTicketsDir, parent, levels, plural(levels, "directory", "directories"), filepath.Dir(parent), config.EnvTicketsDir, parent)}
+		}
+		return nil
+	}
+
+	if n := countTicketFiles(ticketsDir, largeStoreTickets+1); n > largeStoreTickets {
+		return []string{fmt.Sprintf("%s holds more than %d tickets, which slows every command; archive closed ones with: tk gc (after setting retention.closed in %s)",
+			ticketsDir, largeStoreTickets, config.FileName)}
+	}
+	return nil
+}
+
+// findParentTicketsDir returns the nearest tickets directory above dir and
+// how many levels up it is, or an empty path when there is none.
+func findParentTicketsDir(dir string) (string, int) {
+	for levels := 1; ; levels++ {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", 0
+		}
+		dir = parent
+		candidate := filepath.Join(dir, config.DefaultTicketsDir)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, levels
+		}
+	}
+}
+
+// countTicketFiles counts the ticket files in dir, stopping once limit is reached.
+func countTicketFiles(dir string, limit int) int {
+	f, err := os.Open(dir)
+	if err != nil {
+		return 0
+	}
+	defer func() { _ = f.Close() }()
+
+	count := 0
+	for count < limit {
+		names, err := f.Readdirnames(1024)
+		for _, name := range names {
+			if strings.HasSuffix(name, ".md") {
+				count++
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return count
+			}
+			break
+		}
+	}
+	return min(count, limit)
+}
+
+// plural returns one when n is 1 and many otherwise.
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// warnStoreHealth prints the store health warnings to stderr.
+func warnStoreHealth(c *config.Config) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	for _, w := range storeHealthWarnings(c.TicketsDir, os.Getenv(config.EnvTicketsDir) != "", cwd) {
+		fmt.Fprintf(os.Stderr, "%s %s (silence with %s)\n", tr(msgWarningPrefix, nil), w, healthSilenceHint(c.TicketsDir))
+	}
+}
+
+// healthSilenceHint names how to silence the health warnings about
+// ticketsDir. The config key is read from the tickets directory, so it only
+// helps when that directory exists.
+func healthSilenceHint(ticketsDir string) string {
+	hint := config.EnvHealthWarnings + "=0"
+	if info, err := os.Stat(ticketsDir); err == nil && info.IsDir() {
+		hint = "health_warnings: false or " + hint
+	}
+	return hint
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthSuite struct {
+	suite.Suite
+}
+
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(HealthSuite))
+}
+
+func (s *HealthSuite) TestHealthyStore() {
+	dir := s.T().TempDir()
+	ticketsDir := filepath.Join(dir, ".tickets")
+	require.NoError(s.T(), os.Mkdir(ticketsDir, 0755))
+
+	require.Empty(s.T(), storeHealthWarnings(ticketsDir, false, dir))
+	// A missing default directory is normal before the first ticket
+	fresh := s.T().TempDir()
+	require.Empty(s.T(), storeHealthWarnings(filepath.Join(fresh, ".tickets"), false, fresh))
+}
+
+func (s *HealthSuite) TestMissingEnvDir() {
+	missing := filepath.Join(s.T().TempDir(), "typo")
+
+	warnings := storeHealthWarnings(missing, true, s.T().TempDir())
+	require.Len(s.T(), warnings, 1)
+	require.Contains(s.T(), warnings[0], "TICKETS_DIR="+missing+" does not exist")
+	require.Contains(s.T(), warnings[0], "mkdir -p "+missing)
+}
+
+func (s *HealthSuite) TestTicketsDirFurtherUp() {
+	root := s.T().TempDir()
+	require.NoError(s.T(), os.Mkdir(filepath.Join(root, ".tickets"), 0755))
+	cwd := filepath.Join(root, "services", "api")
+	require.NoError(s.T(), os.MkdirAll(cwd, 0755))
+
+	warnings := storeHealthWarnings(filepath.Join(cwd, ".tickets"), false, cwd)
+	require.Len(s.T(), warnings, 1)
+	require.Contains(s.T(), warnings[0], filepath.Join(root, ".tickets")+" is 2 directories up")
+	require.Contains(s.T(), warnings[0], "run tk from "+root)
+}
+
+func (s *HealthSuite) TestHealthSilenceHint() {
+	dir := s.T().TempDir()
+	require.Equal(s.T(), "health_warnings: false or TK_HEALTH_WARNINGS=0", healthSilenceHint(dir))
+	// Without the directory there is no config file to set the key in
+	require.Equal(s.T(), "TK_HEALTH_WARNINGS=0", healthSilenceHint(filepath.Join(dir, "missing")))
+}
+
+func (s *HealthSuite) TestLargeStore() {
+	defer func(n int) { largeStoreTickets = n }(largeStoreTickets)
+	largeStoreTickets = 3
+	dir := s.T().TempDir()
+	for i := range 3 {
+		require.NoError(s.T(), os.WriteFile(filepath.Join(dir, fmt.Sprintf("tic-%d.md", i)), nil, 0644))
+	}
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, "config.yaml"), nil, 0644))
+
+	require.Empty(s.T(), storeHealthWarnings(dir, true, dir))
+
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, "tic-3.md"), nil, 0644))
+	warnings := storeHealthWarnings(dir, true, dir)
+	require.Len(s.T(), warnings, 1)
+	require.Contains(s.T(), warnings[0], "more than 3 tickets")
+	require.Contains(s.T(), warnings[0], "tk gc")
+}
@@ -1,21 +1,105 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
 
 	"github.com/radutopala/ticket/internal/domain"
+	"github.com/radutopala/ticket/internal/storage"
 )
 
 // resolveAndReadTicket resolves a partial ID and reads the ticket.
 // This is a common pattern used throughout the commands.
 func resolveAndReadTicket(idArg string) (*domain.Ticket, error) {
-	id, err := store.ResolveID(idArg)
+	id, err := resolveID(store, idArg)
 	if err != nil {
 		return nil, err
 	}
 	return store.Read(id)
 }
 
+// resolveID resolves a partial ID in s, reporting a missing ticket in the
+// output language.
+func resolveID(s *storage.Storage, idArg string) (string, error) {
+	id, err := s.ResolveID(idArg)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", trError(msgNotFound, map[string]any{"ID": idArg}, err)
+	}
+	return id, err
+}
+
+// archiveFlags selects which tickets read-only commands scan.
+var archiveFlags struct {
+	archived bool
+	all      bool
+}
+
+// addArchiveFlags registers the --archived and --all flags.
+func addArchiveFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&archiveFlags.archived, "archived", false, "Only include archived tickets")
+	cmd.Flags().BoolVar(&archiveFlags.all, "all", false, "Include both active and archived tickets")
+}
+
+// scopedStores returns the active store, the archive, or both,
+// depending on --archived and --all.
+func scopedStores() []*storage.Storage {
+	switch {
+	case archiveFlags.all:
+		return []*storage.Storage{store, store.Archive()}
+	case archiveFlags.archived:
+		return []*storage.Storage{store.Archive()}
+	default:
+		return []*storage.Storage{store}
+	}
+}
+
+// listScopedTickets lists active tickets, archived tickets, or both,
+// depending on --archived and --all.
+func listScopedTickets() ([]*domain.Ticket, error) {
+	var tickets []*domain.Ticket
+	for _, s := range scopedStores() {
+		listed, err := s.List()
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, listed...)
+	}
+	return tickets, nil
+}
+
+// resolveScopedTicket resolves and reads a ticket honoring --archived and --all.
+// With --all, active tickets take precedence over archived ones.
+func resolveScopedTicket(idArg string) (*domain.Ticket, error) {
+	if archiveFlags.archived && !archiveFlags.all {
+		archive := store.Archive()
+		id, err := resolveID(archive, idArg)
+		if err != nil {
+			return nil, err
+		}
+		return archive.Read(id)
+	}
+
+	ticket, err := resolveAndReadTicket(idArg)
+	if !archiveFlags.all || !errors.Is(err, storage.ErrNotFound) {
+		return ticket, err
+	}
+
+	archive := store.Archive()
+	id, archiveErr := archive.ResolveID(idArg)
+	if archiveErr != nil {
+		return nil, err
+	}
+	return archive.Read(id)
+}
+
 // updateTicketStatus updates a ticket's status and prints a confirmation message.
 func updateTicketStatus(idArg string, newStatus domain.Status) error {
 	ticket, err := resolveAndReadTicket(idArg)
@@ -23,16 +107,35 @@ func updateTicketStatus(idArg string, newStatus domain.Status) error {
 		return err
 	}
 
-	ticket.Status = newStatus
+	ticket.SetStatus(newStatus, time.Now().UTC())
+
+	if err := enforcePolicy(ticket); err != nil {
+		return trWrap(msgCannotUpdate, map[string]any{"ID": ticket.ID}, err)
+	}
 
 	if err := store.Write(ticket); err != nil {
 		return fmt.Errorf("failed to update ticket: %w", err)
 	}
 
-	fmt.Printf("Updated %s -> %s\n", ticket.ID, newStatus)
+	fmt.Println(tr(msgUpdatedStatus, map[string]any{"ID": ticket.ID, "Status": newStatus}))
 	return nil
 }
 
+// withTicketLocks runs fn while holding the locks of all ids, taken in sorted
+// order. Writes in fn to the locked tickets are rolled back together if fn fails.
+func withTicketLocks(ids []string, fn func() error) error {
+	sorted := slices.Sorted(slices.Values(ids))
+
+	var lockFrom func(i int) error
+	lockFrom = func(i int) error {
+		if i == len(sorted) {
+			return fn()
+		}
+		return store.WithLock(sorted[i], func() error { return lockFrom(i + 1) })
+	}
+	return lockFrom(0)
+}
+
 // removeFromSlice removes the first occurrence of value from slice.
 // Returns the new slice and a boolean indicating if the value was found.
 func removeFromSlice(slice []string, value string) ([]string, bool) {
@@ -59,7 +162,269 @@ func buildOpenIDSet(tickets []*domain.Ticket) map[string]bool {
 	return openIDs
 }
 
+// defaultLineFormat is the template used for one-line ticket summaries
+// when neither the config file nor --line-format overrides it.
+const defaultLineFormat = `{{.ID}} [P{{.Priority}}][{{.Status}}][{{.Type}}] - {{.Title}}` +
+	`{{with .Assignee}} @{{.}}{{end}}{{range .Tags}} #{{.}}{{end}} ({{.Age}})`
+
+// lineFormatFlag holds the --line-format override for list-style commands.
+var lineFormatFlag string
+
+var lineTemplate = template.Must(parseLineFormat(defaultLineFormat))
+
+// lineData is the data passed to the line format template.
+type lineData struct {
+	*domain.Ticket
+	Age string
+}
+
+// InStatus formats the time the ticket has been in its current status, e.g. 3d.
+func (d lineData) InStatus() string {
+	return formatAge(statusChangedAt(d.Ticket), time.Now())
+}
+
+// Symbol formats the ticket's status symbol, e.g. [~].
+func (d lineData) Symbol() string {
+	return statusIndicator(d.Status)
+}
+
+// statusSince caches, per ticket ID, the time of its last status change in the
+// journal. It is loaded on first use and reset for every command.
+var statusSince map[string]time.Time
+
+// statusChangedAt returns when t entered its current status according to the
+// journal, or its creation time if the journal has no status change for it.
+func statusChangedAt(t *domain.Ticket) time.Time {
+	if statusSince == nil {
+		statusSince = make(map[string]time.Time)
+		if store != nil {
+			// An unreadable journal only loses precision: ages fall back to created
+			events, _ := store.ReadJournal()
+			for _, ev := range events {
+				if _, ok := ev.Changes["status"]; ok {
+					statusSince[ev.Ticket] = ev.Time
+				}
+			}
+		}
+	}
+
+	if at, ok := statusSince[t.ID]; ok && at.After(t.Created) {
+		return at
+	}
+	return t.Created
+}
+
+// parseLineFormat compiles a line format template.
+func parseLineFormat(format string) (*template.Template, error) {
+	return template.New("line").Funcs(template.FuncMap{
+		"join":  strings.Join,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"time":  formatTime,
+		"date":  formatDate,
+	}).Parse(format)
+}
+
+// setLineFormat compiles format and makes it the active line template.
+// An empty format restores the default.
+func setLineFormat(format string) error {
+	if format == "" {
+		format = defaultLineFormat
+	}
+
+	tmpl, err := parseLineFormat(format)
+	if err != nil {
+		return fmt.Errorf("invalid line format: %w", err)
+	}
+
+	// Catch references to unknown fields before rendering real tickets
+	if err := tmpl.Execute(io.Discard, lineData{Ticket: &domain.Ticket{}}); err != nil {
+		return fmt.Errorf("invalid line format: %w", err)
+	}
+
+	lineTemplate = tmpl
+	return nil
+}
+
 // formatTicketLine formats a ticket as a single-line summary.
 func formatTicketLine(t *domain.Ticket) string {
-	return fmt.Sprintf("%s [P%d][%s] - %s", t.ID, t.Priority, t.Status, t.Title)
+	var buf strings.Builder
+	data := lineData{Ticket: t, Age: formatAge(t.Created, time.Now())}
+	if err := lineTemplate.Execute(&buf, data); err != nil {
+		return fmt.Sprintf("%s (line format error: %v)", t.ID, err)
+	}
+	return buf.String()
+}
+
+// formatAge formats the time elapsed since created compactly, e.g. 45m, 5h or 12d.
+func formatAge(created, now time.Time) string {
+	age := now.Sub(created)
+	switch {
+	case created.IsZero():
+		return "?"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(max(age, 0).Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
+
+// parseDuration parses a duration string, extending time.ParseDuration
+// with day (d) and week (w) units, e.g. "7d" or "2w".
+func parseDuration(value string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid duration: %s", value)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration: %s", value)
+	}
+	return d, nil
+}
+
+// parseTimeArg parses an absolute time (RFC3339 or YYYY-MM-DD) or a relative
+// duration such as "2w", which is interpreted as that long before now.
+func parseTimeArg(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	d, err := parseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: use RFC3339, YYYY-MM-DD, or a duration like 2w", value)
+	}
+	return now.Add(-d), nil
+}
+
+// parseDueDate parses a due date given as YYYY-MM-DD, RFC3339, or a duration
+// from now like 3d or 2w, truncated to the date.
+func parseDueDate(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC().Truncate(24 * time.Hour), nil
+	}
+	d, err := parseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due date %q: use YYYY-MM-DD, RFC3339, or a duration like 2w", value)
+	}
+	return now.Add(d).UTC().Truncate(24 * time.Hour), nil
+}
+
+// timeValue is a pflag.Value that parses its argument with parseTimeArg.
+type timeValue struct {
+	t *time.Time
+}
+
+func (v timeValue) String() string {
+	if v.t == nil || v.t.IsZero() {
+		return ""
+	}
+	return v.t.Format(time.RFC3339)
+}
+
+func (v timeValue) Set(s string) error {
+	t, err := parseTimeArg(s, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	*v.t = t
+	return nil
+}
+
+func (v timeValue) Type() string {
+	return "time"
+}
+
+// durationValue is a pflag.Value that parses its argument with parseDuration.
+type durationValue struct {
+	d *time.Duration
+}
+
+func (v durationValue) String() string {
+	if v.d == nil || *v.d == 0 {
+		return ""
+	}
+	return v.d.String()
+}
+
+func (v durationValue) Set(s string) error {
+	d, err := parseDuration(s)
+	if err != nil {
+		return err
+	}
+	*v.d = d
+	return nil
+}
+
+func (v durationValue) Type() string {
+	return "duration"
+}
+
+// meAlias is the placeholder accepted by --assignee style flags for the current user.
+const meAlias = "@me"
+
+// userName is the current user's identity, derived once per invocation from
+// TK_USER or git user.name.
+var userName string
+
+// currentUser returns the current user's identity, or empty if unknown.
+func currentUser() string {
+	return userName
+}
+
+// resolveMe replaces the @me placeholder with the current user.
+func resolveMe(value string) (string, error) {
+	if !strings.EqualFold(value, meAlias) {
+		return value, nil
+	}
+	if userName == "" {
+		return "", trError(msgNoCurrentUser, nil, nil)
+	}
+	return userName, nil
+}
+
+// resolveMeSlice replaces every @me placeholder in values with the current user.
+func resolveMeSlice(values []string) error {
+	for i, v := range values {
+		resolved, err := resolveMe(v)
+		if err != nil {
+			return err
+		}
+		values[i] = resolved
+	}
+	return nil
+}
+
+// resolveMeFlags expands @me in every flag that takes a user.
+func resolveMeFlags() error {
+	for _, values := range [][]string{listFlags.Assignee, listFlags.NotAssignee, bulkFlags.assignee, activityFlags.assignee} {
+		if err := resolveMeSlice(values); err != nil {
+			return err
+		}
+	}
+	for _, value := range []*string{&createFlags.assignee, &ensureFlags.assignee, &mentionsFlags.user, &watchlistFlags.user} {
+		resolved, err := resolveMe(*value)
+		if err != nil {
+			return err
+		}
+		*value = resolved
+	}
+	return nil
 }
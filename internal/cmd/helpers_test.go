@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/radutopala/ticket/internal/domain"
 )
 
 type HelpersSuite struct {
@@ -96,3 +99,150 @@ func (s *HelpersSuite) TestRemoveFromSlice() {
 		})
 	}
 }
+
+func (s *HelpersSuite) TestParseDuration() {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "12h", want: 12 * time.Hour},
+		{value: "7d", want: 7 * 24 * time.Hour},
+		{value: "2w", want: 14 * 24 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "xd", wantErr: true},
+		{value: "-1d", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.value, func() {
+			got, err := parseDuration(tt.value)
+			if tt.wantErr {
+				require.Error(s.T(), err)
+				return
+			}
+			require.NoError(s.T(), err)
+			require.Equal(s.T(), tt.want, got)
+		})
+	}
+}
+
+func (s *HelpersSuite) TestParseTimeArg() {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseTimeArg("2026-03-01T08:30:00Z", now)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC), got)
+
+	got, err = parseTimeArg("2026-03-01", now)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = parseTimeArg("2w", now)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), now.Add(-14*24*time.Hour), got)
+
+	_, err = parseTimeArg("yesterday", now)
+	require.Error(s.T(), err)
+}
+
+func (s *HelpersSuite) TestParseDueDate() {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseDueDate("2026-04-01", now)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = parseDueDate("2026-04-01T18:30:00+02:00", now)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = parseDueDate("3d", now)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC), got)
+
+	_, err = parseDueDate("soon", now)
+	require.Error(s.T(), err)
+}
+
+func (s *HelpersSuite) TestFormatTicketLineDefault() {
+	require.NoError(s.T(), setLineFormat(""))
+
+	ticket := &domain.Ticket{
+		ID:       "tic-1234",
+		Status:   domain.StatusOpen,
+		Type:     domain.TypeBug,
+		Priority: 1,
+		Assignee: "alice",
+		Tags:     []string{"auth", "urgent"},
+		Title:    "Fix login bug",
+		Created:  time.Now().Add(-3*24*time.Hour - time.Hour),
+	}
+
+	require.Equal(s.T(), "tic-1234 [P1][open][bug] - Fix login bug @alice #auth #urgent (3d)", formatTicketLine(ticket))
+
+	ticket.Assignee = ""
+	ticket.Tags = nil
+	require.Equal(s.T(), "tic-1234 [P1][open][bug] - Fix login bug (3d)", formatTicketLine(ticket))
+}
+
+func (s *HelpersSuite) TestFormatTicketLineCustom() {
+	defer func() { require.NoError(s.T(), setLineFormat("")) }()
+
+	require.NoError(s.T(), setLineFormat(`{{.ID}}|{{upper .Status.String}}|{{join .Tags ","}}`))
+
+	ticket := &domain.Ticket{ID: "tic-1", Status: domain.StatusInProgress, Tags: []string{"a", "b"}}
+	require.Equal(s.T(), "tic-1|IN_PROGRESS|a,b", formatTicketLine(ticket))
+}
+
+func (s *HelpersSuite) TestFormatTicketLineInStatus() {
+	defer func() { require.NoError(s.T(), setLineFormat("")) }()
+
+	now := time.Now()
+	statusSince = map[string]time.Time{"tic-1": now.Add(-2*24*time.Hour - time.Hour)}
+	defer func() { statusSince = nil }()
+
+	require.NoError(s.T(), setLineFormat(`{{.ID}} {{.Age}} {{.InStatus}}`))
+
+	ticket := &domain.Ticket{ID: "tic-1", Created: now.Add(-9*24*time.Hour - time.Hour)}
+	require.Equal(s.T(), "tic-1 9d 2d", formatTicketLine(ticket))
+
+	ticket.ID = "tic-2"
+	require.Equal(s.T(), "tic-2 9d 9d", formatTicketLine(ticket))
+}
+
+func (s *HelpersSuite) TestSetLineFormatInvalid() {
+	defer func() { require.NoError(s.T(), setLineFormat("")) }()
+
+	require.Error(s.T(), setLineFormat("{{.ID"))
+	require.Error(s.T(), setLineFormat("{{.NoSuchField}}"))
+}
+
+func (s *HelpersSuite) TestFormatAge() {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(s.T(), "30m", formatAge(now.Add(-30*time.Minute), now))
+	require.Equal(s.T(), "5h", formatAge(now.Add(-5*time.Hour), now))
+	require.Equal(s.T(), "12d", formatAge(now.Add(-12*24*time.Hour), now))
+	require.Equal(s.T(), "0m", formatAge(now.Add(time.Hour), now))
+	require.Equal(s.T(), "?", formatAge(time.Time{}, now))
+}
+
+func (s *HelpersSuite) TestWIPViolations() {
+	tickets := []*domain.Ticket{
+		{ID: "tic-a", Status: domain.StatusInProgress, Assignee: "alice", Tags: []string{"backend"}},
+		{ID: "tic-b", Status: domain.StatusInProgress, Assignee: "bob", Tags: []string{"backend"}},
+		{ID: "tic-c", Status: domain.StatusClosed, Assignee: "alice", Tags: []string{"backend"}},
+		{ID: "tic-new", Status: domain.StatusOpen, Tags: []string{"backend", "ui"}},
+	}
+
+	require.Empty(s.T(), wipViolations("tic-new", "alice", tickets, 2, nil))
+	require.Len(s.T(), wipViolations("tic-new", "alice", tickets, 1, nil), 1)
+	require.Empty(s.T(), wipViolations("tic-new", "", tickets, 1, nil), "unknown user has no per-assignee limit")
+
+	violations := wipViolations("tic-new", "carol", tickets, 0, map[string]int{"backend": 2, "ui": 1})
+	require.Equal(s.T(), []string{"WIP limit reached: 2 in_progress ticket(s) tagged backend (limit 2)"}, violations)
+
+	require.Empty(s.T(), wipViolations("tic-a", "alice", tickets, 1, nil), "restarting a ticket does not count itself")
+}
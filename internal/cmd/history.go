@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/storage"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show the change history of a ticket",
+	Long: `Show every journal event for a ticket, oldest first, with who made each change.
+Supports partial ID matching, including archived tickets; deleted tickets can
+be looked up by full ID.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := resolveID(store, args[0])
+		if errors.Is(err, storage.ErrNotFound) {
+			id, err = resolveID(store.Archive(), args[0])
+		}
+		if errors.Is(err, storage.ErrNotFound) {
+			id, err = args[0], nil
+		}
+		if err != nil {
+			return err
+		}
+
+		events, err := store.ReadJournal()
+		if err != nil {
+			return err
+		}
+
+		var history []storage.Event
+		for _, ev := range events {
+			if ev.Ticket == id {
+				history = append(history, ev)
+			}
+		}
+		if len(history) == 0 {
+			return trError(msgHistoryNone, map[string]any{"ID": id}, nil)
+		}
+
+		return runWithPager(func(w io.Writer) error {
+			for _, ev := range history {
+				line := fmt.Sprintf("%s  %-10s", ev.Time.In(displayLocation).Format(time.DateTime), describeEvent(ev))
+				if ev.Actor != "" {
+					line += "  by " + ev.Actor
+				}
+				if ev.Action != storage.ActionCreate && ev.Action != storage.ActionDelete {
+					if changes := formatChanges(ev); changes != "" {
+						line += "  (" + changes + ")"
+					}
+				}
+				if _, err := fmt.Fprintln(w, line); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
@@ -0,0 +1,615 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+
+	"github.com/radutopala/ticket/internal/config"
+)
+
+// LocalesDirName is the directory inside the tickets directory holding
+// translated message catalogs named like active.<lang>.yaml.
+const LocalesDirName = "locales"
+
+// catalogExtensions are the message catalog formats loaded from the locales directory.
+var catalogExtensions = []string{".yaml", ".yml", ".json"}
+
+// messages are the translatable output messages, registered by addMessage.
+var messages []*i18n.Message
+
+// addMessage registers msg for tk i18n extract and returns it.
+func addMessage(msg *i18n.Message) *i18n.Message {
+	messages = append(messages, msg)
+	return msg
+}
+
+// Output messages. IDs are stable: catalogs are keyed by them.
+var (
+	helpMessage = addMessage(&i18n.Message{
+		ID:          "help",
+		Description: "Output of tk and tk --help. Keep the %d verbs: they are replaced by priority bounds.",
+		Other:       helpText,
+	})
+	msgUpdatedStatus   = addMessage(&i18n.Message{ID: "status.updated", Other: "Updated {{.ID}} -> {{.Status}}"})
+	msgClaimed         = addMessage(&i18n.Message{ID: "start.claimed", Other: "Claimed {{.ID}} -> in_progress"})
+	msgClaimedLease    = addMessage(&i18n.Message{ID: "start.claimed_lease", Other: "Claimed {{.ID}} -> in_progress (lease until {{.Until}})"})
+	msgAddedNote       = addMessage(&i18n.Message{ID: "note.added", Other: "Added note to {{.ID}}"})
+	msgAddedReply      = addMessage(&i18n.Message{ID: "note.replied", Other: "Added reply to note #{{.Note}} on {{.ID}}"})
+	msgAddedDep        = addMessage(&i18n.Message{ID: "dep.added", Other: "Added dependency: {{.ID}} -> {{.Dep}}"})
+	msgRemovedDep      = addMessage(&i18n.Message{ID: "dep.removed", Other: "Removed dependency: {{.ID}} -> {{.Dep}}"})
+	msgLinked          = addMessage(&i18n.Message{ID: "link.linked", Other: "Linked: {{.IDs}}"})
+	msgUnlinked        = addMessage(&i18n.Message{ID: "link.unlinked", Other: "Unlinked: {{.ID}} and {{.Other}}"})
+	msgNoCyclesFound   = addMessage(&i18n.Message{ID: "dep.no_cycles", Other: "No cycles detected"})
+	msgNoLeasedTickets = addMessage(&i18n.Message{ID: "leases.none", Other: "No leased tickets"})
+)
+
+// Errors shared by many commands.
+var (
+	msgErrorPrefix     = addMessage(&i18n.Message{ID: "error.prefix", Other: "Error:"})
+	msgWarningPrefix   = addMessage(&i18n.Message{ID: "warning.prefix", Other: "Warning:"})
+	msgCannotUpdate    = addMessage(&i18n.Message{ID: "error.cannot_update", Other: "cannot update {{.ID}}"})
+	msgNoCurrentUser   = addMessage(&i18n.Message{ID: "error.no_current_user", Other: "cannot determine current user: set TK_USER or git user.name"})
+	msgResolveFailed   = addMessage(&i18n.Message{ID: "error.resolve", Other: "failed to resolve ticket ID"})
+	msgNotFound        = addMessage(&i18n.Message{ID: "error.not_found", Other: "ticket not found: {{.ID}}"})
+	msgInvalidPriority = addMessage(&i18n.Message{ID: "error.invalid_priority", Other: "invalid priority {{.Priority}}: must be between {{.Min}} and {{.Max}} ({{.Min}}=highest)"})
+)
+
+// Output of list and show.
+var (
+	msgInvalidTagMode   = addMessage(&i18n.Message{ID: "list.invalid_tag_mode", Other: "invalid tag mode: {{.Mode}} (use {{.All}} or {{.Any}})"})
+	msgInvalidLimit     = addMessage(&i18n.Message{ID: "list.invalid_limit", Other: "invalid --limit {{.Limit}}: must not be negative"})
+	msgMineAssigneeFlag = addMessage(&i18n.Message{ID: "mine.assignee_flag", Other: "--{{.Flag}} does not apply to tk mine, which lists tickets assigned to you: use tk list"})
+	msgShowOverdue      = addMessage(&i18n.Message{ID: "show.overdue", Other: "Overdue: due {{.Due}}"})
+	msgShowBlockers     = addMessage(&i18n.Message{ID: "show.blockers", Other: "Blockers: {{.IDs}}"})
+	msgShowBlocking     = addMessage(&i18n.Message{ID: "show.blocking", Other: "Blocking: {{.IDs}}"})
+	msgShowChildren     = addMessage(&i18n.Message{ID: "show.children", Other: "Children: {{.IDs}}"})
+	msgShowRollup       = addMessage(&i18n.Message{ID: "show.estimate_rollup", Other: "Estimate: {{.Total}} ({{.Remaining}} remaining) from children"})
+	msgShowLinks        = addMessage(&i18n.Message{ID: "show.links", Other: "Links: {{.IDs}}"})
+	msgNoSection        = addMessage(&i18n.Message{ID: "show.no_section", Other: "ticket {{.ID}} has no section {{.Name}}"})
+	msgEmptySectionName = addMessage(&i18n.Message{ID: "show.empty_section_name", Other: "section name cannot be empty"})
+	msgNotesNotSection  = addMessage(&i18n.Message{ID: "show.notes_not_section", Other: "notes are not a plain section: use tk add-note or tk reply"})
+	msgTitleNotSection  = addMessage(&i18n.Message{ID: "show.title_not_section", Other: "the title is not a section: use tk edit"})
+)
+
+// Output of import and its conflict resolver.
+var (
+	msgImported              = addMessage(&i18n.Message{ID: "import.imported", Other: "Imported {{.Count}} ticket(s)"})
+	msgImportMerged          = addMessage(&i18n.Message{ID: "import.merged", Other: ", merged {{.Count}} existing"})
+	msgImportSkipped         = addMessage(&i18n.Message{ID: "import.skipped", Other: ", skipped {{.Count}} existing"})
+	msgImportGenerated       = addMessage(&i18n.Message{ID: "import.generated", Other: ", generated {{.Count}} ID(s)"})
+	msgImportExists          = addMessage(&i18n.Message{ID: "import.exists", Other: "ticket {{.ID}} already exists (use --skip-existing to skip or --merge to update)"})
+	msgImportReadFailed      = addMessage(&i18n.Message{ID: "import.read_failed", Other: "failed to read input"})
+	msgImportParseFailed     = addMessage(&i18n.Message{ID: "import.parse_failed", Other: "failed to parse JSON"})
+	msgImportConvertFailed   = addMessage(&i18n.Message{ID: "import.convert_failed", Other: "failed to convert ticket {{.ID}}"})
+	msgImportInvalidPrefer   = addMessage(&i18n.Message{ID: "import.invalid_prefer", Other: "invalid --prefer {{.Value}}: must be {{.Newest}}, {{.Local}} or {{.Remote}}"})
+	msgImportInvalidStrategy = addMessage(&i18n.Message{ID: "import.invalid_strategy", Other: "invalid --strategy {{.Value}}: must be {{.Ours}}, {{.Theirs}} or {{.Newest}}"})
+	msgImportRequiresMerge   = addMessage(&i18n.Message{ID: "import.requires_merge", Other: "{{.Flag}} requires --merge"})
+	msgConflict              = addMessage(&i18n.Message{ID: "import.conflict", Other: "Conflict in {{.ID}} field {{.Field}}:"})
+	msgConflictPanes         = addMessage(&i18n.Message{ID: "import.conflict_panes", Description: "The three pane titles, separated by |", Other: "LOCAL|REMOTE|RESULT"})
+	msgConflictPrompt        = addMessage(&i18n.Message{ID: "import.conflict_prompt", Description: "Keep the letters in brackets: they are the keys to press", Other: "[o]urs, [t]heirs, [e]dit result, all [O]urs/[T]heirs, [q]uit (Enter keeps result): "})
+	msgUnknownChoice         = addMessage(&i18n.Message{ID: "import.unknown_choice", Other: "Unknown choice {{.Choice}}"})
+)
+
+// Output of pr and prs.
+var (
+	msgPRLinked        = addMessage(&i18n.Message{ID: "pr.linked", Other: "Linked {{.URL}} to {{.ID}}"})
+	msgPRAlreadyLinked = addMessage(&i18n.Message{ID: "pr.already_linked", Other: "{{.URL}} is already linked to {{.ID}}"})
+	msgPRUnlinked      = addMessage(&i18n.Message{ID: "pr.unlinked", Other: "Unlinked {{.URL}} from {{.ID}}"})
+	msgPRNotLinked     = addMessage(&i18n.Message{ID: "pr.not_linked", Other: "{{.URL}} is not linked to {{.ID}}"})
+	msgPRInvalidURL    = addMessage(&i18n.Message{ID: "pr.invalid_url", Other: "invalid pull request URL {{.URL}}"})
+	msgPRUnknownForge  = addMessage(&i18n.Message{ID: "pr.unknown_forge", Other: "invalid pull request URL {{.URL}}: expected a GitHub pull request or GitLab merge request"})
+	msgPRsNone         = addMessage(&i18n.Message{ID: "prs.none", Other: "No tickets with linked pull requests"})
+	msgPRsStillOpen    = addMessage(&i18n.Message{ID: "prs.still_open", Other: "! merged but ticket still {{.Status}}"})
+	msgPRsStale        = addMessage(&i18n.Message{ID: "prs.stale", Other: "{{.Count}} ticket(s) have only merged pull requests but are not closed"})
+)
+
+// Output of triage.
+var (
+	msgTriageNothing        = addMessage(&i18n.Message{ID: "triage.nothing", Other: "Nothing to triage"})
+	msgTriageSummary        = addMessage(&i18n.Message{ID: "triage.summary", Other: "Triaged {{.Seen}} of {{.Total}} ticket(s): {{.Updated}} updated, {{.Closed}} closed, {{.Snoozed}} snoozed, {{.Skipped}} skipped"})
+	msgTriageActions        = addMessage(&i18n.Message{ID: "triage.actions", Description: "Keep the letters in brackets: they are the keys to press", Other: "[p]riority, [t]ag, [a]ssign, [c]lose, [s]kip, [z] snooze, [q]uit: "})
+	msgTriageSnoozed        = addMessage(&i18n.Message{ID: "triage.snoozed", Other: "Snoozed {{.ID}} until {{.Until}}"})
+	msgTriageUnknownAction  = addMessage(&i18n.Message{ID: "triage.unknown_action", Other: "Unknown action {{.Action}}"})
+	msgTriagePriorityPrompt = addMessage(&i18n.Message{ID: "triage.priority_prompt", Other: "Priority ({{.Min}}-{{.Max}}, {{.Min}}=highest) [{{.Current}}]: "})
+	msgTriagePrioritySet    = addMessage(&i18n.Message{ID: "triage.priority_set", Other: "Set {{.ID}} priority to P{{.Priority}}"})
+	msgTriageTagsPrompt     = addMessage(&i18n.Message{ID: "triage.tags_prompt", Other: "Tags to add, comma-separated (-tag removes): "})
+	msgTriageTagged         = addMessage(&i18n.Message{ID: "triage.tagged", Other: "Tagged {{.ID}}: {{.Tags}}"})
+	msgTriageAssignPrompt   = addMessage(&i18n.Message{ID: "triage.assignee_prompt", Other: "Assignee ({{.Me}} for you, - to unassign) [{{.Current}}]: "})
+	msgTriageAssigned       = addMessage(&i18n.Message{ID: "triage.assigned", Other: "Assigned {{.ID}} to {{.Assignee}}"})
+	msgTriageUnassigned     = addMessage(&i18n.Message{ID: "triage.unassigned", Other: "Unassigned {{.ID}}"})
+	msgTriageCannotUpdate   = addMessage(&i18n.Message{ID: "triage.cannot_update", Other: "cannot update {{.ID}}"})
+)
+
+// Output of stats and summary.
+var (
+	msgStatsInvalidWeeks  = addMessage(&i18n.Message{ID: "stats.invalid_weeks", Other: "invalid --weeks {{.Weeks}}: must be at least 1"})
+	msgStatsTotal         = addMessage(&i18n.Message{ID: "stats.total", Other: "Total: {{.Count}} tickets"})
+	msgStatsCounts        = addMessage(&i18n.Message{ID: "stats.counts", Other: "Ready: {{.Ready}}, blocked: {{.Blocked}}, overdue: {{.Overdue}}"})
+	msgStatsByStatus      = addMessage(&i18n.Message{ID: "stats.by_status", Other: "By Status:"})
+	msgStatsByResolution  = addMessage(&i18n.Message{ID: "stats.by_resolution", Other: "By Resolution:"})
+	msgStatsByType        = addMessage(&i18n.Message{ID: "stats.by_type", Other: "By Type:"})
+	msgStatsByPriority    = addMessage(&i18n.Message{ID: "stats.by_priority", Other: "By Priority:"})
+	msgStatsByAssignee    = addMessage(&i18n.Message{ID: "stats.by_assignee", Other: "By Assignee:"})
+	msgStatsHeatmapLegend = addMessage(&i18n.Message{ID: "stats.heatmap_legend", Description: "Keep {{.Shades}} between the two words: it is the scale of shades", Other: "Less {{.Shades}} More"})
+	msgStatsClosedWeeks   = addMessage(&i18n.Message{ID: "stats.closed_weeks", Other: "{{.Count}} closed in the last {{.Weeks}} weeks"})
+	msgSummaryTickets     = addMessage(&i18n.Message{ID: "summary.tickets", Other: "Tickets: {{.Total}} ({{.Counts}})"})
+	msgSummaryBlocked     = addMessage(&i18n.Message{ID: "summary.blocked", Other: "Blocked: {{.Count}}"})
+	msgSummaryOverdueN    = addMessage(&i18n.Message{ID: "summary.overdue_count", Other: "Overdue: {{.Count}}"})
+	msgSummaryReady       = addMessage(&i18n.Message{ID: "summary.ready", Other: "Ready ({{.Count}} of {{.Total}}):"})
+	msgSummaryInProgress  = addMessage(&i18n.Message{ID: "summary.in_progress", Other: "In progress:"})
+	msgSummaryOverdue     = addMessage(&i18n.Message{ID: "summary.overdue", Other: "Overdue:"})
+	msgSummaryDue         = addMessage(&i18n.Message{ID: "summary.due", Other: "(due {{.Due}})"})
+	msgSummaryNone        = addMessage(&i18n.Message{ID: "summary.none", Other: "none"})
+)
+
+// Output of the bulk commands. The verbs fill {{.Verb}} in the other messages.
+var (
+	msgBulkVerbClosed   = addMessage(&i18n.Message{ID: "bulk.verb.closed", Other: "closed"})
+	msgBulkVerbReopened = addMessage(&i18n.Message{ID: "bulk.verb.reopened", Other: "reopened"})
+	msgBulkVerbStarted  = addMessage(&i18n.Message{ID: "bulk.verb.started", Other: "started"})
+	msgBulkNoMatch      = addMessage(&i18n.Message{ID: "bulk.no_match", Other: "No tickets match the specified filters"})
+	msgBulkDryRun       = addMessage(&i18n.Message{ID: "bulk.dry_run", Other: "Dry run: would {{.Verb}} {{.Count}} ticket(s):"})
+	msgBulkUpdated      = addMessage(&i18n.Message{ID: "bulk.updated", Other: "{{.Verb}} {{.ID}}"})
+	msgBulkNoneNeeded   = addMessage(&i18n.Message{ID: "bulk.none_needed", Other: "No tickets needed updating (all already {{.Status}})"})
+	msgBulkSucceeded    = addMessage(&i18n.Message{ID: "bulk.succeeded", Other: "Successfully {{.Verb}} {{.Count}} ticket(s)"})
+)
+
+// Output of undo, history, activity and watchlist. The event labels name
+// journal events in the feeds.
+var (
+	msgUndoInvalidCount   = addMessage(&i18n.Message{ID: "undo.invalid_count", Other: "invalid count {{.Count}}: must be a positive integer"})
+	msgUndoNothing        = addMessage(&i18n.Message{ID: "undo.nothing", Other: "Nothing to undo"})
+	msgUndoReverted       = addMessage(&i18n.Message{ID: "undo.reverted", Other: "Reverted {{.Event}}"})
+	msgHistoryNone        = addMessage(&i18n.Message{ID: "history.none", Other: "no history for {{.ID}}"})
+	msgEventCreated       = addMessage(&i18n.Message{ID: "event.created", Other: "created"})
+	msgEventStarted       = addMessage(&i18n.Message{ID: "event.started", Other: "started"})
+	msgEventClosed        = addMessage(&i18n.Message{ID: "event.closed", Other: "closed"})
+	msgEventReopened      = addMessage(&i18n.Message{ID: "event.reopened", Other: "reopened"})
+	msgEventNoted         = addMessage(&i18n.Message{ID: "event.noted", Other: "noted"})
+	msgEventReviewed      = addMessage(&i18n.Message{ID: "event.reviewed", Other: "reviewed"})
+	msgEventWatchers      = addMessage(&i18n.Message{ID: "event.watchers", Other: "watchers"})
+	msgEventDeps          = addMessage(&i18n.Message{ID: "event.deps", Other: "deps"})
+	msgEventLinked        = addMessage(&i18n.Message{ID: "event.linked", Other: "linked"})
+	msgEventDeleted       = addMessage(&i18n.Message{ID: "event.deleted", Other: "deleted"})
+	msgEventArchived      = addMessage(&i18n.Message{ID: "event.archived", Other: "archived"})
+	msgEventUndone        = addMessage(&i18n.Message{ID: "event.undone", Other: "undone"})
+	msgEventUpdated       = addMessage(&i18n.Message{ID: "event.updated", Other: "updated"})
+	msgEventUpdatedFields = addMessage(&i18n.Message{ID: "event.updated_fields", Description: "{{.Fields}} are field names, which stay untranslated", Other: "updated {{.Fields}}"})
+)
+
+// Output of watch and watchlist.
+var (
+	msgWatchAlready     = addMessage(&i18n.Message{ID: "watch.already", Other: "{{.User}} already watches {{.ID}}"})
+	msgWatchAdded       = addMessage(&i18n.Message{ID: "watch.added", Other: "{{.User}} now watches {{.ID}}"})
+	msgWatchNotWatching = addMessage(&i18n.Message{ID: "watch.not_watching", Other: "{{.User}} does not watch {{.ID}}"})
+	msgWatchRemoved     = addMessage(&i18n.Message{ID: "watch.removed", Other: "{{.User}} no longer watches {{.ID}}"})
+	msgWatchEmptyUser   = addMessage(&i18n.Message{ID: "watch.empty_watcher", Other: "watcher must not be empty"})
+	msgWatchlistEmpty   = addMessage(&i18n.Message{ID: "watchlist.empty", Other: "{{.User}} watches no tickets"})
+	msgWatchlistIdle    = addMessage(&i18n.Message{ID: "watchlist.no_activity", Other: "no recent activity"})
+	msgNotifyFailed     = addMessage(&i18n.Message{ID: "notify.failed", Other: "{{.Command}} failed for {{.ID}}: {{.Error}}"})
+)
+
+// Output of gc.
+var (
+	msgGCNoPolicy      = addMessage(&i18n.Message{ID: "gc.no_policy", Other: "no retention policy: set retention.closed in {{.File}}"})
+	msgGCInvalidAction = addMessage(&i18n.Message{ID: "gc.invalid_action", Other: "invalid retention.action {{.Action}} in {{.File}}: must be {{.Archive}} or {{.Delete}}"})
+	msgGCInvalidClosed = addMessage(&i18n.Message{ID: "gc.invalid_closed", Other: "invalid retention.closed in {{.File}}"})
+	msgGCWouldArchive  = addMessage(&i18n.Message{ID: "gc.would_archive", Other: "Would archive {{.ID}} (closed {{.Age}} ago)"})
+	msgGCWouldDelete   = addMessage(&i18n.Message{ID: "gc.would_delete", Other: "Would delete {{.ID}} (closed {{.Age}} ago)"})
+	msgGCArchived      = addMessage(&i18n.Message{ID: "gc.archived", Other: "Archived {{.ID}} (closed {{.Age}} ago)"})
+	msgGCDeleted       = addMessage(&i18n.Message{ID: "gc.deleted", Other: "Deleted {{.ID}} (closed {{.Age}} ago)"})
+	msgGCNothing       = addMessage(&i18n.Message{ID: "gc.nothing", Other: "Nothing to clean up"})
+)
+
+// Output of forecast, report, pick and diff.
+var (
+	msgForecastInvalidFlags = addMessage(&i18n.Message{ID: "forecast.invalid_flags", Other: "--weeks and --trials must be positive"})
+	msgForecastNoHistory    = addMessage(&i18n.Message{ID: "forecast.no_history", Other: "no tickets closed in the history window: cannot forecast (try a larger --weeks)"})
+	msgForecastRemaining    = addMessage(&i18n.Message{ID: "forecast.remaining", Description: "The values of the forecast lines are aligned", Other: "Remaining:  {{.Count}} tickets"})
+	msgForecastThroughput   = addMessage(&i18n.Message{ID: "forecast.throughput", Other: "Throughput: {{.Throughput}}/week over the last {{.Weeks}} weeks"})
+	msgForecastNothing      = addMessage(&i18n.Message{ID: "forecast.nothing", Other: "Nothing left to do"})
+	msgForecastP50          = addMessage(&i18n.Message{ID: "forecast.p50", Other: "P50:        {{.Date}} ({{.Weeks}} weeks)"})
+	msgForecastP85          = addMessage(&i18n.Message{ID: "forecast.p85", Other: "P85:        {{.Date}} ({{.Weeks}} weeks)"})
+	msgReportInvalidGroupBy = addMessage(&i18n.Message{ID: "report.invalid_group_by", Other: "invalid --group-by {{.Value}}: must be {{.Tag}} or {{.Assignee}}"})
+	msgReportColumns        = addMessage(&i18n.Message{ID: "report.columns", Description: "The column headings after the group column, separated by |", Other: "TICKETS|ESTIMATE|DONE|REMAINING"})
+	msgPickNone             = addMessage(&i18n.Message{ID: "pick.none", Other: "no ready tickets to pick from"})
+	msgDiffNotGit           = addMessage(&i18n.Message{ID: "diff.not_git", Other: "{{.Dir}} is not inside a git repository"})
+)
+
+// Output of lint, start and the policy checks.
+var (
+	msgLintNone         = addMessage(&i18n.Message{ID: "lint.none", Other: "No problems found"})
+	msgLintFound        = addMessage(&i18n.Message{ID: "lint.found", Other: "{{.Count}} problem(s) found"})
+	msgLintClosedDep    = addMessage(&i18n.Message{ID: "lint.closed_dep", Other: "depends on closed ticket {{.Dep}} (use tk dep remove)"})
+	msgPolicyRefused    = addMessage(&i18n.Message{ID: "policy.refused", Other: "{{.Violations}} (use --force to override)"})
+	msgStartCannot      = addMessage(&i18n.Message{ID: "start.cannot_start", Other: "cannot start {{.ID}}"})
+	msgStartCannotClaim = addMessage(&i18n.Message{ID: "start.cannot_claim", Other: "cannot claim {{.ID}}"})
+	msgStartRefused     = addMessage(&i18n.Message{ID: "start.refused", Other: "cannot start {{.ID}}: {{.Violation}} (use --force to override)"})
+	msgWIPAssignee      = addMessage(&i18n.Message{ID: "start.wip_assignee", Other: "WIP limit reached: {{.User}} already has {{.Count}} in_progress ticket(s) (limit {{.Limit}})"})
+	msgWIPTag           = addMessage(&i18n.Message{ID: "start.wip_tag", Other: "WIP limit reached: {{.Count}} in_progress ticket(s) tagged {{.Tag}} (limit {{.Limit}})"})
+)
+
+// Output of dep and dep edit.
+var (
+	msgDepInvalidTicket = addMessage(&i18n.Message{ID: "dep.invalid_ticket", Other: "invalid ticket"})
+	msgDepInvalid       = addMessage(&i18n.Message{ID: "dep.invalid", Other: "invalid dependency"})
+	msgDepSelf          = addMessage(&i18n.Message{ID: "dep.self", Other: "ticket cannot depend on itself"})
+	msgDepExists        = addMessage(&i18n.Message{ID: "dep.exists", Other: "dependency {{.Dep}} already exists"})
+	msgDepNotOn         = addMessage(&i18n.Message{ID: "dep.not_on", Other: "dependency {{.Dep}} not found on {{.ID}}"})
+	msgDepClosed        = addMessage(&i18n.Message{ID: "dep.closed", Other: "dependency {{.Dep}} is closed and would never block {{.ID}} (use --force to add it anyway)"})
+	msgDepClosedWarning = addMessage(&i18n.Message{ID: "dep.closed_warning", Other: "dependency {{.Dep}} is closed and will never block {{.ID}}"})
+	msgDepCycle         = addMessage(&i18n.Message{ID: "dep.cycle", Other: "adding dependency would create a cycle: {{.ID}} -> {{.Dep}}"})
+	msgDepCycleDetected = addMessage(&i18n.Message{ID: "dep.cycle_detected", Other: "cycle detected in dependencies"})
+	msgDepCyclesFound   = addMessage(&i18n.Message{ID: "dep.cycles_found", Other: "Found {{.Count}} cycle(s):"})
+	msgDepCyclesError   = addMessage(&i18n.Message{ID: "dep.cycles_error", Other: "dependency cycles detected"})
+	msgDepBadFormat     = addMessage(&i18n.Message{ID: "dep.unsupported_format", Other: "unsupported format: {{.Format}} (use json)"})
+	msgDepNoChanges     = addMessage(&i18n.Message{ID: "dep.no_changes", Other: "No dependency changes"})
+	msgDepEditHeader    = addMessage(&i18n.Message{ID: "dep.edit_header", Description: "Comment lines atop the tk dep edit file: keep the # starting each line", Other: "# Dependencies of {{.ID}}: {{.Title}}\n# One ticket ID per line; add lines to add dependencies, delete them to remove.\n# Everything after # is ignored."})
+	msgDepEditMissing   = addMessage(&i18n.Message{ID: "dep.edit_missing", Other: "(missing)"})
+	msgDepEditLineIDs   = addMessage(&i18n.Message{ID: "dep.edit_line_ids", Other: "line {{.Line}}: expected one ticket ID, got {{.Text}}"})
+	msgDepEditLineDep   = addMessage(&i18n.Message{ID: "dep.edit_line_invalid", Other: "line {{.Line}}: invalid dependency"})
+	msgDepEditLineSelf  = addMessage(&i18n.Message{ID: "dep.edit_line_self", Other: "line {{.Line}}: ticket cannot depend on itself"})
+)
+
+// Output of note.
+var (
+	msgNoteInvalidNumber   = addMessage(&i18n.Message{ID: "note.invalid_number", Other: "invalid note number {{.Number}}: {{.ID}} has {{.Count}} note(s)"})
+	msgNoteEmpty           = addMessage(&i18n.Message{ID: "note.empty", Other: "no note text provided"})
+	msgNoteInvalidKeepLast = addMessage(&i18n.Message{ID: "note.invalid_keep_last", Other: "invalid --keep-last {{.Count}}: must not be negative"})
+	msgNoteNothingCompact  = addMessage(&i18n.Message{ID: "note.nothing_to_compact", Other: "Nothing to compact on {{.ID}}"})
+	msgNoteCompacted       = addMessage(&i18n.Message{ID: "note.compacted", Other: "Compacted {{.Count}} notes on {{.ID}} into 1"})
+	msgNoteSavedTo         = addMessage(&i18n.Message{ID: "note.saved_to", Other: "Saved them to {{.Path}}"})
+)
+
+// Output of the commands that change one ticket field.
+var (
+	msgInvalidReason     = addMessage(&i18n.Message{ID: "close.invalid_reason", Other: "invalid --reason {{.Reason}}: must be {{.Valid}}"})
+	msgParentNotFound    = addMessage(&i18n.Message{ID: "create.parent_not_found", Other: "parent ticket not found: {{.ID}}"})
+	msgCannotCreate      = addMessage(&i18n.Message{ID: "create.cannot_create", Other: "cannot create ticket"})
+	msgEnsureNeedsRef    = addMessage(&i18n.Message{ID: "ensure.needs_ref", Other: "--external-ref is required"})
+	msgEnsureAmbiguous   = addMessage(&i18n.Message{ID: "ensure.ambiguous", Other: "external ref {{.Ref}} is on {{.Count}} tickets: {{.IDs}}"})
+	msgDueCleared        = addMessage(&i18n.Message{ID: "due.cleared", Other: "Cleared due date of {{.ID}}"})
+	msgDueSet            = addMessage(&i18n.Message{ID: "due.set", Other: "Set {{.ID}} due {{.Due}}"})
+	msgTouched           = addMessage(&i18n.Message{ID: "touch.touched", Other: "Touched {{.ID}}"})
+	msgEstimateNotNumber = addMessage(&i18n.Message{ID: "estimate.not_number", Other: "invalid estimate {{.Estimate}}: must be a number"})
+	msgEstimateInvalid   = addMessage(&i18n.Message{ID: "estimate.invalid", Other: "invalid estimate {{.Estimate}}: must be a non-negative number"})
+	msgEstimated         = addMessage(&i18n.Message{ID: "estimate.set", Other: "Estimated {{.ID}} at {{.Estimate}}"})
+	msgAlreadyLocked     = addMessage(&i18n.Message{ID: "lock.already_locked", Other: "ticket {{.ID}} is already locked"})
+	msgNotLocked         = addMessage(&i18n.Message{ID: "lock.not_locked", Other: "ticket {{.ID}} is not locked"})
+	msgLocked            = addMessage(&i18n.Message{ID: "lock.locked", Other: "Locked {{.ID}}"})
+	msgUnlocked          = addMessage(&i18n.Message{ID: "lock.unlocked", Other: "Unlocked {{.ID}}"})
+	msgInvalidReviewer   = addMessage(&i18n.Message{ID: "review.invalid_reviewer", Other: "invalid reviewer: {{.Reviewer}}"})
+	msgReviewRequested   = addMessage(&i18n.Message{ID: "review.requested", Other: "Requested review of {{.ID}} from {{.Reviewers}}"})
+	msgReviewApproved    = addMessage(&i18n.Message{ID: "review.approved", Other: "Approved {{.ID}}"})
+	msgReviewRejected    = addMessage(&i18n.Message{ID: "review.rejected", Other: "Rejected {{.ID}}"})
+	msgDuplicateID       = addMessage(&i18n.Message{ID: "link.duplicate", Other: "duplicate ticket ID: {{.ID}}"})
+	msgNoLink            = addMessage(&i18n.Message{ID: "link.none", Other: "no link found between {{.ID}} and {{.Other}}"})
+	msgEditKept          = addMessage(&i18n.Message{ID: "edit.kept", Other: "nothing was saved; your edit is kept in {{.Path}}"})
+	msgEditorFailed      = addMessage(&i18n.Message{ID: "edit.editor_failed", Other: "editor failed"})
+)
+
+// Output of move, export, query, grep and schema.
+var (
+	msgMoveNeedsTo        = addMessage(&i18n.Message{ID: "move.needs_to", Other: "--to is required"})
+	msgMoveSameDir        = addMessage(&i18n.Message{ID: "move.same_dir", Other: "tickets are already in {{.Dir}}"})
+	msgMoveExists         = addMessage(&i18n.Message{ID: "move.exists", Other: "ticket {{.ID}} already exists in {{.Dir}}"})
+	msgMoveRefLocked      = addMessage(&i18n.Message{ID: "move.ref_locked", Other: "{{.ID}} references moved tickets; unlock it with tk unlock"})
+	msgMoved              = addMessage(&i18n.Message{ID: "move.moved", Other: "Moved {{.ID}} to {{.Dir}}"})
+	msgMoveDropped        = addMessage(&i18n.Message{ID: "move.dropped", Other: "{{.ID}}: dropped {{.Ref}}"})
+	msgExportBadFormat    = addMessage(&i18n.Message{ID: "export.unsupported_format", Other: "unsupported format: {{.Format}} (use json or csv)"})
+	msgExportInvalidSplit = addMessage(&i18n.Message{ID: "export.invalid_split_by", Other: "invalid --split-by {{.Value}}: must be {{.Status}}, {{.Assignee}} or {{.Tag}}"})
+	msgExportWrote        = addMessage(&i18n.Message{ID: "export.wrote", Other: "Wrote {{.Count}} ticket(s) to {{.Path}}"})
+	msgQueryExplainFilter = addMessage(&i18n.Message{ID: "query.explain_needs_filter", Other: "--explain needs a jq filter"})
+	msgQueryExplainJQ     = addMessage(&i18n.Message{ID: "query.explain_needs_jq", Other: "--explain needs jq"})
+	msgQueryInvalidFilter = addMessage(&i18n.Message{ID: "query.invalid_filter", Other: "invalid jq filter: {{.Error}}"})
+	msgQueryFailed        = addMessage(&i18n.Message{ID: "query.failed", Other: "jq failed: {{.Error}}"})
+	msgQueryLoaded        = addMessage(&i18n.Message{ID: "query.loaded", Description: "The values of the --explain lines are aligned", Other: "Loaded:   {{.Count}} tickets"})
+	msgQueryFiltered      = addMessage(&i18n.Message{ID: "query.filtered", Other: "Filtered: {{.Count}} tickets after date filters"})
+	msgQueryStages        = addMessage(&i18n.Message{ID: "query.stages", Other: "Stages:"})
+	msgGrepInvalidPattern = addMessage(&i18n.Message{ID: "grep.invalid_pattern", Other: "invalid pattern"})
+	msgSchemaUnknown      = addMessage(&i18n.Message{ID: "schema.unknown", Other: "unknown schema {{.Kind}}: must be {{.Ticket}}, {{.Frontmatter}}, {{.Import}} or {{.Stats}}"})
+)
+
+// The active localizer, and the message IDs of each loaded catalog keyed by language tag.
+var (
+	localizer       = i18n.NewLocalizer(i18n.NewBundle(language.English))
+	catalogMessages = map[string]map[string]bool{}
+)
+
+// englishLocalizer renders the built-in English messages when a translation fails.
+var englishLocalizer = i18n.NewLocalizer(i18n.NewBundle(language.English))
+
+// commandText is a command's short or long help, which catalogs translate
+// under IDs such as tk.dep.add.short.
+type commandText struct {
+	field *string
+	msg   *i18n.Message
+}
+
+// commandTexts holds the English help of every command, collected on first use.
+var commandTexts []commandText
+
+// setLanguage loads the catalogs in ticketsDir's locales directory and
+// switches output and the help of root's command tree to lang, or to the
+// locale from the environment when lang is empty. Untranslated messages stay
+// in English.
+func setLanguage(root *cobra.Command, ticketsDir, lang string) error {
+	bundle := i18n.NewBundle(language.English)
+	bundle.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
+	bundle.RegisterUnmarshalFunc("yml", yaml.Unmarshal)
+
+	loaded, err := loadCatalogs(bundle, filepath.Join(ticketsDir, LocalesDirName))
+	if err != nil {
+		return err
+	}
+
+	if lang == "" {
+		lang = localeLanguage()
+	}
+	localizer = i18n.NewLocalizer(bundle, lang)
+	catalogMessages = loaded
+	localizeCommands(root)
+	root.SetErrPrefix(tr(msgErrorPrefix, nil))
+	return nil
+}
+
+// setHelpLanguage localizes help output, which cobra prints without running
+// PersistentPreRunE. Errors are left for the command itself to report.
+func setHelpLanguage(root *cobra.Command) {
+	c, err := config.Load()
+	if err != nil {
+		return
+	}
+	_ = setLanguage(root, c.TicketsDir, c.Language)
+}
+
+// loadCatalogs adds the message catalogs in dir to bundle and returns the
+// message IDs each one translates. A missing directory is not an error.
+func loadCatalogs(bundle *i18n.Bundle, dir string) (map[string]map[string]bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]map[string]bool{}, nil
+		}
+		return nil, fmt.Errorf("failed to read locales directory: %w", err)
+	}
+
+	loaded := map[string]map[string]bool{}
+	for _, entry := range entries {
+		if entry.IsDir() || !hasCatalogExtension(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		file, err := bundle.LoadMessageFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load message catalog %s: %w", path, err)
+		}
+		tag := file.Tag.String()
+		if loaded[tag] == nil {
+			loaded[tag] = map[string]bool{}
+		}
+		for _, msg := range file.Messages {
+			loaded[tag][msg.ID] = true
+		}
+	}
+	return loaded, nil
+}
+
+func hasCatalogExtension(name string) bool {
+	for _, ext := range catalogExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// localeLanguage returns the language of the first set of LC_ALL,
+// LC_MESSAGES and LANG as a BCP 47 tag, e.g. de-DE for de_DE.UTF-8.
+// The C and POSIX locales mean no preference.
+func localeLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+		locale, _, _ = strings.Cut(locale, ".")
+		locale, _, _ = strings.Cut(locale, "@")
+		if locale == "C" || locale == "POSIX" {
+			return ""
+		}
+		return strings.ReplaceAll(locale, "_", "-")
+	}
+	return ""
+}
+
+// tr renders msg in the output language with data as its template data,
+// falling back to English when the translation cannot be rendered.
+func tr(msg *i18n.Message, data map[string]any) string {
+	lc := &i18n.LocalizeConfig{DefaultMessage: msg, TemplateData: data}
+	if s, err := localizer.Localize(lc); err == nil || s != "" {
+		return s
+	}
+	s, _ := englishLocalizer.Localize(lc)
+	return s
+}
+
+// trText returns msg in the output language verbatim, without template
+// expansion, for texts such as help that may contain {{ themselves.
+func trText(msg *i18n.Message) string {
+	s, err := localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: msg,
+		TemplateParser: template.IdentityParser{},
+	})
+	if err != nil && s == "" {
+		return msg.Other
+	}
+	return s
+}
+
+// trFormat returns msg in the output language for use as a Printf format. A
+// translation whose formatting verbs differ from the English ones would
+// garble the output, so it falls back to English.
+func trFormat(msg *i18n.Message) string {
+	s := trText(msg)
+	if !slices.Equal(formatVerbs(s), formatVerbs(msg.Other)) {
+		return msg.Other
+	}
+	return s
+}
+
+// formatVerbPattern matches a Printf verb with its flags, width and precision.
+var formatVerbPattern = regexp.MustCompile(`%[-+# 0]*[0-9*]*(?:\.[0-9*]*)?[a-zA-Z%]`)
+
+// formatVerbs returns the Printf verbs of format in order, without %%.
+func formatVerbs(format string) []string {
+	return slices.DeleteFunc(formatVerbPattern.FindAllString(format, -1), func(v string) bool {
+		return v == "%%"
+	})
+}
+
+// localizedError is an error whose text is in the output language. It still
+// wraps err, if any, so errors.Is matches it.
+type localizedError struct {
+	text string
+	err  error
+}
+
+func (e *localizedError) Error() string { return e.text }
+
+func (e *localizedError) Unwrap() error { return e.err }
+
+// trError returns an error reading msg in the output language, matching err
+// with errors.Is.
+func trError(msg *i18n.Message, data map[string]any, err error) error {
+	return &localizedError{text: tr(msg, data), err: err}
+}
+
+// trWrap returns err prefixed with msg in the output language.
+func trWrap(msg *i18n.Message, data map[string]any, err error) error {
+	return fmt.Errorf("%s: %w", tr(msg, data), err)
+}
+
+// localizeCommands sets the short and long help of root and its subcommands
+// in the output language.
+func localizeCommands(root *cobra.Command) {
+	if commandTexts == nil {
+		commandTexts = collectCommandTexts(root, nil)
+	}
+	for _, text := range commandTexts {
+		*text.field = trText(text.msg)
+	}
+}
+
+// collectCommandTexts appends the English short and long help of cmd and its
+// subcommands to texts.
+func collectCommandTexts(cmd *cobra.Command, texts []commandText) []commandText {
+	prefix := strings.ReplaceAll(cmd.CommandPath(), " ", ".")
+	if cmd.Short != "" {
+		texts = append(texts, commandText{&cmd.Short, &i18n.Message{ID: prefix + ".short", Other: cmd.Short}})
+	}
+	if cmd.Long != "" {
+		texts = append(texts, commandText{&cmd.Long, &i18n.Message{ID: prefix + ".long", Other: cmd.Long}})
+	}
+	for _, sub := range cmd.Commands() {
+		texts = collectCommandTexts(sub, texts)
+	}
+	return texts
+}
+
+var i18nFlags struct {
+	output  string
+	missing string
+}
+
+var i18nCmd = &cobra.Command{
+	Use:   "i18n",
+	Short: "Work with translated message catalogs",
+	Long: `Work with the message catalogs that translate tk's output and help.
+
+Catalogs are go-i18n YAML or JSON files in .tickets/locales named
+active.<lang>.yaml, mapping message IDs to translated text. The output
+language comes from TK_LANG, the language config key, or the locale
+(LC_ALL, LC_MESSAGES, LANG), in that order. Messages a catalog does not
+translate stay in English.`,
+}
+
+var i18nExtractCmd = &cobra.Command{
+	Use:   "extract",
+	Short: "Write the English message catalog translators start from",
+	Long: `Write every translatable message - command output, the tk help text and the
+short and long help of each command - as a YAML catalog keyed by message ID.
+Copy it to .tickets/locales/active.<lang>.yaml and translate the values.
+Output messages use Go template fields such as {{.ID}}; keep them. The help
+text uses %d verbs instead, and a translation that changes them is ignored.
+
+With --missing <lang> only the messages the <lang> catalog does not translate
+yet are written, which is what translators need after upgrading tk. The file
+also works with the goi18n merge tool.
+
+Examples:
+  tk i18n extract -o active.en.yaml
+  tk i18n extract --missing de`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var translated map[string]bool
+		if i18nFlags.missing != "" {
+			tag, err := language.Parse(i18nFlags.missing)
+			if err != nil {
+				return fmt.Errorf("invalid language %q: %w", i18nFlags.missing, err)
+			}
+			translated = catalogMessages[tag.String()]
+		}
+
+		catalog := map[string]any{}
+		for _, msg := range extractMessages() {
+			if translated[msg.ID] {
+				continue
+			}
+			if msg.Description != "" {
+				catalog[msg.ID] = map[string]string{"description": msg.Description, "other": msg.Other}
+			} else {
+				catalog[msg.ID] = msg.Other
+			}
+		}
+
+		data, err := yaml.Marshal(catalog)
+		if err != nil {
+			return fmt.Errorf("failed to encode catalog: %w", err)
+		}
+		if i18nFlags.output == "" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(i18nFlags.output, data, 0644); err != nil {
+			return fmt.Errorf("failed to write catalog: %w", err)
+		}
+		return nil
+	},
+}
+
+// extractMessages returns the output messages followed by the command help,
+// all in English.
+func extractMessages() []*i18n.Message {
+	extracted := append([]*i18n.Message{}, messages...)
+	for _, text := range commandTexts {
+		extracted = append(extracted, text.msg)
+	}
+	return extracted
+}
+
+func init() {
+	i18nExtractCmd.Flags().StringVarP(&i18nFlags.output, "output", "o", "", "Write the catalog to this file instead of stdout")
+	i18nExtractCmd.Flags().StringVar(&i18nFlags.missing, "missing", "", "Only write messages the catalog of this language does not translate")
+	i18nCmd.AddCommand(i18nExtractCmd)
+}
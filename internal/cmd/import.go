@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -14,30 +17,77 @@ import (
 
 var importFlags struct {
 	skipExisting bool
+	merge        bool
+	prefer       string
+	strategy     string
+	mapping      string
+}
+
+// Merge preferences accepted by tk import --prefer.
+const (
+	PreferNewest = "newest"
+	PreferLocal  = "local"
+	PreferRemote = "remote"
+)
+
+// mergeFields lists the fields tk import --merge updates, by JSON key, which
+// matches the domain.Ticket field name. Locks and leases are local state that
+// an import never changes.
+var mergeFields = []string{
+	"Status", "ClosedAt", "Resolution", "Due", "Type", "Priority", "Estimate", "Assignee", "Parent",
+	"ExternalRef", "Tags", "Deps", "Links", "Watchers", "PRs", "Reviews",
+	"Title", "Description", "Sections", "Design", "Acceptance", "Notes",
 }
 
 // importTicket is a struct for JSON import that mirrors domain.Ticket
 // but uses concrete types for unmarshaling.
 type importTicket struct {
-	ID          string    `json:"ID"`
-	Status      string    `json:"Status"`
-	Type        string    `json:"Type"`
-	Priority    int       `json:"Priority"`
-	Assignee    string    `json:"Assignee"`
-	Parent      string    `json:"Parent"`
-	ExternalRef string    `json:"ExternalRef"`
-	Tags        []string  `json:"Tags"`
-	Deps        []string  `json:"Deps"`
-	Links       []string  `json:"Links"`
-	Created     time.Time `json:"Created"`
-	Title       string    `json:"Title"`
-	Description string    `json:"Description"`
-	Design      string    `json:"Design"`
-	Acceptance  string    `json:"Acceptance"`
-	Notes       []struct {
-		Timestamp time.Time `json:"Timestamp"`
-		Content   string    `json:"Content"`
-	} `json:"Notes"`
+	ID          string       `json:"ID"`
+	Status      string       `json:"Status"`
+	Type        string       `json:"Type"`
+	Priority    int          `json:"Priority"`
+	Assignee    string       `json:"Assignee"`
+	Parent      string       `json:"Parent"`
+	ExternalRef string       `json:"ExternalRef"`
+	Tags        []string     `json:"Tags"`
+	Deps        []string     `json:"Deps"`
+	Links       []string     `json:"Links"`
+	Created     time.Time    `json:"Created"`
+	ClosedAt    time.Time    `json:"ClosedAt"`
+	Resolution  string       `json:"Resolution"`
+	Title       string       `json:"Title"`
+	Description string       `json:"Description"`
+	Design      string       `json:"Design"`
+	Acceptance  string       `json:"Acceptance"`
+	Notes       []importNote `json:"Notes"`
+
+	// Restored as exported so a backup round-trips unchanged
+	Estimate      float64         `json:"Estimate"`
+	UpdatedAt     time.Time       `json:"UpdatedAt"`
+	LastUpdatedBy string          `json:"LastUpdatedBy"`
+	Revision      int             `json:"Revision"`
+	Locked        bool            `json:"Locked"`
+	LockReason    string          `json:"LockReason"`
+	Reviews       []domain.Review `json:"Reviews"`
+	Sections      []importSection `json:"Sections"`
+	Due           time.Time       `json:"Due"`
+	LeaseUntil    time.Time       `json:"LeaseUntil"`
+	LeaseHolder   string          `json:"LeaseHolder"`
+	Watchers      []string        `json:"Watchers"`
+	PRs           []string        `json:"PRs"`
+}
+
+// importSection mirrors domain.Section for JSON import.
+type importSection struct {
+	Name    string `json:"Name"`
+	Content string `json:"Content"`
+}
+
+// importNote mirrors domain.Note for JSON import.
+type importNote struct {
+	Timestamp time.Time `json:"Timestamp"`
+	Content   string    `json:"Content"`
+	ReplyTo   int       `json:"ReplyTo"`
 }
 
 var importCmd = &cobra.Command{
@@ -46,9 +96,56 @@ var importCmd = &cobra.Command{
 	Long: `Import tickets from a JSON file. The file should contain an array of tickets
 in the same format as produced by 'tk export' or 'tk query'.
 
+Tickets are written exactly as exported, including revision, update stamps,
+notes and custom body sections, so export followed by import restores every
+field. Files come back in the layout tk writes: custom sections follow the
+description wherever they were, and spacing is normalized.
+
+With --merge, existing tickets are updated field by field instead. Only fields
+present in the JSON are considered. A field that differs takes the imported
+value when the imported ticket's UpdatedAt is newer than the local one
+(--prefer newest, the default) or always (--prefer remote); otherwise it only
+fills a field that is unset locally (--prefer local keeps every local value
+that is set). Priority 0 counts as set, an empty Status or Type leaves the
+local one alone, and imported notes missing locally are added in timestamp
+order rather than replacing the local notes. Merged tickets are written like
+any other change.
+
+A field set to different values on both sides is a conflict. When tk import
+--merge runs in a terminal, reading a file rather than stdin, and neither
+--strategy nor --prefer is given, each conflict is shown in three panes -
+local, remote and the result - and you choose: o keeps ours, t takes theirs,
+e edits the result in $EDITOR (checked like tk create checks it: priority
+range, estimate, dependency IDs), O and T decide every remaining conflict,
+q stops (tickets merged so far stay merged) and Enter keeps the result, which
+starts as the newer side's value. In scripts and CI, --strategy
+ours|theirs|newest decides every conflict the same way, like --prefer
+local|remote|newest.
+
+With --mapping, a YAML file translates another tracker's export first:
+
+  fields:            # rename JSON keys to ticket fields
+    summary: Title
+    key: ExternalRef
+  status:            # external value: tk status (case-insensitive)
+    To Do: open
+    Done: closed
+  type:
+    Story: feature
+  assignees:         # e.g. email: git user.name
+    jane@example.com: Jane Doe
+  tags:
+    lowercase: true
+    rename: {frontend: ui}
+    drop: [triage]
+    add: [imported]
+
 Examples:
   tk import tickets.json                  # Import tickets, fail on ID conflicts
+  tk import jira.json --mapping jira.yml  # Translate another tracker's export
   tk import tickets.json --skip-existing  # Skip tickets that already exist
+  tk import upstream.json --merge --prefer remote  # Sync from an upstream tracker
+  tk import upstream.json --merge --strategy theirs  # The same, git-style
   cat tickets.json | tk import -          # Import from stdin`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -63,23 +160,77 @@ Examples:
 			data, err = os.ReadFile(filePath)
 		}
 		if err != nil {
-			return fmt.Errorf("failed to read input: %w", err)
+			return trWrap(msgImportReadFailed, nil, err)
+		}
+
+		switch importFlags.prefer {
+		case PreferNewest, PreferLocal, PreferRemote:
+		default:
+			return trError(msgImportInvalidPrefer, map[string]any{
+				"Value": strconv.Quote(importFlags.prefer), "Newest": PreferNewest, "Local": PreferLocal, "Remote": PreferRemote,
+			}, nil)
+		}
+		if cmd.Flags().Changed("prefer") && !importFlags.merge {
+			return trError(msgImportRequiresMerge, map[string]any{"Flag": "--prefer"}, nil)
+		}
+		prefer := importFlags.prefer
+		if cmd.Flags().Changed("strategy") {
+			var ok bool
+			if prefer, ok = strategyPreferences[importFlags.strategy]; !ok {
+				return trError(msgImportInvalidStrategy, map[string]any{
+					"Value": strconv.Quote(importFlags.strategy), "Ours": StrategyOurs, "Theirs": StrategyTheirs, "Newest": StrategyNewest,
+				}, nil)
+			}
+			if !importFlags.merge {
+				return trError(msgImportRequiresMerge, map[string]any{"Flag": "--strategy"}, nil)
+			}
+		}
+		resolve := preferResolver(prefer)
+		if importFlags.merge && !cmd.Flags().Changed("prefer") && !cmd.Flags().Changed("strategy") &&
+			filePath != "-" && isTerminal(os.Stdin) && isTerminal(os.Stdout) {
+			resolve = newTerminalResolver(os.Stdin, os.Stdout).resolve
+		}
+
+		var mapping *importMapping
+		if importFlags.mapping != "" {
+			if mapping, err = loadImportMapping(importFlags.mapping); err != nil {
+				return err
+			}
+			if data, err = mapping.renameFields(data); err != nil {
+				return err
+			}
 		}
 
 		var tickets []importTicket
 		if err := json.Unmarshal(data, &tickets); err != nil {
-			return fmt.Errorf("failed to parse JSON: %w", err)
+			return trWrap(msgImportParseFailed, nil, err)
+		}
+		if mapping != nil {
+			for i := range tickets {
+				mapping.apply(&tickets[i])
+			}
+		}
+		// The keys of each object tell --merge which fields were given
+		var present []map[string]json.RawMessage
+		if importFlags.merge {
+			if err := json.Unmarshal(data, &present); err != nil {
+				return trWrap(msgImportParseFailed, nil, err)
+			}
 		}
 
 		if err := store.EnsureDir(); err != nil {
 			return fmt.Errorf("failed to ensure tickets directory: %w", err)
 		}
 
-		var imported, skipped, generated int
-		for _, t := range tickets {
+		var imported, skipped, generated, merged int
+		for i, t := range tickets {
 			// Generate ID if not provided
 			if t.ID == "" {
-				newID, err := storage.GenerateID()
+				prefix, err := cfg.IDPrefix(domain.Type(t.Type))
+				if err != nil {
+					return err
+				}
+				newID, err := storage.GenerateID(prefix)
 				if err != nil {
 					return fmt.Errorf("failed to generate ID: %w", err)
 				}
@@ -89,31 +240,46 @@ Examples:
 
 			// Check if ticket exists
 			if store.Exists(t.ID) {
+				if importFlags.merge {
+					changed, err := mergeImportTicket(t, present[i], resolve)
+					if err != nil {
+						return err
+					}
+					if changed {
+						merged++
+					} else {
+						skipped++
+					}
+					continue
+				}
 				if importFlags.skipExisting {
 					skipped++
 					continue
 				}
-				return fmt.Errorf("ticket %s already exists (use --skip-existing to skip)", t.ID)
+				return trError(msgImportExists, map[string]any{"ID": t.ID}, nil)
 			}
 
 			// Convert to domain.Ticket
 			ticket, err := convertImportTicket(t)
 			if err != nil {
-				return fmt.Errorf("failed to convert ticket %s: %w", t.ID, err)
+				return trWrap(msgImportConvertFailed, map[string]any{"ID": t.ID}, err)
 			}
 
-			if err := store.Write(ticket); err != nil {
+			if err := store.Restore(ticket); err != nil {
 				return fmt.Errorf("failed to write ticket %s: %w", t.ID, err)
 			}
 			imported++
 		}
 
-		fmt.Printf("Imported %d ticket(s)", imported)
+		fmt.Print(tr(msgImported, map[string]any{"Count": imported}))
+		if merged > 0 {
+			fmt.Print(tr(msgImportMerged, map[string]any{"Count": merged}))
+		}
 		if skipped > 0 {
-			fmt.Printf(", skipped %d existing", skipped)
+			fmt.Print(tr(msgImportSkipped, map[string]any{"Count": skipped}))
 		}
 		if generated > 0 {
-			fmt.Printf(", generated %d ID(s)", generated)
+			fmt.Print(tr(msgImportGenerated, map[string]any{"Count": generated}))
 		}
 		fmt.Println()
 
@@ -121,13 +287,201 @@ Examples:
 	},
 }
 
+// mergeImportTicket updates the existing ticket t.ID with the fields of t
+// named in present, resolving conflicts with resolve, and reports whether
+// anything changed.
+func mergeImportTicket(t importTicket, present map[string]json.RawMessage, resolve resolveFunc) (bool, error) {
+	remote, err := parseImportTicket(t)
+	if err != nil {
+		return false, trWrap(msgImportConvertFailed, map[string]any{"ID": t.ID}, err)
+	}
+	local, err := store.Read(t.ID)
+	if err != nil {
+		return false, err
+	}
+
+	changed, err := mergeTicketWith(local, remote, present, resolve)
+	if err != nil || !changed {
+		return false, err
+	}
+	if err := store.Write(local); err != nil {
+		return false, fmt.Errorf("failed to merge ticket %s: %w", t.ID, err)
+	}
+	return true, nil
+}
+
+// mergeTicketWith copies into local the fields of remote named in present
+// that differ. Unset local fields take the remote value; fields set on both
+// sides are conflicts, which resolve decides. Notes are combined instead. It
+// reports whether local changed.
+func mergeTicketWith(local, remote *domain.Ticket, present map[string]json.RawMessage, resolve resolveFunc) (bool, error) {
+	remoteNewer := remote.UpdatedAt.After(local.UpdatedAt)
+	status := local.Status
+	dst := reflect.ValueOf(local).Elem()
+	src := reflect.ValueOf(remote).Elem()
+	changed := false
+	for _, name := range mergeFields {
+		if _, ok := present[name]; !ok {
+			continue
+		}
+		to, from := dst.FieldByName(name), src.FieldByName(name)
+		if name == "Notes" {
+			from = reflect.ValueOf(mergeNotes(local.Notes, remote.Notes))
+		}
+		if sameFieldValue(to, from) {
+			continue
+		}
+		// Every ticket has a status and type, so an empty one clears nothing
+		if (name == "Status" || name == "Type") && fieldUnset(from) {
+			continue
+		}
+		value := from
+		if name != "Notes" && !fieldUnset(to) {
+			var err error
+			value, err = resolve(fieldConflict{ID: local.ID, Field: name, Local: to, Remote: from, RemoteNewer: remoteNewer})
+			if err != nil {
+				return false, err
+			}
+			if sameFieldValue(to, value) {
+				continue
+			}
+		}
+		to.Set(value)
+		changed = true
+	}
+
+	if local.Status != status {
+		// Keep closed-at consistent when only the status was given
+		local.SetStatus(local.Status, time.Now().UTC())
+	}
+	return changed, nil
+}
+
+// fieldUnset reports whether a ticket field value holds nothing. Priority 0
+// is a real priority and counts as set; an estimate of 0 is not written to
+// the file, so it means no estimate.
+func fieldUnset(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice:
+		return v.Len() == 0
+	case reflect.Float64:
+		return v.Float() == 0
+	case reflect.Int:
+		return false
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.IsZero()
+	}
+	return false
+}
+
+// mergeNotes returns local with the remote notes it lacks, matched by
+// timestamp and content, added in timestamp order. Replies are renumbered to
+// keep pointing at the same notes.
+func mergeNotes(local, remote []domain.Note) []domain.Note {
+	type entry struct {
+		note   domain.Note
+		remote bool
+		index  int
+	}
+	var entries []entry
+	for i, n := range local {
+		entries = append(entries, entry{note: n, index: i})
+	}
+	// remoteAt maps each remote note to its local copy, if any
+	remoteAt := make(map[int]int)
+	for j, n := range remote {
+		if i := findNote(local, n); i >= 0 {
+			remoteAt[j] = i
+			continue
+		}
+		entries = append(entries, entry{note: n, remote: true, index: j})
+	}
+	if len(entries) == len(local) {
+		return local
+	}
+	sort.SliceStable(entries, func(a, b int) bool {
+		return entries[a].note.Timestamp.Before(entries[b].note.Timestamp)
+	})
+
+	localPos := make(map[int]int)
+	remotePos := make(map[int]int)
+	for k, e := range entries {
+		if e.remote {
+			remotePos[e.index] = k + 1
+		} else {
+			localPos[e.index] = k + 1
+		}
+	}
+	for j, i := range remoteAt {
+		remotePos[j] = localPos[i]
+	}
+
+	merged := make([]domain.Note, len(entries))
+	for k, e := range entries {
+		merged[k] = e.note
+		if e.note.ReplyTo > 0 {
+			if e.remote {
+				merged[k].ReplyTo = remotePos[e.note.ReplyTo-1]
+			} else {
+				merged[k].ReplyTo = localPos[e.note.ReplyTo-1]
+			}
+		}
+	}
+	return merged
+}
+
+// findNote returns the index in notes of a note with n's timestamp and
+// content, or -1.
+func findNote(notes []domain.Note, n domain.Note) int {
+	for i, note := range notes {
+		if note.Timestamp.Equal(n.Timestamp) && note.Content == n.Content {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameFieldValue compares two ticket field values, treating nil and empty
+// slices as equal and times by instant.
+func sameFieldValue(a, b reflect.Value) bool {
+	if t, ok := a.Interface().(time.Time); ok {
+		return t.Equal(b.Interface().(time.Time))
+	}
+	if a.Kind() == reflect.Slice && a.Len() == 0 && b.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
 func readAllFromStdin() ([]byte, error) {
 	return os.ReadFile("/dev/stdin")
 }
 
+// convertImportTicket converts t into a new ticket, defaulting a missing
+// status, type and creation time.
 func convertImportTicket(t importTicket) (*domain.Ticket, error) {
+	ticket, err := parseImportTicket(t)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Status == "" {
+		ticket.Status = domain.StatusOpen
+	}
+	if ticket.Type == "" {
+		ticket.Type = domain.TypeTask
+	}
+	if ticket.Created.IsZero() {
+		ticket.Created = time.Now().UTC()
+	}
+	return ticket, nil
+}
+
+// parseImportTicket converts t as given, leaving fields it lacks unset so a
+// merge can tell them apart from values.
+func parseImportTicket(t importTicket) (*domain.Ticket, error) {
 	// Parse status
-	status := domain.StatusOpen
+	var status domain.Status
 	if t.Status != "" {
 		parsed, err := domain.ParseStatus(t.Status)
 		if err != nil {
@@ -137,7 +491,7 @@ func convertImportTicket(t importTicket) (*domain.Ticket, error) {
 	}
 
 	// Parse type
-	ticketType := domain.TypeTask
+	var ticketType domain.Type
 	if t.Type != "" {
 		parsed, err := domain.ParseType(t.Type)
 		if err != nil {
@@ -146,10 +500,30 @@ func convertImportTicket(t importTicket) (*domain.Ticket, error) {
 		ticketType = parsed
 	}
 
-	// Set created time if not provided
-	created := t.Created
-	if created.IsZero() {
-		created = time.Now().UTC()
+	var resolution domain.Resolution
+	if t.Resolution != "" {
+		parsed, err := domain.ParseResolution(t.Resolution)
+		if err != nil {
+			return nil, err
+		}
+		resolution = parsed
+	}
+
+	if err := validateEstimate(t.Estimate); err != nil {
+		return nil, err
+	}
+
+	for _, r := range t.Reviews {
+		switch r.Decision {
+		case domain.ReviewPending, domain.ReviewApproved, domain.ReviewRejected:
+		default:
+			return nil, fmt.Errorf("invalid review decision: %q", r.Decision)
+		}
+	}
+
+	var sections []domain.Section
+	for _, s := range t.Sections {
+		sections = append(sections, domain.Section{Name: s.Name, Content: s.Content})
 	}
 
 	// Convert notes
@@ -158,6 +532,7 @@ func convertImportTicket(t importTicket) (*domain.Ticket, error) {
 		notes[i] = domain.Note{
 			Timestamp: n.Timestamp,
 			Content:   n.Content,
+			ReplyTo:   n.ReplyTo,
 		}
 	}
 
@@ -172,9 +547,26 @@ func convertImportTicket(t importTicket) (*domain.Ticket, error) {
 		Tags:        t.Tags,
 		Deps:        t.Deps,
 		Links:       t.Links,
-		Created:     created,
+		Created:     t.Created,
+		ClosedAt:    t.ClosedAt,
+		Resolution:  resolution,
+
+		Estimate:      t.Estimate,
+		UpdatedAt:     t.UpdatedAt,
+		LastUpdatedBy: t.LastUpdatedBy,
+		Revision:      t.Revision,
+		Locked:        t.Locked,
+		LockReason:    t.LockReason,
+		Reviews:       t.Reviews,
+		Due:           t.Due,
+		LeaseUntil:    t.LeaseUntil,
+		LeaseHolder:   t.LeaseHolder,
+		Watchers:      t.Watchers,
+		PRs:           t.PRs,
+
 		Title:       t.Title,
 		Description: t.Description,
+		Sections:    sections,
 		Design:      t.Design,
 		Acceptance:  t.Acceptance,
 		Notes:       notes,
@@ -183,4 +575,10 @@ func convertImportTicket(t importTicket) (*domain.Ticket, error) {
 
 func init() {
 	importCmd.Flags().BoolVar(&importFlags.skipExisting, "skip-existing", false, "Skip tickets that already exist instead of failing")
+	importCmd.Flags().BoolVar(&importFlags.merge, "merge", false, "Update existing tickets field by field instead of failing")
+	importCmd.Flags().StringVar(&importFlags.prefer, "prefer", PreferNewest, "Which side wins conflicting fields with --merge (newest|local|remote)")
+	importCmd.Flags().StringVar(&importFlags.strategy, "strategy", "", "Decide every conflict with --merge without asking (ours|theirs|newest)")
+	importCmd.Flags().StringVar(&importFlags.mapping, "mapping", "", "YAML file mapping another tracker's fields, statuses, types, assignees and tags")
+	importCmd.MarkFlagsMutuallyExclusive("skip-existing", "merge")
+	importCmd.MarkFlagsMutuallyExclusive("prefer", "strategy")
 }
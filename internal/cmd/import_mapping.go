@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// importMapping translates another tracker's export into tk's vocabulary
+// before tickets are imported. It is read from the file given to --mapping.
+type importMapping struct {
+	// Fields renames JSON keys to ticket fields, e.g. summary: Title.
+	Fields map[string]string `yaml:"fields"`
+	// Status and Type map external values to tk values, ignoring case.
+	Status map[string]string `yaml:"status"`
+	Type   map[string]string `yaml:"type"`
+	// Assignees maps external identities, e.g. emails, to tk users.
+	Assignees map[string]string `yaml:"assignees"`
+	Tags      tagMapping        `yaml:"tags"`
+}
+
+// tagMapping transforms imported tags. They are lowercased first if asked,
+// then renamed, then dropped, and finally the added tags are appended.
+type tagMapping struct {
+	Lowercase bool              `yaml:"lowercase"`
+	Rename    map[string]string `yaml:"rename"`
+	Drop      []string          `yaml:"drop"`
+	Add       []string          `yaml:"add"`
+}
+
+// loadImportMapping reads and validates the mapping file at path.
+func loadImportMapping(path string) (*importMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var m importMapping
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse mapping file: %w", err)
+	}
+
+	fields := importFieldNames()
+	for from, to := range m.Fields {
+		if !slices.Contains(fields, to) {
+			return nil, fmt.Errorf("invalid mapping for field %q: unknown ticket field %q", from, to)
+		}
+	}
+	for from, to := range m.Status {
+		if _, err := domain.ParseStatus(to); err != nil {
+			return nil, fmt.Errorf("invalid mapping for status %q: %w", from, err)
+		}
+	}
+	for from, to := range m.Type {
+		if _, err := domain.ParseType(to); err != nil {
+			return nil, fmt.Errorf("invalid mapping for type %q: %w", from, err)
+		}
+	}
+	return &m, nil
+}
+
+// importFieldNames returns the JSON keys tk import understands.
+func importFieldNames() []string {
+	typ := reflect.TypeFor[importTicket]()
+	names := make([]string, 0, typ.NumField())
+	for i := range typ.NumField() {
+		names = append(names, typ.Field(i).Tag.Get("json"))
+	}
+	return names
+}
+
+// renameFields rewrites the keys of every ticket object in data according to
+// the field mapping. Renamed keys replace keys of the same name.
+func (m *importMapping) renameFields(data []byte) ([]byte, error) {
+	if len(m.Fields) == 0 {
+		return data, nil
+	}
+
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	for _, obj := range objects {
+		for from, to := range m.Fields {
+			if value, ok := obj[from]; ok {
+				delete(obj, from)
+				obj[to] = value
+			}
+		}
+	}
+	return json.Marshal(objects)
+}
+
+// apply translates the status, type, assignee and tags of t.
+func (m *importMapping) apply(t *importTicket) {
+	t.Status = lookupFold(m.Status, t.Status)
+	t.Type = lookupFold(m.Type, t.Type)
+	t.Assignee = lookupFold(m.Assignees, t.Assignee)
+	t.Tags = m.Tags.apply(t.Tags)
+}
+
+func (m tagMapping) apply(tags []string) []string {
+	var result []string
+	for _, tag := range tags {
+		if m.Lowercase {
+			tag = strings.ToLower(tag)
+		}
+		if renamed, ok := m.Rename[tag]; ok {
+			tag = renamed
+		}
+		if tag == "" || slices.Contains(m.Drop, tag) || slices.Contains(result, tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+	for _, tag := range m.Add {
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// lookupFold returns the value mapped to key, preferring an exact match over
+// one ignoring case, or key itself if it is not mapped.
+func lookupFold(mapping map[string]string, key string) string {
+	if value, ok := mapping[key]; ok {
+		return value
+	}
+	for from, value := range mapping {
+		if strings.EqualFold(from, key) {
+			return value
+		}
+	}
+	return key
+}
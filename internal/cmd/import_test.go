@@ -1,6 +1,12 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -164,12 +170,9 @@ func (s *ImportSuite) TestConvertImportTicketNotesConversion() {
 	input := importTicket{
 		ID:    "tic-test",
 		Title: "Test Ticket",
-		Notes: []struct {
-			Timestamp time.Time `json:"Timestamp"`
-			Content   string    `json:"Content"`
-		}{
+		Notes: []importNote{
 			{Timestamp: now, Content: "First note"},
-			{Timestamp: now.Add(time.Hour), Content: "Second note"},
+			{Timestamp: now.Add(time.Hour), Content: "Second note", ReplyTo: 1},
 		},
 	}
 
@@ -181,6 +184,7 @@ func (s *ImportSuite) TestConvertImportTicketNotesConversion() {
 	require.Equal(s.T(), now, result.Notes[0].Timestamp)
 	require.Equal(s.T(), "Second note", result.Notes[1].Content)
 	require.Equal(s.T(), now.Add(time.Hour), result.Notes[1].Timestamp)
+	require.Equal(s.T(), 1, result.Notes[1].ReplyTo)
 }
 
 func (s *ImportSuite) TestConvertImportTicketEmptyNotes() {
@@ -283,3 +287,236 @@ func (s *ImportSuite) TestConvertImportTicketValidStatusInvalidType() {
 	require.Nil(s.T(), result)
 	require.Contains(s.T(), err.Error(), "invalid type")
 }
+
+func (s *ImportSuite) TestMergeTicketPreference() {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	present := map[string]json.RawMessage{"Title": nil, "Assignee": nil, "Tags": nil}
+	testCases := []struct {
+		name          string
+		prefer        string
+		remoteUpdated time.Time
+		expectedTitle string
+	}{
+		{"newest remote wins", PreferNewest, base.Add(time.Hour), "Remote"},
+		{"newest local wins", PreferNewest, base.Add(-time.Hour), "Local"},
+		{"local always wins", PreferLocal, base.Add(time.Hour), "Local"},
+		{"remote always wins", PreferRemote, base.Add(-time.Hour), "Remote"},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			local := &domain.Ticket{Title: "Local", Priority: 1, UpdatedAt: base}
+			remote := &domain.Ticket{Title: "Remote", Priority: 3, Assignee: "alice", UpdatedAt: tc.remoteUpdated}
+
+			changed, err := mergeTicketWith(local, remote, present, preferResolver(tc.prefer))
+			require.NoError(s.T(), err)
+			require.True(s.T(), changed)
+			require.Equal(s.T(), tc.expectedTitle, local.Title)
+			// Empty local fields are filled whatever the preference
+			require.Equal(s.T(), "alice", local.Assignee)
+			// Fields absent from the import are untouched
+			require.Equal(s.T(), 1, local.Priority)
+		})
+	}
+}
+
+func (s *ImportSuite) TestMergeTicketUnchanged() {
+	local := &domain.Ticket{Title: "Same"}
+	remote := &domain.Ticket{Title: "Same", Tags: []string{}}
+	present := map[string]json.RawMessage{"Title": nil, "Tags": nil}
+
+	changed, err := mergeTicketWith(local, remote, present, preferResolver(PreferRemote))
+	require.NoError(s.T(), err)
+	require.False(s.T(), changed)
+}
+
+func (s *ImportSuite) TestMergeTicketUnsetFields() {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	local := &domain.Ticket{Status: domain.StatusInProgress, Type: domain.TypeBug, Priority: 0, UpdatedAt: base}
+	remote, err := parseImportTicket(importTicket{Priority: 2, Estimate: 3, UpdatedAt: base.Add(-time.Hour)})
+	require.NoError(s.T(), err)
+	present := map[string]json.RawMessage{"Status": nil, "Type": nil, "Priority": nil, "Estimate": nil}
+
+	changed, err := mergeTicketWith(local, remote, present, preferResolver(PreferNewest))
+	require.NoError(s.T(), err)
+	require.True(s.T(), changed)
+	// Priority 0 is set, so the older remote loses the conflict
+	require.Equal(s.T(), 0, local.Priority)
+	// No estimate locally, so the remote one fills it
+	require.Equal(s.T(), 3.0, local.Estimate)
+	// A missing status and type keep the local ones
+	require.Equal(s.T(), domain.StatusInProgress, local.Status)
+	require.Equal(s.T(), domain.TypeBug, local.Type)
+}
+
+func (s *ImportSuite) TestMergeNotes() {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	local := []domain.Note{
+		{Timestamp: base, Content: "Shared"},
+		{Timestamp: base.Add(2 * time.Hour), Content: "Local reply", ReplyTo: 1},
+	}
+	remote := []domain.Note{
+		{Timestamp: base, Content: "Shared"},
+		{Timestamp: base.Add(time.Hour), Content: "Remote"},
+		{Timestamp: base.Add(3 * time.Hour), Content: "Remote reply", ReplyTo: 2},
+	}
+
+	require.Equal(s.T(), []domain.Note{
+		{Timestamp: base, Content: "Shared"},
+		{Timestamp: base.Add(time.Hour), Content: "Remote"},
+		{Timestamp: base.Add(2 * time.Hour), Content: "Local reply", ReplyTo: 1},
+		{Timestamp: base.Add(3 * time.Hour), Content: "Remote reply", ReplyTo: 2},
+	}, mergeNotes(local, remote))
+	require.Equal(s.T(), local, mergeNotes(local, remote[:1]))
+
+	ticket := &domain.Ticket{Notes: local, UpdatedAt: base}
+	changed, err := mergeTicketWith(ticket, &domain.Ticket{Notes: remote[1:2], UpdatedAt: base.Add(time.Hour)},
+		map[string]json.RawMessage{"Notes": nil}, preferResolver(PreferRemote))
+	require.NoError(s.T(), err)
+	require.True(s.T(), changed)
+	require.Len(s.T(), ticket.Notes, 3)
+	require.Equal(s.T(), "Local reply", ticket.Notes[2].Content)
+}
+
+func (s *ImportSuite) TestMergeTicketWithResolvesOnlyConflicts() {
+	local := &domain.Ticket{ID: "tic-a", Title: "Local", Priority: 1}
+	remote := &domain.Ticket{ID: "tic-a", Title: "Remote", Priority: 1, Assignee: "alice"}
+	present := map[string]json.RawMessage{"Title": nil, "Priority": nil, "Assignee": nil}
+
+	var asked []string
+	changed, err := mergeTicketWith(local, remote, present, func(c fieldConflict) (reflect.Value, error) {
+		asked = append(asked, c.Field)
+		return c.Local, nil
+	})
+	require.NoError(s.T(), err)
+	require.True(s.T(), changed)
+	require.Equal(s.T(), []string{"Title"}, asked)
+	require.Equal(s.T(), "Local", local.Title)
+	require.Equal(s.T(), "alice", local.Assignee)
+}
+
+func (s *ImportSuite) TestTerminalResolver() {
+	conflict := func(field string, local, remote any) fieldConflict {
+		return fieldConflict{ID: "tic-a", Field: field, Local: reflect.ValueOf(local), Remote: reflect.ValueOf(remote), RemoteNewer: true}
+	}
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"enter keeps the newer side", "\n", "Remote"},
+		{"ours", "o\n", "Local"},
+		{"theirs", "t\n", "Remote"},
+		{"unknown choice asks again", "x\no\n", "Local"},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			var out bytes.Buffer
+			r := newTerminalResolver(strings.NewReader(tc.input), &out)
+			v, err := r.resolve(conflict("Title", "Local", "Remote"))
+			require.NoError(s.T(), err)
+			require.Equal(s.T(), tc.expected, v.String())
+			require.Contains(s.T(), out.String(), "Conflict in tic-a field Title:")
+			require.Contains(s.T(), out.String(), "LOCAL")
+			require.Contains(s.T(), out.String(), "RESULT")
+		})
+	}
+
+	s.Run("all theirs settles later conflicts", func() {
+		var out bytes.Buffer
+		r := newTerminalResolver(strings.NewReader("T\n"), &out)
+		_, err := r.resolve(conflict("Title", "Local", "Remote"))
+		require.NoError(s.T(), err)
+		v, err := r.resolve(conflict("Design", "Ours", "Theirs"))
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), "Theirs", v.String())
+		require.NotContains(s.T(), out.String(), "field Design")
+	})
+
+	s.Run("quit and end of input abort", func() {
+		for _, input := range []string{"q\n", ""} {
+			r := newTerminalResolver(strings.NewReader(input), &bytes.Buffer{})
+			_, err := r.resolve(conflict("Title", "Local", "Remote"))
+			require.ErrorIs(s.T(), err, errMergeAborted)
+		}
+	})
+}
+
+func (s *ImportSuite) TestWritePanes() {
+	var out bytes.Buffer
+	writePanes(&out, 36, []string{"LOCAL", "REMOTE", "RESULT"}, []string{"short", "a value longer than ten", "x\ny"})
+
+	require.Equal(s.T(), `LOCAL      | REMOTE     | RESULT
+---------- | ---------- | ----------
+short      | a value lo | x
+           | nger than  | y
+           | ten        |
+`, out.String())
+}
+
+func (s *ImportSuite) TestFormatFieldValue() {
+	require.Equal(s.T(), "(empty)", formatFieldValue(reflect.ValueOf("")))
+	require.Equal(s.T(), "a, b", formatFieldValue(reflect.ValueOf([]string{"a", "b"})))
+	require.Equal(s.T(), "(empty)", formatFieldValue(reflect.ValueOf(time.Time{})))
+	require.Equal(s.T(), "0", formatFieldValue(reflect.ValueOf(0)))
+	require.Equal(s.T(), "in_progress", formatFieldValue(reflect.ValueOf(domain.StatusInProgress)))
+}
+
+func (s *ImportSuite) TestImportMappingApply() {
+	m := &importMapping{
+		Status:    map[string]string{"To Do": "open", "Done": "closed"},
+		Type:      map[string]string{"Story": "feature"},
+		Assignees: map[string]string{"jane@example.com": "Jane Doe"},
+		Tags: tagMapping{
+			Lowercase: true,
+			Rename:    map[string]string{"frontend": "ui"},
+			Drop:      []string{"triage"},
+			Add:       []string{"imported"},
+		},
+	}
+	t := importTicket{
+		Status:   "done",
+		Type:     "Story",
+		Assignee: "jane@example.com",
+		Tags:     []string{"Frontend", "TRIAGE", "ui", "api"},
+	}
+
+	m.apply(&t)
+
+	require.Equal(s.T(), "closed", t.Status)
+	require.Equal(s.T(), "feature", t.Type)
+	require.Equal(s.T(), "Jane Doe", t.Assignee)
+	require.Equal(s.T(), []string{"ui", "api", "imported"}, t.Tags)
+
+	// Unmapped values pass through
+	t = importTicket{Status: "open", Assignee: "bob"}
+	m.apply(&t)
+	require.Equal(s.T(), "open", t.Status)
+	require.Equal(s.T(), "bob", t.Assignee)
+}
+
+func (s *ImportSuite) TestImportMappingRenameFields() {
+	m := &importMapping{Fields: map[string]string{"summary": "Title", "key": "ExternalRef"}}
+
+	data, err := m.renameFields([]byte(`[{"summary": "Fix login", "key": "JIRA-1", "Status": "open"}]`))
+
+	require.NoError(s.T(), err)
+	require.JSONEq(s.T(), `[{"Title": "Fix login", "ExternalRef": "JIRA-1", "Status": "open"}]`, string(data))
+}
+
+func (s *ImportSuite) TestLoadImportMappingRejectsUnknownValues() {
+	dir := s.T().TempDir()
+	load := func(content string) error {
+		path := filepath.Join(dir, "map.yml")
+		require.NoError(s.T(), os.WriteFile(path, []byte(content), 0644))
+		_, err := loadImportMapping(path)
+		return err
+	}
+
+	require.NoError(s.T(), load("status:\n  Done: closed\n"))
+	require.ErrorContains(s.T(), load("fields:\n  summary: Headline\n"), `unknown ticket field "Headline"`)
+	require.ErrorContains(s.T(), load("status:\n  Done: finished\n"), `invalid mapping for status "Done"`)
+	require.ErrorContains(s.T(), load("type:\n  Story: saga\n"), `invalid mapping for type "Story"`)
+	require.ErrorContains(s.T(), load("statuses:\n  Done: closed\n"), "failed to parse mapping file")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+var leasesCmd = &cobra.Command{
+	Use:   "leases",
+	Short: "List leased claims and when they expire",
+	Long: `List in_progress tickets claimed with --lease, soonest expiry first, with
+the lease holder and the time left. Expired leases are marked; tk claim,
+tk pick and tk ready --claimable treat those tickets as claimable again.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+
+		var leased []*domain.Ticket
+		for _, t := range tickets {
+			if t.Status == domain.StatusInProgress && !t.LeaseUntil.IsZero() {
+				leased = append(leased, t)
+			}
+		}
+		if len(leased) == 0 {
+			fmt.Println(tr(msgNoLeasedTickets, nil))
+			return nil
+		}
+		sort.SliceStable(leased, func(i, j int) bool {
+			return leased[i].LeaseUntil.Before(leased[j].LeaseUntil)
+		})
+
+		return runWithPager(func(w io.Writer) error {
+			return outputLeasesText(w, leased, time.Now())
+		})
+	},
+}
+
+func outputLeasesText(w io.Writer, tickets []*domain.Ticket, now time.Time) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "ID\tHOLDER\tEXPIRES\tLEFT\tTITLE"); err != nil {
+		return err
+	}
+	for _, t := range tickets {
+		left := "expired"
+		if !t.LeaseExpired(now) {
+			left = t.LeaseUntil.Sub(now).Round(time.Second).String()
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", t.ID, cmp.Or(t.LeaseHolder, "-"),
+			formatTime(t.LeaseUntil), left, t.Title); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
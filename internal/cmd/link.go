@@ -16,7 +16,7 @@ var linkCmd = &cobra.Command{
 		// Resolve all IDs first
 		ids := make([]string, len(args))
 		for i, arg := range args {
-			id, err := store.ResolveID(arg)
+			id, err := resolveID(store, arg)
 			if err != nil {
 				return fmt.Errorf("failed to resolve %s: %w", arg, err)
 			}
@@ -27,34 +27,40 @@ var linkCmd = &cobra.Command{
 		seen := make(map[string]bool)
 		for _, id := range ids {
 			if seen[id] {
-				return fmt.Errorf("duplicate ticket ID: %s", id)
+				return trError(msgDuplicateID, map[string]any{"ID": id}, nil)
 			}
 			seen[id] = true
 		}
 
-		// Add links to all tickets
-		for _, id := range ids {
-			ticket, err := store.Read(id)
-			if err != nil {
-				return err
-			}
-
-			// Add all other IDs as links
-			for _, otherID := range ids {
-				if otherID == id {
-					continue
+		// Add links to all tickets, all or none
+		err := withTicketLocks(ids, func() error {
+			for _, id := range ids {
+				ticket, err := store.Read(id)
+				if err != nil {
+					return err
 				}
-				if !slices.Contains(ticket.Links, otherID) {
-					ticket.Links = append(ticket.Links, otherID)
+
+				// Add all other IDs as links
+				for _, otherID := range ids {
+					if otherID == id {
+						continue
+					}
+					if !slices.Contains(ticket.Links, otherID) {
+						ticket.Links = append(ticket.Links, otherID)
+					}
 				}
-			}
 
-			if err := store.Write(ticket); err != nil {
-				return err
+				if err := store.Write(ticket); err != nil {
+					return err
+				}
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 
-		fmt.Printf("Linked: %v\n", ids)
+		fmt.Println(tr(msgLinked, map[string]any{"IDs": ids}))
 		return nil
 	},
 }
@@ -65,46 +71,48 @@ var unlinkCmd = &cobra.Command{
 	Long:  `Remove a bidirectional link between two tickets.`,
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id1, err := store.ResolveID(args[0])
+		id1, err := resolveID(store, args[0])
 		if err != nil {
 			return fmt.Errorf("failed to resolve %s: %w", args[0], err)
 		}
 
-		id2, err := store.ResolveID(args[1])
+		id2, err := resolveID(store, args[1])
 		if err != nil {
 			return fmt.Errorf("failed to resolve %s: %w", args[1], err)
 		}
 
-		// Remove link from first ticket
-		ticket1, err := store.Read(id1)
-		if err != nil {
-			return err
-		}
+		// Remove both halves of the link, or neither
+		err = withTicketLocks([]string{id1, id2}, func() error {
+			ticket1, err := store.Read(id1)
+			if err != nil {
+				return err
+			}
 
-		newLinks1, found1 := removeFromSlice(ticket1.Links, id2)
-		ticket1.Links = newLinks1
+			newLinks1, found1 := removeFromSlice(ticket1.Links, id2)
+			ticket1.Links = newLinks1
 
-		// Remove link from second ticket
-		ticket2, err := store.Read(id2)
-		if err != nil {
-			return err
-		}
+			ticket2, err := store.Read(id2)
+			if err != nil {
+				return err
+			}
 
-		newLinks2, found2 := removeFromSlice(ticket2.Links, id1)
-		ticket2.Links = newLinks2
+			newLinks2, found2 := removeFromSlice(ticket2.Links, id1)
+			ticket2.Links = newLinks2
 
-		if !found1 && !found2 {
-			return fmt.Errorf("no link found between %s and %s", id1, id2)
-		}
+			if !found1 && !found2 {
+				return trError(msgNoLink, map[string]any{"ID": id1, "Other": id2}, nil)
+			}
 
-		if err := store.Write(ticket1); err != nil {
-			return err
-		}
-		if err := store.Write(ticket2); err != nil {
+			if err := store.Write(ticket1); err != nil {
+				return err
+			}
+			return store.Write(ticket2)
+		})
+		if err != nil {
 			return err
 		}
 
-		fmt.Printf("Unlinked: %s and %s\n", id1, id2)
+		fmt.Println(tr(msgUnlinked, map[string]any{"ID": id1, "Other": id2}))
 		return nil
 	},
 }
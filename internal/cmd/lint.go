@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/config"
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// lintIssue is a problem found by tk lint.
+type lintIssue struct {
+	ID      string
+	Message string
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check tickets against project policies",
+	Long: `Check every ticket against the required-field policies in the config file and
+report each problem as "<id>: <message>". Exits non-zero if problems are found.
+Open tickets that depend on closed tickets are also reported.
+
+Policies are declared per type in .tickets/config.yaml:
+
+  policies:
+    bug:
+      required: [acceptance, assignee]
+      max_priority: 2
+
+Fields that can be required: ` + strings.Join(config.PolicyFieldNames(), ", "),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.ValidatePolicies(cfg.Policies); err != nil {
+			return err
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+
+		issues := lintTickets(tickets, cfg.Policies)
+		if len(issues) == 0 {
+			fmt.Println(tr(msgLintNone, nil))
+			return nil
+		}
+
+		if err := runWithPager(func(w io.Writer) error {
+			for _, issue := range issues {
+				if _, err := fmt.Fprintf(w, "%s: %s\n", issue.ID, issue.Message); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return trError(msgLintFound, map[string]any{"Count": len(issues)}, nil)
+	},
+}
+
+// lintTickets returns the problems found in tickets, ordered by ticket ID.
+func lintTickets(tickets []*domain.Ticket, policies map[string]config.TypePolicy) []lintIssue {
+	sorted := slices.Clone(tickets)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	status := make(map[string]domain.Status, len(tickets))
+	for _, t := range tickets {
+		status[t.ID] = t.Status
+	}
+
+	var issues []lintIssue
+	for _, t := range sorted {
+		for _, msg := range config.PolicyViolations(t, policies) {
+			issues = append(issues, lintIssue{ID: t.ID, Message: msg})
+		}
+		for _, msg := range closedDepViolations(t, status) {
+			issues = append(issues, lintIssue{ID: t.ID, Message: msg})
+		}
+	}
+	return issues
+}
+
+// closedDepViolations reports dependencies of an unclosed ticket on closed
+// tickets, which never block anything.
+func closedDepViolations(t *domain.Ticket, status map[string]domain.Status) []string {
+	if t.Status == domain.StatusClosed {
+		return nil
+	}
+
+	var violations []string
+	for _, dep := range t.Deps {
+		if status[dep] == domain.StatusClosed {
+			violations = append(violations, tr(msgLintClosedDep, map[string]any{"Dep": dep}))
+		}
+	}
+	return violations
+}
+
+// enforcePolicy fails if the ticket violates its type's policy, or only
+// warns when --force is set. Callers add which operation was refused.
+func enforcePolicy(t *domain.Ticket) error {
+	if err := config.ValidatePolicies(cfg.Policies); err != nil {
+		return err
+	}
+
+	violations := config.PolicyViolations(t, cfg.Policies)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if forceFlag {
+		for _, v := range violations {
+			fmt.Fprintln(os.Stderr, tr(msgWarningPrefix, nil), v)
+		}
+		return nil
+	}
+	return trError(msgPolicyRefused, map[string]any{"Violations": strings.Join(violations, "; ")}, nil)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/radutopala/ticket/internal/config"
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+type LintSuite struct {
+	suite.Suite
+}
+
+func TestLintSuite(t *testing.T) {
+	suite.Run(t, new(LintSuite))
+}
+
+func bugPolicy() map[string]config.TypePolicy {
+	maxPriority := 2
+	return map[string]config.TypePolicy{
+		"bug": {Required: []string{"acceptance", "assignee"}, MaxPriority: &maxPriority},
+	}
+}
+
+func (s *LintSuite) TestLintTicketsSortedByID() {
+	tickets := []*domain.Ticket{
+		{ID: "tic-b", Type: domain.TypeBug, Priority: 1, Assignee: "a"},
+		{ID: "tic-a", Type: domain.TypeBug, Priority: 1, Acceptance: "x"},
+		{ID: "tic-c", Type: domain.TypeBug, Priority: 1, Acceptance: "x", Assignee: "a"},
+	}
+
+	issues := lintTickets(tickets, bugPolicy())
+	require.Len(s.T(), issues, 2)
+	require.Equal(s.T(), "tic-a", issues[0].ID)
+	require.Contains(s.T(), issues[0].Message, "assignee")
+	require.Equal(s.T(), "tic-b", issues[1].ID)
+	require.Contains(s.T(), issues[1].Message, "acceptance criteria")
+}
+
+func (s *LintSuite) TestLintTicketsClosedDeps() {
+	tickets := []*domain.Ticket{
+		{ID: "tic-done", Status: domain.StatusClosed},
+		{ID: "tic-todo", Status: domain.StatusOpen},
+		{ID: "tic-open", Status: domain.StatusOpen, Deps: []string{"tic-done", "tic-todo", "tic-gone"}},
+		{ID: "tic-old", Status: domain.StatusClosed, Deps: []string{"tic-done"}},
+	}
+
+	issues := lintTickets(tickets, nil)
+	require.Equal(s.T(), []lintIssue{
+		{ID: "tic-open", Message: "depends on closed ticket tic-done (use tk dep remove)"},
+	}, issues)
+}
@@ -1,22 +1,46 @@
 package cmd
 
 import (
+	"cmp"
 	"fmt"
 	"io"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/radutopala/ticket/internal/domain"
 )
 
+// Tag matching modes for FilterOptions.TagMode.
+const (
+	TagModeAll = "all"
+	TagModeAny = "any"
+)
+
 // FilterOptions holds common filtering options for list commands.
+// Multi-valued fields match if the ticket matches any of the values,
+// except Tag, which requires all tags unless TagMode is TagModeAny.
+// The Not* fields exclude tickets matching any of their values.
 type FilterOptions struct {
-	Status   string
-	Assignee string
-	Tag      string
-	Type     string
+	Status      []string
+	Assignee    []string
+	Tag         []string
+	TagMode     string
+	Type        []string
+	NotStatus   []string
+	NotAssignee []string
+	NotTag      []string
+	Unassigned  bool
+	Untagged    bool
+	NeedsReview bool
+
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	ClosedAfter   time.Time
+	ClosedBefore  time.Time
 }
 
 // SortOptions holds sorting options for list commands.
@@ -26,28 +50,123 @@ type SortOptions struct {
 }
 
 // validSortFields lists valid sort field names.
-var validSortFields = []string{"priority", "created", "status", "title"}
+var validSortFields = []string{"priority", "created", "age", "status", "title", "in-status"}
+
+// sortFieldValue is a pflag.Value that accepts only validSortFields.
+type sortFieldValue struct {
+	field *string
+}
+
+func (v sortFieldValue) String() string {
+	if v.field == nil {
+		return ""
+	}
+	return *v.field
+}
+
+func (v sortFieldValue) Set(s string) error {
+	if !slices.Contains(validSortFields, s) {
+		return fmt.Errorf("invalid sort field %q: must be one of %s", s, strings.Join(validSortFields, ", "))
+	}
+	*v.field = s
+	return nil
+}
+
+func (v sortFieldValue) Type() string {
+	return "string"
+}
+
+// Validate checks that the filter options are well-formed.
+func (f FilterOptions) Validate() error {
+	switch f.TagMode {
+	case "", TagModeAll, TagModeAny:
+		return nil
+	default:
+		return trError(msgInvalidTagMode, map[string]any{"Mode": f.TagMode, "All": TagModeAll, "Any": TagModeAny}, nil)
+	}
+}
 
 // Matches checks if a ticket matches the filter options.
 func (f FilterOptions) Matches(t *domain.Ticket) bool {
-	if f.Status != "" && string(t.Status) != f.Status {
+	if len(f.Status) > 0 && !slices.Contains(f.Status, string(t.Status)) {
 		return false
 	}
-	if f.Assignee != "" && t.Assignee != f.Assignee {
+	if len(f.Assignee) > 0 && !slices.Contains(f.Assignee, t.Assignee) {
 		return false
 	}
-	if f.Tag != "" && !hasTag(t.Tags, f.Tag) {
+	if len(f.Tag) > 0 && !f.matchesTags(t.Tags) {
 		return false
 	}
-	if f.Type != "" && string(t.Type) != f.Type {
+	if len(f.Type) > 0 && !slices.Contains(f.Type, string(t.Type)) {
+		return false
+	}
+	if slices.Contains(f.NotStatus, string(t.Status)) {
+		return false
+	}
+	if t.Assignee != "" && slices.Contains(f.NotAssignee, t.Assignee) {
+		return false
+	}
+	for _, tag := range f.NotTag {
+		if hasTag(t.Tags, tag) {
+			return false
+		}
+	}
+	if f.Unassigned && t.Assignee != "" {
+		return false
+	}
+	if f.Untagged && len(t.Tags) > 0 {
+		return false
+	}
+	if f.NeedsReview && !t.NeedsReview() {
+		return false
+	}
+	return f.matchesDates(t)
+}
+
+// matchesDates checks the created and closed-at range filters.
+// Tickets without a closed-at timestamp never match a closed range.
+func (f FilterOptions) matchesDates(t *domain.Ticket) bool {
+	if !f.CreatedAfter.IsZero() && t.Created.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !t.Created.Before(f.CreatedBefore) {
+		return false
+	}
+	if f.ClosedAfter.IsZero() && f.ClosedBefore.IsZero() {
+		return true
+	}
+	if t.ClosedAt.IsZero() {
+		return false
+	}
+	if !f.ClosedAfter.IsZero() && t.ClosedAt.Before(f.ClosedAfter) {
+		return false
+	}
+	if !f.ClosedBefore.IsZero() && !t.ClosedAt.Before(f.ClosedBefore) {
 		return false
 	}
 	return true
 }
 
+// matchesTags checks the ticket tags against the tag filter using TagMode.
+func (f FilterOptions) matchesTags(tags []string) bool {
+	for _, tag := range f.Tag {
+		found := hasTag(tags, tag)
+		if f.TagMode == TagModeAny && found {
+			return true
+		}
+		if f.TagMode != TagModeAny && !found {
+			return false
+		}
+	}
+	return f.TagMode != TagModeAny
+}
+
 var listFlags FilterOptions
 var sortFlags SortOptions
 
+// countFlag makes list-style commands print only the number of matching tickets.
+var countFlag bool
+
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
@@ -56,6 +175,10 @@ var listCmd = &cobra.Command{
 
 Sort options: priority (default), created, status, title`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listFlags.Validate(); err != nil {
+			return err
+		}
+
 		tickets, err := store.List()
 		if err != nil {
 			return err
@@ -64,25 +187,31 @@ Sort options: priority (default), created, status, title`,
 		filtered := filterTickets(tickets, listFlags)
 		sortTickets(filtered, sortFlags)
 
-		return runWithPager(func(w io.Writer) error {
-			for _, t := range filtered {
-				if _, err := fmt.Fprintln(w, formatTicketLine(t)); err != nil {
-					return err
-				}
-			}
-			return nil
-		})
+		return printTickets(filtered)
 	},
 }
 
+var readyFlags struct {
+	limit     int
+	claimable bool
+}
+
 var readyCmd = &cobra.Command{
 	Use:   "ready",
 	Short: "List open/in_progress tickets with resolved deps",
 	Long: `List open or in_progress tickets that have no unresolved dependencies.
 
-Sort options: priority (default), created, status, title`,
+With --claimable, only tickets that tk claim would accept are listed: open
+tickets, and in_progress tickets whose lease has expired.
+
+Sort options: priority (default), created, age, status, title, in-status
+Ties are broken by ticket ID, so with --limit N an orchestrator always gets
+the same next N tickets for the same state.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return listByDependencyStatus(false)
+		if readyFlags.limit < 0 {
+			return trError(msgInvalidLimit, map[string]any{"Limit": readyFlags.limit}, nil)
+		}
+		return listByDependencyStatus(false, readyFlags.limit, readyFlags.claimable)
 	},
 }
 
@@ -93,7 +222,54 @@ var blockedCmd = &cobra.Command{
 
 Sort options: priority (default), created, status, title`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return listByDependencyStatus(true)
+		return listByDependencyStatus(true, 0, false)
+	},
+}
+
+// mineAssigneeFlags are the shared filter flags that contradict tk mine.
+var mineAssigneeFlags = []string{"assignee", "not-assignee", "no-assignee", "unassigned"}
+
+var mineCmd = &cobra.Command{
+	Use:   "mine",
+	Short: "List open/in_progress tickets assigned to you",
+	Long: `List open or in_progress tickets assigned to you (TK_USER or git user.name).
+Equivalent to: tk list --status open,in_progress --assignee @me
+The assignee filters do not apply; use tk list for other people's tickets.
+
+Sort options: priority (default), created, age, status, title, in-status`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, name := range mineAssigneeFlags {
+			if cmd.Flags().Changed(name) {
+				return trError(msgMineAssigneeFlag, map[string]any{"Flag": name}, nil)
+			}
+		}
+		if err := listFlags.Validate(); err != nil {
+			return err
+		}
+
+		user := currentUser()
+		if user == "" {
+			return trError(msgNoCurrentUser, nil, nil)
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+
+		var result []*domain.Ticket
+		for _, t := range tickets {
+			if t.Status == domain.StatusClosed || t.Assignee != user {
+				continue
+			}
+			if listFlags.Matches(t) {
+				result = append(result, t)
+			}
+		}
+
+		sortTickets(result, sortFlags)
+
+		return printTickets(result)
 	},
 }
 
@@ -106,8 +282,16 @@ var closedCmd = &cobra.Command{
 	Short: "List recently closed tickets",
 	Long: `List recently closed tickets.
 
+Use --since to show tickets closed within a window, e.g. --since 7d for
+"what landed this week". --since is based on the closed-at timestamp and
+lifts the default --limit unless --limit is given explicitly.
+
 Sort options: priority, created (default, descending), status, title`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listFlags.Validate(); err != nil {
+			return err
+		}
+
 		tickets, err := store.List()
 		if err != nil {
 			return err
@@ -131,19 +315,16 @@ Sort options: priority, created (default, descending), status, title`,
 		}
 		sortTickets(closed, opts)
 
-		// Limit results
-		if closedFlags.limit > 0 && len(closed) > closedFlags.limit {
-			closed = closed[:closedFlags.limit]
+		// Limit results; a --since window shows everything unless --limit is explicit
+		limit := closedFlags.limit
+		if cmd.Flags().Changed("since") && !cmd.Flags().Changed("limit") {
+			limit = 0
+		}
+		if limit > 0 && len(closed) > limit {
+			closed = closed[:limit]
 		}
 
-		return runWithPager(func(w io.Writer) error {
-			for _, t := range closed {
-				if _, err := fmt.Fprintln(w, formatTicketLine(t)); err != nil {
-					return err
-				}
-			}
-			return nil
-		})
+		return printTickets(closed)
 	},
 }
 
@@ -172,39 +353,65 @@ func sortTickets(tickets []*domain.Ticket, opts SortOptions) {
 		sortBy = "priority"
 	}
 
+	// Ties are broken by ID so the order is stable across runs
 	sort.Slice(tickets, func(i, j int) bool {
-		var less bool
+		a, b := tickets[i], tickets[j]
+		if opts.Reverse {
+			a, b = b, a
+		}
+
+		var c int
 		switch sortBy {
-		case "created":
-			less = tickets[i].Created.Before(tickets[j].Created)
+		case "created", "age": // age: oldest first
+			c = a.Created.Compare(b.Created)
 		case "status":
-			less = string(tickets[i].Status) < string(tickets[j].Status)
+			c = cmp.Compare(a.Status, b.Status)
 		case "title":
-			less = strings.ToLower(tickets[i].Title) < strings.ToLower(tickets[j].Title)
+			c = cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		case "in-status": // longest in current status first
+			c = statusChangedAt(a).Compare(statusChangedAt(b))
 		default: // priority
-			if tickets[i].Priority != tickets[j].Priority {
-				less = tickets[i].Priority < tickets[j].Priority
-			} else {
-				less = tickets[i].ID < tickets[j].ID
-			}
+			c = cmp.Compare(a.Priority, b.Priority)
 		}
 
-		if opts.Reverse {
-			return !less
+		if c != 0 {
+			return c < 0
 		}
-		return less
+		return a.ID < b.ID
 	})
 }
 
 // listByDependencyStatus lists tickets filtered by their dependency status.
 // If wantBlocked is true, it lists tickets with unresolved dependencies (blocked).
 // If wantBlocked is false, it lists tickets with no unresolved dependencies (ready).
-func listByDependencyStatus(wantBlocked bool) error {
+// A positive limit keeps only the first limit tickets after sorting, and
+// claimable keeps only tickets that can be claimed.
+func listByDependencyStatus(wantBlocked bool, limit int, claimable bool) error {
+	if err := listFlags.Validate(); err != nil {
+		return err
+	}
+
 	tickets, err := store.List()
 	if err != nil {
 		return err
 	}
 
+	result := filterByDependencyStatus(tickets, wantBlocked)
+	if claimable {
+		now := time.Now()
+		result = slices.DeleteFunc(result, func(t *domain.Ticket) bool { return !t.Claimable(now) })
+	}
+	sortTickets(result, sortFlags)
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return printTickets(result)
+}
+
+// filterByDependencyStatus returns the open or in_progress tickets matching
+// the filter flags whose unresolved dependencies match wantBlocked.
+func filterByDependencyStatus(tickets []*domain.Ticket, wantBlocked bool) []*domain.Ticket {
 	openIDs := buildOpenIDSet(tickets)
 
 	var result []*domain.Ticket
@@ -226,11 +433,19 @@ func listByDependencyStatus(wantBlocked bool) error {
 			result = append(result, t)
 		}
 	}
+	return result
+}
 
-	sortTickets(result, sortFlags)
+// printTickets writes one summary line per ticket through the pager,
+// or only the number of tickets when --count is set.
+func printTickets(tickets []*domain.Ticket) error {
+	if countFlag {
+		fmt.Println(len(tickets))
+		return nil
+	}
 
 	return runWithPager(func(w io.Writer) error {
-		for _, t := range result {
+		for _, t := range tickets {
 			if _, err := fmt.Fprintln(w, formatTicketLine(t)); err != nil {
 				return err
 			}
@@ -239,30 +454,64 @@ func listByDependencyStatus(wantBlocked bool) error {
 	})
 }
 
-func init() {
-	listCmd.Flags().StringVar(&listFlags.Status, "status", "", "Filter by status (open|in_progress|closed)")
-	listCmd.Flags().StringVarP(&listFlags.Assignee, "assignee", "a", "", "Filter by assignee")
-	listCmd.Flags().StringVarP(&listFlags.Tag, "tag", "T", "", "Filter by tag")
-	listCmd.Flags().StringVarP(&listFlags.Type, "type", "t", "", "Filter by type (task|bug|feature|epic|chore)")
-	listCmd.Flags().StringVarP(&sortFlags.SortBy, "sort", "s", "", "Sort by field (priority|created|status|title)")
-	listCmd.Flags().BoolVarP(&sortFlags.Reverse, "reverse", "r", false, "Reverse sort order")
-
-	readyCmd.Flags().StringVarP(&listFlags.Assignee, "assignee", "a", "", "Filter by assignee")
-	readyCmd.Flags().StringVarP(&listFlags.Tag, "tag", "T", "", "Filter by tag")
-	readyCmd.Flags().StringVarP(&listFlags.Type, "type", "t", "", "Filter by type (task|bug|feature|epic|chore)")
-	readyCmd.Flags().StringVarP(&sortFlags.SortBy, "sort", "s", "", "Sort by field (priority|created|status|title)")
-	readyCmd.Flags().BoolVarP(&sortFlags.Reverse, "reverse", "r", false, "Reverse sort order")
-
-	blockedCmd.Flags().StringVarP(&listFlags.Assignee, "assignee", "a", "", "Filter by assignee")
-	blockedCmd.Flags().StringVarP(&listFlags.Tag, "tag", "T", "", "Filter by tag")
-	blockedCmd.Flags().StringVarP(&listFlags.Type, "type", "t", "", "Filter by type (task|bug|feature|epic|chore)")
-	blockedCmd.Flags().StringVarP(&sortFlags.SortBy, "sort", "s", "", "Sort by field (priority|created|status|title)")
-	blockedCmd.Flags().BoolVarP(&sortFlags.Reverse, "reverse", "r", false, "Reverse sort order")
+// addFilterFlags registers the common filter and sort flags on a list command.
+func addFilterFlags(cmd *cobra.Command, withStatus bool) {
+	addMatchFlags(cmd, withStatus)
+	cmd.Flags().VarP(sortFieldValue{&sortFlags.SortBy}, "sort", "s", "Sort by field (priority|created|age|status|title|in-status)")
+	cmd.Flags().BoolVarP(&sortFlags.Reverse, "reverse", "r", false, "Reverse sort order")
+	addLineFormatFlag(cmd)
+	addCountFlag(cmd)
+}
 
+// addMatchFlags registers the ticket filter flags without the output flags.
+func addMatchFlags(cmd *cobra.Command, withStatus bool) {
+	if withStatus {
+		cmd.Flags().StringSliceVar(&listFlags.Status, "status", nil, "Filter by status, comma-separated or repeated (open|in_progress|closed)")
+		cmd.Flags().StringSliceVar(&listFlags.NotStatus, "not-status", nil, "Exclude tickets with status")
+	}
+	cmd.Flags().StringSliceVarP(&listFlags.Assignee, "assignee", "a", nil, "Filter by assignee, comma-separated or repeated")
+	cmd.Flags().StringSliceVarP(&listFlags.Tag, "tag", "T", nil, "Filter by tag, comma-separated or repeated")
+	cmd.Flags().StringVar(&listFlags.TagMode, "tag-mode", TagModeAll, "Match all tags or any tag (all|any)")
+	cmd.Flags().StringSliceVarP(&listFlags.Type, "type", "t", nil, "Filter by type, comma-separated or repeated (task|bug|feature|epic|chore)")
+	cmd.Flags().StringSliceVar(&listFlags.NotAssignee, "not-assignee", nil, "Exclude tickets assigned to assignee")
+	cmd.Flags().BoolVar(&listFlags.Unassigned, "no-assignee", false, "Only show unassigned tickets")
+	cmd.Flags().BoolVar(&listFlags.Unassigned, "unassigned", false, "Only show unassigned tickets (same as --no-assignee)")
+	cmd.Flags().BoolVar(&listFlags.Untagged, "untagged", false, "Only show tickets without tags")
+	cmd.Flags().StringSliceVar(&listFlags.NotTag, "not-tag", nil, "Exclude tickets with tag")
+	cmd.Flags().BoolVar(&listFlags.NeedsReview, "needs-review", false, "Only show tickets with pending review requests")
+}
+
+// addCountFlag registers the --count flag.
+func addCountFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&countFlag, "count", false, "Print only the number of matching tickets")
+}
+
+// addLineFormatFlag registers the --line-format template override.
+func addLineFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&lineFormatFlag, "line-format", "", "Go template for each ticket line (overrides line_format in config)")
+}
+
+// addDateFilterFlags registers the created and closed date-range flags.
+func addDateFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(timeValue{&listFlags.CreatedAfter}, "created-after", "Only tickets created at or after time (RFC3339, YYYY-MM-DD, or relative like 2w)")
+	cmd.Flags().Var(timeValue{&listFlags.CreatedBefore}, "created-before", "Only tickets created before time")
+	cmd.Flags().Var(timeValue{&listFlags.ClosedAfter}, "closed-after", "Only tickets closed at or after time")
+	cmd.Flags().Var(timeValue{&listFlags.ClosedBefore}, "closed-before", "Only tickets closed before time")
+}
+
+func init() {
+	addFilterFlags(listCmd, true)
+	addFilterFlags(readyCmd, true)
+	readyCmd.Flags().IntVar(&readyFlags.limit, "limit", 0, "Show at most N tickets (0 = no limit)")
+	readyCmd.Flags().BoolVar(&readyFlags.claimable, "claimable", false, "Only open tickets and in_progress tickets with an expired lease")
+	addFilterFlags(blockedCmd, true)
+	addFilterFlags(closedCmd, false)
+	addFilterFlags(mineCmd, false)
+	for _, name := range mineAssigneeFlags {
+		_ = mineCmd.Flags().MarkHidden(name)
+	}
+	addDateFilterFlags(listCmd)
+	addDateFilterFlags(closedCmd)
 	closedCmd.Flags().IntVar(&closedFlags.limit, "limit", 20, "Limit number of results")
-	closedCmd.Flags().StringVarP(&listFlags.Assignee, "assignee", "a", "", "Filter by assignee")
-	closedCmd.Flags().StringVarP(&listFlags.Tag, "tag", "T", "", "Filter by tag")
-	closedCmd.Flags().StringVarP(&listFlags.Type, "type", "t", "", "Filter by type (task|bug|feature|epic|chore)")
-	closedCmd.Flags().StringVarP(&sortFlags.SortBy, "sort", "s", "", "Sort by field (priority|created|status|title)")
-	closedCmd.Flags().BoolVarP(&sortFlags.Reverse, "reverse", "r", false, "Reverse sort order")
+	closedCmd.Flags().Var(timeValue{&listFlags.ClosedAfter}, "since", "Only tickets closed within duration (e.g. 24h, 7d, 2w)")
 }
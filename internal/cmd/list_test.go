@@ -75,11 +75,12 @@ func (s *ListSuite) TestFilterTickets() {
 	}
 
 	tests := []struct {
-		name      string
-		status    string
-		assignee  string
-		tag       string
-		wantIDs   []string
+		name     string
+		status   []string
+		assignee []string
+		tag      []string
+		tagMode  string
+		wantIDs  []string
 	}{
 		{
 			name:    "no filters",
@@ -87,40 +88,61 @@ func (s *ListSuite) TestFilterTickets() {
 		},
 		{
 			name:    "filter by status open",
-			status:  "open",
+			status:  []string{"open"},
 			wantIDs: []string{"t1", "t4"},
 		},
 		{
 			name:    "filter by status in_progress",
-			status:  "in_progress",
+			status:  []string{"in_progress"},
 			wantIDs: []string{"t2"},
 		},
+		{
+			name:    "filter by multiple statuses",
+			status:  []string{"open", "in_progress"},
+			wantIDs: []string{"t1", "t2", "t4"},
+		},
 		{
 			name:     "filter by assignee",
-			assignee: "alice",
+			assignee: []string{"alice"},
 			wantIDs:  []string{"t1", "t3"},
 		},
+		{
+			name:     "filter by multiple assignees",
+			assignee: []string{"bob", "charlie"},
+			wantIDs:  []string{"t2", "t4"},
+		},
 		{
 			name:    "filter by tag",
-			tag:     "backend",
+			tag:     []string{"backend"},
 			wantIDs: []string{"t1", "t3"},
 		},
+		{
+			name:    "filter by multiple tags matches all by default",
+			tag:     []string{"backend", "urgent"},
+			wantIDs: []string{"t3"},
+		},
+		{
+			name:    "filter by multiple tags with any mode",
+			tag:     []string{"frontend", "api"},
+			tagMode: TagModeAny,
+			wantIDs: []string{"t2", "t4"},
+		},
 		{
 			name:     "filter by status and assignee",
-			status:   "open",
-			assignee: "alice",
+			status:   []string{"open"},
+			assignee: []string{"alice"},
 			wantIDs:  []string{"t1"},
 		},
 		{
 			name:     "filter by assignee and tag",
-			assignee: "alice",
-			tag:      "urgent",
+			assignee: []string{"alice"},
+			tag:      []string{"urgent"},
 			wantIDs:  []string{"t3"},
 		},
 		{
 			name:    "no matches",
-			status:  "open",
-			tag:     "nonexistent",
+			status:  []string{"open"},
+			tag:     []string{"nonexistent"},
 			wantIDs: nil,
 		},
 	}
@@ -131,6 +153,7 @@ func (s *ListSuite) TestFilterTickets() {
 				Status:   tt.status,
 				Assignee: tt.assignee,
 				Tag:      tt.tag,
+				TagMode:  tt.tagMode,
 			}
 			result := filterTickets(tickets, opts)
 
@@ -144,6 +167,113 @@ func (s *ListSuite) TestFilterTickets() {
 	}
 }
 
+func (s *ListSuite) TestFilterTicketsNegation() {
+	tickets := []*domain.Ticket{
+		{ID: "t1", Status: domain.StatusOpen, Assignee: "alice", Tags: []string{"icebox"}},
+		{ID: "t2", Status: domain.StatusInProgress, Assignee: "bob"},
+		{ID: "t3", Status: domain.StatusClosed, Tags: []string{"backend"}},
+	}
+
+	tests := []struct {
+		name    string
+		opts    FilterOptions
+		wantIDs []string
+	}{
+		{
+			name:    "not status",
+			opts:    FilterOptions{NotStatus: []string{"closed"}},
+			wantIDs: []string{"t1", "t2"},
+		},
+		{
+			name:    "not assignee keeps unassigned",
+			opts:    FilterOptions{NotAssignee: []string{"alice"}},
+			wantIDs: []string{"t2", "t3"},
+		},
+		{
+			name:    "not tag",
+			opts:    FilterOptions{NotTag: []string{"ICEBOX"}},
+			wantIDs: []string{"t2", "t3"},
+		},
+		{
+			name:    "unassigned",
+			opts:    FilterOptions{Unassigned: true},
+			wantIDs: []string{"t3"},
+		},
+		{
+			name:    "untagged",
+			opts:    FilterOptions{Untagged: true},
+			wantIDs: []string{"t2"},
+		},
+		{
+			name:    "combined with positive filter",
+			opts:    FilterOptions{Status: []string{"open", "in_progress"}, NotTag: []string{"icebox"}},
+			wantIDs: []string{"t2"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var ids []string
+			for _, t := range filterTickets(tickets, tt.opts) {
+				ids = append(ids, t.ID)
+			}
+			require.Equal(s.T(), tt.wantIDs, ids)
+		})
+	}
+}
+
+func (s *ListSuite) TestFilterTicketsDateRanges() {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	tickets := []*domain.Ticket{
+		{ID: "t1", Status: domain.StatusClosed, Created: base, ClosedAt: base.Add(48 * time.Hour)},
+		{ID: "t2", Status: domain.StatusClosed, Created: base.Add(24 * time.Hour), ClosedAt: base.Add(96 * time.Hour)},
+		{ID: "t3", Status: domain.StatusOpen, Created: base.Add(72 * time.Hour)},
+	}
+
+	tests := []struct {
+		name    string
+		opts    FilterOptions
+		wantIDs []string
+	}{
+		{
+			name:    "created after",
+			opts:    FilterOptions{CreatedAfter: base.Add(24 * time.Hour)},
+			wantIDs: []string{"t2", "t3"},
+		},
+		{
+			name:    "created before",
+			opts:    FilterOptions{CreatedBefore: base.Add(24 * time.Hour)},
+			wantIDs: []string{"t1"},
+		},
+		{
+			name:    "closed after excludes tickets without closed-at",
+			opts:    FilterOptions{ClosedAfter: base},
+			wantIDs: []string{"t1", "t2"},
+		},
+		{
+			name:    "closed window",
+			opts:    FilterOptions{ClosedAfter: base.Add(72 * time.Hour), ClosedBefore: base.Add(120 * time.Hour)},
+			wantIDs: []string{"t2"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var ids []string
+			for _, t := range filterTickets(tickets, tt.opts) {
+				ids = append(ids, t.ID)
+			}
+			require.Equal(s.T(), tt.wantIDs, ids)
+		})
+	}
+}
+
+func (s *ListSuite) TestFilterOptionsValidate() {
+	require.NoError(s.T(), FilterOptions{}.Validate())
+	require.NoError(s.T(), FilterOptions{TagMode: TagModeAny}.Validate())
+	require.Error(s.T(), FilterOptions{TagMode: "some"}.Validate())
+}
+
 func (s *ListSuite) TestSortTicketsDefaultPriority() {
 	tests := []struct {
 		name    string
@@ -279,3 +409,72 @@ func (s *ListSuite) TestSortTickets() {
 		})
 	}
 }
+
+func (s *ListSuite) TestSortTicketsBreaksTiesByID() {
+	now := time.Now()
+	for _, sortBy := range []string{"priority", "created", "age", "status", "title"} {
+		s.Run(sortBy, func() {
+			tickets := []*domain.Ticket{
+				{ID: "t3", Priority: 1, Status: domain.StatusOpen, Title: "Same", Created: now},
+				{ID: "t1", Priority: 1, Status: domain.StatusOpen, Title: "same", Created: now},
+				{ID: "t2", Priority: 1, Status: domain.StatusOpen, Title: "Same", Created: now},
+			}
+
+			sortTickets(tickets, SortOptions{SortBy: sortBy})
+			require.Equal(s.T(), []string{"t1", "t2", "t3"}, []string{tickets[0].ID, tickets[1].ID, tickets[2].ID})
+
+			sortTickets(tickets, SortOptions{SortBy: sortBy, Reverse: true})
+			require.Equal(s.T(), []string{"t3", "t2", "t1"}, []string{tickets[0].ID, tickets[1].ID, tickets[2].ID})
+		})
+	}
+}
+
+func (s *ListSuite) TestSortTicketsByInStatus() {
+	now := time.Now()
+	statusSince = map[string]time.Time{
+		"reopened": now.Add(-time.Hour),
+		"started":  now.Add(-5 * time.Hour),
+	}
+	defer func() { statusSince = nil }()
+
+	tickets := []*domain.Ticket{
+		{ID: "reopened", Created: now.Add(-10 * time.Hour)}, // in status for 1h
+		{ID: "fresh", Created: now.Add(-2 * time.Hour)},     // never changed status
+		{ID: "started", Created: now.Add(-6 * time.Hour)},   // in status for 5h
+		{ID: "old", Created: now.Add(-8 * time.Hour)},
+	}
+
+	sortTickets(tickets, SortOptions{SortBy: "in-status"})
+
+	var ids []string
+	for _, t := range tickets {
+		ids = append(ids, t.ID)
+	}
+	require.Equal(s.T(), []string{"old", "started", "fresh", "reopened"}, ids)
+}
+
+func (s *ListSuite) TestNewlyUnblocked() {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+
+	tickets := []*domain.Ticket{
+		{ID: "recent", Status: domain.StatusClosed, ClosedAt: now.Add(-time.Hour)},
+		{ID: "old", Status: domain.StatusClosed, ClosedAt: now.Add(-72 * time.Hour)},
+		{ID: "open", Status: domain.StatusOpen},
+		{ID: "unblocked", Status: domain.StatusOpen, Deps: []string{"old", "recent"}},
+		{ID: "long-ready", Status: domain.StatusOpen, Deps: []string{"old"}},
+		{ID: "still-blocked", Status: domain.StatusOpen, Deps: []string{"recent", "open"}},
+		{ID: "via-archive", Status: domain.StatusInProgress, Deps: []string{"archived"}},
+		{ID: "no-deps", Status: domain.StatusOpen},
+		{ID: "done", Status: domain.StatusClosed, ClosedAt: now, Deps: []string{"recent"}},
+	}
+	archived := []*domain.Ticket{
+		{ID: "archived", Status: domain.StatusClosed, ClosedAt: now.Add(-2 * time.Hour)},
+	}
+
+	var ids []string
+	for _, t := range newlyUnblocked(tickets, archived, since) {
+		ids = append(ids, t.ID)
+	}
+	require.Equal(s.T(), []string{"unblocked", "via-archive"}, ids)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var lockFlags struct {
+	reason string
+}
+
+var lockCmd = &cobra.Command{
+	Use:   "lock <id>",
+	Short: "Freeze a ticket against changes",
+	Long: `Lock a ticket so that every mutating command refuses to change it until it is
+unlocked, e.g. for compliance-sensitive tickets after sign-off. Neither --force
+nor --overwrite overrides a lock.
+
+Examples:
+  tk lock abc1 --reason "under audit"
+  tk unlock abc1`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgResolveFailed, nil, err)
+		}
+		if ticket.Locked {
+			return trError(msgAlreadyLocked, map[string]any{"ID": ticket.ID}, nil)
+		}
+
+		ticket.Locked = true
+		ticket.LockReason = strings.TrimSpace(lockFlags.reason)
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+
+		fmt.Println(tr(msgLocked, map[string]any{"ID": ticket.ID}))
+		return nil
+	},
+}
+
+var unlockCmd = &cobra.Command{
+	Use:   "unlock <id>",
+	Short: "Allow changes to a locked ticket again",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgResolveFailed, nil, err)
+		}
+		if !ticket.Locked {
+			return trError(msgNotLocked, map[string]any{"ID": ticket.ID}, nil)
+		}
+
+		ticket.Locked = false
+		ticket.LockReason = ""
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+
+		fmt.Println(tr(msgUnlocked, map[string]any{"ID": ticket.ID}))
+		return nil
+	},
+}
+
+func init() {
+	lockCmd.Flags().StringVarP(&lockFlags.reason, "reason", "r", "", "Why the ticket is locked")
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+var mentionsFlags struct {
+	user string
+}
+
+var mentionsCmd = &cobra.Command{
+	Use:   "mentions",
+	Short: "List tickets where a user is @mentioned",
+	Long: `List tickets that @mention a user in the title, description, design,
+acceptance criteria or notes, excluding tickets already assigned to that user.
+Closed tickets are skipped unless --status is given.
+
+--user defaults to you (TK_USER or git user.name); @me is accepted as well. A mention
+matches a user by full name without spaces or punctuation (@janedoe, @jane.doe)
+or by first name (@jane), ignoring case.
+
+Examples:
+  tk mentions                 # Tickets mentioning you
+  tk mentions --user @alice   # Tickets mentioning alice`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listFlags.Validate(); err != nil {
+			return err
+		}
+
+		user := strings.TrimPrefix(mentionsFlags.user, "@")
+		if user == "" {
+			user = currentUser()
+		}
+		if user == "" {
+			return trError(msgNoCurrentUser, nil, nil)
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+
+		var result []*domain.Ticket
+		for _, t := range tickets {
+			if len(listFlags.Status) == 0 && t.Status == domain.StatusClosed {
+				continue
+			}
+			if isAssignedTo(t, user) || !mentionsUser(t, user) {
+				continue
+			}
+			if listFlags.Matches(t) {
+				result = append(result, t)
+			}
+		}
+
+		sortTickets(result, sortFlags)
+
+		return printTickets(result)
+	},
+}
+
+// mentionsUser reports whether the ticket @mentions user.
+func mentionsUser(t *domain.Ticket, user string) bool {
+	return slices.ContainsFunc(t.Mentions(), func(handle string) bool {
+		return domain.MentionMatches(handle, user)
+	})
+}
+
+// isAssignedTo reports whether the ticket is assigned to user, given either
+// as a full name or as a mention handle.
+func isAssignedTo(t *domain.Ticket, user string) bool {
+	if t.Assignee == "" {
+		return false
+	}
+	return strings.EqualFold(t.Assignee, user) || domain.MentionMatches(user, t.Assignee)
+}
+
+func init() {
+	mentionsCmd.Flags().StringVarP(&mentionsFlags.user, "user", "u", "", "User to find mentions of (default: you)")
+	addFilterFlags(mentionsCmd, true)
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+	"github.com/radutopala/ticket/internal/storage"
+)
+
+var moveFlags struct {
+	to string
+}
+
+var moveCmd = &cobra.Command{
+	Use:   "move <id>... --to <dir>",
+	Short: "Move tickets into another tickets directory",
+	Long: `Move tickets into another tickets directory, e.g. to split a monorepo
+backlog into per-service backlogs. Ticket files move unchanged and keep their
+IDs, so parent, dependency and link references between moved tickets stay
+valid. References that would cross between the two directories are dropped
+from both sides and reported, unless the referenced ticket already exists in
+the target directory.
+
+Examples:
+  tk move abc1 def2 --to ../billing/.tickets
+  tk list -T billing --line-format '{{.ID}}' | xargs tk move --to ../billing/.tickets`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if moveFlags.to == "" {
+			return trError(msgMoveNeedsTo, nil, nil)
+		}
+		target := storage.New(moveFlags.to)
+		target.SetActor(store.Actor())
+		if sameDir(target.TicketsDir(), store.TicketsDir()) {
+			return trError(msgMoveSameDir, map[string]any{"Dir": moveFlags.to}, nil)
+		}
+
+		moved := make(map[string]*domain.Ticket)
+		var ids []string
+		for _, arg := range args {
+			ticket, err := resolveAndReadTicket(arg)
+			if err != nil {
+				return trWrap(msgResolveFailed, nil, err)
+			}
+			if _, ok := moved[ticket.ID]; ok {
+				continue
+			}
+			if ticket.Locked {
+				return storage.LockedError(ticket)
+			}
+			if target.Exists(ticket.ID) {
+				return trError(msgMoveExists, map[string]any{"ID": ticket.ID, "Dir": moveFlags.to}, nil)
+			}
+			moved[ticket.ID] = ticket
+			ids = append(ids, ticket.ID)
+		}
+
+		remaining, err := store.List()
+		if err != nil {
+			return err
+		}
+		for _, t := range remaining {
+			if _, ok := moved[t.ID]; ok || !t.Locked {
+				continue
+			}
+			if len(dropReferences(cloneRefs(t), func(id string) bool { return moved[id] == nil })) > 0 {
+				return fmt.Errorf("%w: %s", storage.ErrLocked, tr(msgMoveRefLocked, map[string]any{"ID": t.ID}))
+			}
+		}
+
+		if err := target.EnsureDir(); err != nil {
+			return fmt.Errorf("failed to create tickets directory: %w", err)
+		}
+
+		for _, id := range ids {
+			ticket := moved[id]
+			dropped := dropReferences(ticket, func(ref string) bool {
+				return moved[ref] != nil || target.Exists(ref)
+			})
+			if err := target.Restore(ticket); err != nil {
+				return err
+			}
+			if err := store.Delete(id); err != nil {
+				return err
+			}
+			fmt.Println(tr(msgMoved, map[string]any{"ID": id, "Dir": moveFlags.to}))
+			printDropped(id, dropped)
+		}
+
+		for _, t := range remaining {
+			if _, ok := moved[t.ID]; ok {
+				continue
+			}
+			dropped := dropReferences(t, func(id string) bool { return moved[id] == nil })
+			if len(dropped) == 0 {
+				continue
+			}
+			if err := store.Write(t); err != nil {
+				return err
+			}
+			printDropped(t.ID, dropped)
+		}
+
+		return nil
+	},
+}
+
+// dropReferences removes t's parent, dependencies and links for which keep
+// returns false and describes each removed reference.
+func dropReferences(t *domain.Ticket, keep func(id string) bool) []string {
+	var dropped []string
+	if t.Parent != "" && !keep(t.Parent) {
+		dropped = append(dropped, "parent "+t.Parent)
+		t.Parent = ""
+	}
+	drop := func(kind string) func(id string) bool {
+		return func(id string) bool {
+			if keep(id) {
+				return false
+			}
+			dropped = append(dropped, kind+" "+id)
+			return true
+		}
+	}
+	t.Deps = slices.DeleteFunc(t.Deps, drop("dep"))
+	t.Links = slices.DeleteFunc(t.Links, drop("link"))
+	return dropped
+}
+
+// cloneRefs copies the references of t so dropReferences can check them
+// without modifying t.
+func cloneRefs(t *domain.Ticket) *domain.Ticket {
+	return &domain.Ticket{Parent: t.Parent, Deps: slices.Clone(t.Deps), Links: slices.Clone(t.Links)}
+}
+
+func printDropped(id string, dropped []string) {
+	for _, ref := range dropped {
+		fmt.Println("  " + tr(msgMoveDropped, map[string]any{"ID": id, "Ref": ref}))
+	}
+}
+
+// sameDir reports whether a and b name the same directory.
+func sameDir(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+func init() {
+	moveCmd.Flags().StringVar(&moveFlags.to, "to", "", "Target tickets directory")
+}
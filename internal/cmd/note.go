@@ -4,8 +4,11 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 
@@ -20,31 +23,12 @@ var addNoteCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ticket, err := resolveAndReadTicket(args[0])
 		if err != nil {
-			return fmt.Errorf("failed to resolve ticket ID: %w", err)
-		}
-
-		// Get note text from args or stdin
-		var noteText string
-		if len(args) > 1 {
-			noteText = strings.Join(args[1:], " ")
-		} else {
-			// Check if stdin has data
-			stat, _ := os.Stdin.Stat()
-			if (stat.Mode() & os.ModeCharDevice) == 0 {
-				scanner := bufio.NewScanner(os.Stdin)
-				var lines []string
-				for scanner.Scan() {
-					lines = append(lines, scanner.Text())
-				}
-				if err := scanner.Err(); err != nil {
-					return fmt.Errorf("error reading stdin: %w", err)
-				}
-				noteText = strings.Join(lines, "\n")
-			}
+			return trWrap(msgResolveFailed, nil, err)
 		}
 
-		if noteText == "" {
-			return fmt.Errorf("no note text provided")
+		noteText, err := readNoteText(args[1:])
+		if err != nil {
+			return err
 		}
 
 		// Add the note
@@ -58,7 +42,209 @@ var addNoteCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Printf("Added note to %s\n", ticket.ID)
+		fmt.Println(tr(msgAddedNote, map[string]any{"ID": ticket.ID}))
+		return nil
+	},
+}
+
+var replyCmd = &cobra.Command{
+	Use:   "reply <id> <note-n> [text]",
+	Short: "Reply to a note on a ticket",
+	Long: `Append a timestamped note replying to note number n (as numbered in tk show).
+Replies are shown indented under the note they answer. Text can be provided as
+an argument or piped via stdin.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgResolveFailed, nil, err)
+		}
+
+		replyTo, err := strconv.Atoi(strings.TrimPrefix(args[1], "#"))
+		if err != nil || replyTo < 1 || replyTo > len(ticket.Notes) {
+			return trError(msgNoteInvalidNumber, map[string]any{"Number": strconv.Quote(args[1]), "ID": ticket.ID, "Count": len(ticket.Notes)}, nil)
+		}
+
+		noteText, err := readNoteText(args[2:])
+		if err != nil {
+			return err
+		}
+
+		ticket.Notes = append(ticket.Notes, domain.Note{
+			Timestamp: time.Now().UTC(),
+			Content:   noteText,
+			ReplyTo:   replyTo,
+		})
+
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+
+		fmt.Println(tr(msgAddedReply, map[string]any{"ID": ticket.ID, "Note": replyTo}))
+		return nil
+	},
+}
+
+// AttachmentsDirName is the directory inside the tickets directory holding
+// files that belong to tickets, one subdirectory per ticket.
+const AttachmentsDirName = "attachments"
+
+// compactLineWidth is the maximum length of a compacted note's summary line.
+const compactLineWidth = 72
+
+var noteCompactFlags struct {
+	keepLast int
+	attach   bool
+}
+
+var noteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Manage ticket notes",
+}
+
+var noteCompactCmd = &cobra.Command{
+	Use:   "compact <id>",
+	Short: "Collapse older notes into one summary note",
+	Long: `Collapse all but the last --keep-last notes of a ticket into a single note
+listing the first line of each, to keep tickets readable when agents append
+many progress notes. Replies are renumbered to match.
+
+With --attach, the compacted notes are also saved in full to
+.tickets/attachments/<id>/ and the summary note names the file. Otherwise the
+full text is only recoverable with tk undo.
+
+Examples:
+  tk note compact abc1 --keep-last 3
+  tk note compact abc1 --attach`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if noteCompactFlags.keepLast < 0 {
+			return trError(msgNoteInvalidKeepLast, map[string]any{"Count": noteCompactFlags.keepLast}, nil)
+		}
+
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgResolveFailed, nil, err)
+		}
+
+		older := len(ticket.Notes) - noteCompactFlags.keepLast
+		if older < 2 {
+			fmt.Println(tr(msgNoteNothingCompact, map[string]any{"ID": ticket.ID}))
+			return nil
+		}
+
+		var attachment string
+		if noteCompactFlags.attach {
+			if attachment, err = saveNotesAttachment(ticket, ticket.Notes[:older]); err != nil {
+				return err
+			}
+		}
+
+		ticket.Notes = compactNotes(ticket.Notes, older, attachment)
+		if err := store.Write(ticket); err != nil {
+			if attachment != "" {
+				// No note names the attachment, so don't leave it behind
+				_ = os.Remove(filepath.Join(store.TicketsDir(), attachment))
+			}
+			return err
+		}
+
+		fmt.Println(tr(msgNoteCompacted, map[string]any{"Count": older, "ID": ticket.ID}))
+		if attachment != "" {
+			fmt.Println(tr(msgNoteSavedTo, map[string]any{"Path": filepath.Join(store.TicketsDir(), attachment)}))
+		}
 		return nil
 	},
 }
+
+// compactNotes replaces the first n notes with one note summarizing them,
+// pointing to attachment if set. Replies to compacted notes become replies to
+// the summary note and the remaining replies are renumbered.
+func compactNotes(notes []domain.Note, n int, attachment string) []domain.Note {
+	older := notes[:n]
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "Compacted %d notes from %s to %s:\n", n,
+		older[0].Timestamp.Format(time.RFC3339), older[n-1].Timestamp.Format(time.RFC3339))
+	for _, note := range older {
+		line, _, _ := strings.Cut(strings.TrimSpace(note.Content), "\n")
+		if utf8.RuneCountInString(line) > compactLineWidth {
+			line = string([]rune(line)[:compactLineWidth-3]) + "..."
+		}
+		fmt.Fprintf(&buf, "- %s: %s\n", note.Timestamp.Format(time.RFC3339), line)
+	}
+	if attachment != "" {
+		fmt.Fprintf(&buf, "\nFull text: %s\n", filepath.ToSlash(attachment))
+	}
+
+	compacted := []domain.Note{{Timestamp: older[n-1].Timestamp, Content: strings.TrimSuffix(buf.String(), "\n")}}
+	for _, note := range notes[n:] {
+		switch {
+		case note.ReplyTo > n:
+			note.ReplyTo -= n - 1
+		case note.ReplyTo > 0:
+			note.ReplyTo = 1
+		}
+		compacted = append(compacted, note)
+	}
+	return compacted
+}
+
+// saveNotesAttachment writes notes in full to a new file in the ticket's
+// attachments directory and returns its path relative to the tickets directory.
+func saveNotesAttachment(ticket *domain.Ticket, notes []domain.Note) (string, error) {
+	name := fmt.Sprintf("notes-%s.md", notes[len(notes)-1].Timestamp.UTC().Format("20060102T150405Z"))
+	rel := filepath.Join(AttachmentsDirName, ticket.ID, name)
+	path := filepath.Join(store.TicketsDir(), rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	// Render the notes the way they appear in the ticket file
+	data := (&domain.Ticket{Title: "Notes compacted from " + ticket.ID, Notes: notes}).RenderMarkdownBody()
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to create attachment: %w", err)
+	}
+	if _, err := file.WriteString(data); err != nil {
+		_ = file.Close()
+		return "", fmt.Errorf("failed to write attachment: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write attachment: %w", err)
+	}
+	return rel, nil
+}
+
+// readNoteText returns the note text from args, or from stdin when no args are given.
+func readNoteText(args []string) (string, error) {
+	var noteText string
+	if len(args) > 0 {
+		noteText = strings.Join(args, " ")
+	} else {
+		// Check if stdin has data
+		stat, _ := os.Stdin.Stat()
+		if (stat.Mode() & os.ModeCharDevice) == 0 {
+			scanner := bufio.NewScanner(os.Stdin)
+			var lines []string
+			for scanner.Scan() {
+				lines = append(lines, scanner.Text())
+			}
+			if err := scanner.Err(); err != nil {
+				return "", fmt.Errorf("error reading stdin: %w", err)
+			}
+			noteText = strings.Join(lines, "\n")
+		}
+	}
+
+	if noteText == "" {
+		return "", trError(msgNoteEmpty, nil, nil)
+	}
+	return noteText, nil
+}
+
+func init() {
+	noteCompactCmd.Flags().IntVar(&noteCompactFlags.keepLast, "keep-last", 5, "Number of most recent notes to keep as they are")
+	noteCompactCmd.Flags().BoolVar(&noteCompactFlags.attach, "attach", false, "Save the compacted notes in full to .tickets/attachments/<id>/")
+	noteCmd.AddCommand(noteCompactCmd)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// pickMarkerFileName is the file in the tickets directory recording the
+// ticket last chosen by tk pick --round-robin.
+const pickMarkerFileName = ".pick-last"
+
+var pickFlags struct {
+	roundRobin bool
+	claim      bool
+	lease      time.Duration
+}
+
+var pickCmd = &cobra.Command{
+	Use:     "pick",
+	Aliases: []string{"random"},
+	Short:   "Pick a random ready ticket",
+	Long: `Pick one open ticket with no unresolved dependencies, at random by default,
+e.g. for bug-bash sessions. In-progress tickets whose lease has expired are
+candidates too. Filter flags narrow the candidates.
+
+With --round-robin the tickets are taken in ID order, continuing after the one
+picked last (recorded in .tickets/` + pickMarkerFileName + `), so grunt work is spread
+evenly. With --claim the picked ticket is started like tk start, with
+--lease making the claim expire.
+
+Examples:
+  tk pick -t bug -T bugbash     # A random open bug from the bug bash
+  tk pick --round-robin --claim # Take the next chore in turn`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listFlags.Validate(); err != nil {
+			return err
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var candidates []*domain.Ticket
+		for _, t := range filterByDependencyStatus(tickets, false) {
+			if t.Claimable(now) {
+				candidates = append(candidates, t)
+			}
+		}
+		if len(candidates) == 0 {
+			return trError(msgPickNone, nil, nil)
+		}
+
+		var picked *domain.Ticket
+		if pickFlags.roundRobin {
+			last, err := readPickMarker()
+			if err != nil {
+				return err
+			}
+			picked = nextRoundRobin(candidates, last)
+		} else {
+			picked = candidates[rand.IntN(len(candidates))]
+		}
+
+		if pickFlags.claim {
+			if picked, err = claimTicket(picked.ID, pickFlags.lease); err != nil {
+				return err
+			}
+		}
+		// Only a successful pick moves the rotation on, so a failed claim
+		// offers the same ticket next time
+		if pickFlags.roundRobin {
+			if err := writePickMarker(picked.ID); err != nil {
+				return err
+			}
+		}
+
+		fmt.Println(formatTicketLine(picked))
+		return nil
+	},
+}
+
+// nextRoundRobin returns the candidate with the smallest ID after last,
+// wrapping around to the smallest ID overall.
+func nextRoundRobin(candidates []*domain.Ticket, last string) *domain.Ticket {
+	sorted := append([]*domain.Ticket(nil), candidates...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	for _, t := range sorted {
+		if t.ID > last {
+			return t
+		}
+	}
+	return sorted[0]
+}
+
+// pickMarkerPath returns the path of the round-robin marker file.
+func pickMarkerPath() string {
+	return filepath.Join(store.TicketsDir(), pickMarkerFileName)
+}
+
+// readPickMarker returns the ID picked last by --round-robin, or empty.
+func readPickMarker() (string, error) {
+	data, err := os.ReadFile(pickMarkerPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read pick marker: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writePickMarker records id as the ticket picked last by --round-robin.
+func writePickMarker(id string) error {
+	if err := os.WriteFile(pickMarkerPath(), []byte(id+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write pick marker: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	addMatchFlags(pickCmd, false)
+	addLineFormatFlag(pickCmd)
+	pickCmd.Flags().BoolVar(&pickFlags.roundRobin, "round-robin", false, "Take tickets in turn instead of at random")
+	pickCmd.Flags().BoolVar(&pickFlags.claim, "claim", false, "Start the picked ticket (in_progress)")
+	pickCmd.Flags().Var(durationValue{&pickFlags.lease}, "lease", "With --claim, let the claim expire after duration")
+}
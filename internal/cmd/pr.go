@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// Pull request states reported by tk prs.
+const (
+	PRStateOpen   = "open"
+	PRStateDraft  = "draft"
+	PRStateClosed = "closed"
+	PRStateMerged = "merged"
+)
+
+// githubAPIURL is the GitHub REST API base URL; GitLab's API is on the
+// merge request's own host.
+var githubAPIURL = "https://api.github.com"
+
+// prClient queries forge APIs for tk prs.
+var prClient = &http.Client{Timeout: 10 * time.Second}
+
+const (
+	// prFetchWorkers is how many pull request states tk prs fetches at once.
+	prFetchWorkers = 8
+	// prFetchTimeout bounds the total time tk prs spends fetching states.
+	prFetchTimeout = 30 * time.Second
+	// defaultGitLabHost is the only host GITLAB_TOKEN is sent to unless
+	// GITLAB_HOST names another.
+	defaultGitLabHost = "gitlab.com"
+)
+
+// prState is the fetched state of a pull request, or why it is unknown.
+type prState struct {
+	state string
+	err   error
+}
+
+var prsFlags struct {
+	open bool
+}
+
+// pullRequest identifies a GitHub pull request or GitLab merge request.
+type pullRequest struct {
+	// apiURL is the REST API endpoint describing the pull request.
+	apiURL string
+	gitlab bool
+}
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Link tickets to pull requests",
+	Long: `Link tickets to GitHub pull requests or GitLab merge requests by URL. Linked
+URLs are stored in the prs frontmatter list; tk prs shows their state.`,
+}
+
+var prAddCmd = &cobra.Command{
+	Use:   "add <id> <url>",
+	Short: "Link a pull request to a ticket",
+	Long: `Link a pull request to a ticket. Accepted URLs:
+  https://github.com/<owner>/<repo>/pull/<n>
+  https://<gitlab-host>/<group>/<project>/-/merge_requests/<n>`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgResolveFailed, nil, err)
+		}
+		link := strings.TrimSuffix(args[1], "/")
+		if _, err := parsePRURL(link); err != nil {
+			return err
+		}
+		if slices.Contains(ticket.PRs, link) {
+			return trError(msgPRAlreadyLinked, map[string]any{"URL": link, "ID": ticket.ID}, nil)
+		}
+
+		ticket.PRs = append(ticket.PRs, link)
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+		fmt.Println(tr(msgPRLinked, map[string]any{"URL": link, "ID": ticket.ID}))
+		return nil
+	},
+}
+
+var prRemoveCmd = &cobra.Command{
+	Use:     "remove <id> <url>",
+	Aliases: []string{"rm"},
+	Short:   "Unlink a pull request from a ticket",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgResolveFailed, nil, err)
+		}
+		link := strings.TrimSuffix(args[1], "/")
+		prs, found := removeFromSlice(ticket.PRs, link)
+		if !found {
+			return trError(msgPRNotLinked, map[string]any{"URL": link, "ID": ticket.ID}, nil)
+		}
+
+		ticket.PRs = prs
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+		fmt.Println(tr(msgPRUnlinked, map[string]any{"URL": link, "ID": ticket.ID}))
+		return nil
+	},
+}
+
+var prsCmd = &cobra.Command{
+	Use:   "prs",
+	Short: "Show the state of linked pull requests",
+	Long: `List tickets with linked pull requests and each one's state (open, draft,
+closed or merged) as reported by the forge API. Tickets that are still open
+although all their pull requests merged are flagged, since they are probably
+done.
+
+Set GITHUB_TOKEN (or GH_TOKEN) and GITLAB_TOKEN for private repositories and
+higher rate limits. GITLAB_TOKEN is only sent over https to gitlab.com, or to
+the host in GITLAB_HOST for a self-managed instance. States are fetched eight
+at a time, and the whole lookup gives up after 30 seconds.
+
+Examples:
+  tk prs          # All tickets with pull requests
+  tk prs --open   # Only tickets that are not closed`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+
+		var linked []*domain.Ticket
+		for _, t := range tickets {
+			if len(t.PRs) > 0 && (!prsFlags.open || t.Status != domain.StatusClosed) {
+				linked = append(linked, t)
+			}
+		}
+		if len(linked) == 0 {
+			fmt.Println(tr(msgPRsNone, nil))
+			return nil
+		}
+		sortTickets(linked, SortOptions{})
+
+		var links []string
+		for _, t := range linked {
+			links = append(links, t.PRs...)
+		}
+		states := fetchPRStates(links)
+
+		var buf strings.Builder
+		stale := 0
+		for _, t := range linked {
+			buf.WriteString(formatTicketLine(t) + "\n")
+			merged := 0
+			for _, link := range t.PRs {
+				state := states[link]
+				if state.err != nil {
+					fmt.Fprintf(&buf, "  %-7s %s (%v)\n", "unknown", link, state.err)
+					continue
+				}
+				if state.state == PRStateMerged {
+					merged++
+				}
+				fmt.Fprintf(&buf, "  %-7s %s\n", state.state, link)
+			}
+			if merged == len(t.PRs) && t.Status != domain.StatusClosed {
+				buf.WriteString("  " + tr(msgPRsStillOpen, map[string]any{"Status": t.Status}) + "\n")
+				stale++
+			}
+		}
+		if stale > 0 {
+			fmt.Fprintf(&buf, "\n%s\n", tr(msgPRsStale, map[string]any{"Count": stale}))
+		}
+
+		return runWithPager(func(w io.Writer) error {
+			_, err := io.WriteString(w, buf.String())
+			return err
+		})
+	},
+}
+
+// parsePRURL recognizes GitHub pull request and GitLab merge request URLs.
+func parsePRURL(link string) (pullRequest, error) {
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return pullRequest{}, trError(msgPRInvalidURL, map[string]any{"URL": strconv.Quote(link)}, nil)
+	}
+	path := strings.Trim(u.Path, "/")
+
+	if project, number, ok := strings.Cut(path, "/-/merge_requests/"); ok && isPRNumber(number) && project != "" {
+		api := fmt.Sprintf("%s://%s/api/v4/projects/%s/merge_requests/%s", u.Scheme, u.Host, url.PathEscape(project), number)
+		return pullRequest{apiURL: api, gitlab: true}, nil
+	}
+
+	parts := strings.Split(path, "/")
+	if u.Host == "github.com" && len(parts) == 4 && parts[2] == "pull" && isPRNumber(parts[3]) {
+		api := fmt.Sprintf("%s/repos/%s/%s/pulls/%s", githubAPIURL, parts[0], parts[1], parts[3])
+		return pullRequest{apiURL: api}, nil
+	}
+
+	return pullRequest{}, trError(msgPRUnknownForge, map[string]any{"URL": strconv.Quote(link)}, nil)
+}
+
+func isPRNumber(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n > 0
+}
+
+// fetchPRStates fetches the state of each distinct link, prFetchWorkers at
+// a time and within prFetchTimeout overall.
+func fetchPRStates(links []string) map[string]prState {
+	ctx, cancel := context.WithTimeout(context.Background(), prFetchTimeout)
+	defer cancel()
+
+	var distinct []string
+	for _, link := range links {
+		if !slices.Contains(distinct, link) {
+			distinct = append(distinct, link)
+		}
+	}
+
+	// Each worker writes only its own slot, so no locking is needed.
+	results := make([]prState, len(distinct))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, prFetchWorkers)
+	for i, link := range distinct {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			state, err := fetchPRState(ctx, link)
+			results[i] = prState{state: state, err: err}
+		}()
+	}
+	wg.Wait()
+
+	states := make(map[string]prState, len(distinct))
+	for i, link := range distinct {
+		states[link] = results[i]
+	}
+	return states
+}
+
+// fetchPRState asks the forge API for the state of the pull request at link.
+func fetchPRState(ctx context.Context, link string) (string, error) {
+	pr, err := parsePRURL(link)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pr.apiURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if pr.gitlab {
+		if token := os.Getenv("GITLAB_TOKEN"); token != "" && isGitLabTokenHost(req.URL) {
+			req.Header.Set("PRIVATE-TOKEN", token)
+		}
+	} else {
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if token := firstEnv("GITHUB_TOKEN", "GH_TOKEN"); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := prClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API returned %s", resp.Status)
+	}
+
+	var body struct {
+		State    string `json:"state"`
+		Merged   bool   `json:"merged"`
+		Draft    bool   `json:"draft"`
+		MergedAt string `json:"merged_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	switch {
+	case body.State == "merged" || body.Merged || body.MergedAt != "":
+		return PRStateMerged, nil
+	case body.State == "closed" || body.State == "locked":
+		return PRStateClosed, nil
+	case body.Draft:
+		return PRStateDraft, nil
+	default:
+		return PRStateOpen, nil
+	}
+}
+
+// isGitLabTokenHost reports whether GITLAB_TOKEN may be sent to u: only over
+// https, and only to GITLAB_HOST (gitlab.com by default), so a link to any
+// other host cannot collect the token.
+func isGitLabTokenHost(u *url.URL) bool {
+	host := cmp.Or(os.Getenv("GITLAB_HOST"), defaultGitLabHost)
+	if h, err := url.Parse(host); err == nil && h.Host != "" {
+		host = h.Host
+	}
+	return u.Scheme == "https" && strings.EqualFold(u.Host, host)
+}
+
+// firstEnv returns the first non-empty environment variable of names.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func init() {
+	prCmd.AddCommand(prAddCmd)
+	prCmd.AddCommand(prRemoveCmd)
+	prsCmd.Flags().BoolVar(&prsFlags.open, "open", false, "Only tickets that are not closed")
+	addLineFormatFlag(prsCmd)
+}
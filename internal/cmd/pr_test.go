@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PRSuite struct {
+	suite.Suite
+}
+
+func TestPRSuite(t *testing.T) {
+	suite.Run(t, new(PRSuite))
+}
+
+func (s *PRSuite) TestParsePRURL() {
+	pr, err := parsePRURL("https://github.com/acme/api/pull/42")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), pullRequest{apiURL: githubAPIURL + "/repos/acme/api/pulls/42"}, pr)
+
+	pr, err = parsePRURL("https://gitlab.example.com/acme/sub/web/-/merge_requests/7")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), pullRequest{
+		apiURL: "https://gitlab.example.com/api/v4/projects/acme%2Fsub%2Fweb/merge_requests/7",
+		gitlab: true,
+	}, pr)
+
+	for _, link := range []string{
+		"https://github.com/acme/api/issues/42",
+		"https://github.com/acme/api/pull/x",
+		"https://example.com/acme/api/pull/42",
+		"not a url",
+		"file:///acme/web/-/merge_requests/7",
+	} {
+		_, err := parsePRURL(link)
+		require.ErrorContains(s.T(), err, "invalid pull request URL", link)
+	}
+}
+
+func (s *PRSuite) TestFetchPRState() {
+	responses := map[string]string{
+		"/repos/acme/api/pulls/1":                      `{"state": "open", "draft": true}`,
+		"/repos/acme/api/pulls/2":                      `{"state": "closed", "merged": true}`,
+		"/repos/acme/api/pulls/3":                      `{"state": "closed", "merged": false}`,
+		"/api/v4/projects/acme%2Fweb/merge_requests/4": `{"state": "opened"}`,
+		"/api/v4/projects/acme%2Fweb/merge_requests/5": `{"state": "merged"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.EscapedPath()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	defer func(old string) { githubAPIURL = old }(githubAPIURL)
+	githubAPIURL = server.URL
+
+	for link, want := range map[string]string{
+		"https://github.com/acme/api/pull/1":        PRStateDraft,
+		"https://github.com/acme/api/pull/2":        PRStateMerged,
+		"https://github.com/acme/api/pull/3":        PRStateClosed,
+		server.URL + "/acme/web/-/merge_requests/4": PRStateOpen,
+		server.URL + "/acme/web/-/merge_requests/5": PRStateMerged,
+	} {
+		state, err := fetchPRState(context.Background(), link)
+		require.NoError(s.T(), err, link)
+		require.Equal(s.T(), want, state, link)
+	}
+
+	_, err := fetchPRState(context.Background(), "https://github.com/acme/api/pull/9")
+	require.ErrorContains(s.T(), err, "404")
+}
+
+func (s *PRSuite) TestGitLabTokenOnlyToConfiguredHost() {
+	var tokens []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("PRIVATE-TOKEN"))
+		_, _ = w.Write([]byte(`{"state": "opened"}`))
+	})
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+	plainServer := httptest.NewServer(handler)
+	defer plainServer.Close()
+	defer func(old *http.Client) { prClient = old }(prClient)
+	prClient = tlsServer.Client()
+
+	s.T().Setenv("GITLAB_TOKEN", "secret")
+	link := tlsServer.URL + "/acme/web/-/merge_requests/1"
+	tlsHost := strings.TrimPrefix(tlsServer.URL, "https://")
+
+	// Not gitlab.com, so the token stays home
+	_, err := fetchPRState(context.Background(), link)
+	require.NoError(s.T(), err)
+
+	s.T().Setenv("GITLAB_HOST", tlsHost)
+	_, err = fetchPRState(context.Background(), link)
+	require.NoError(s.T(), err)
+
+	// Never over plain http, even to the configured host
+	s.T().Setenv("GITLAB_HOST", strings.TrimPrefix(plainServer.URL, "http://"))
+	_, err = fetchPRState(context.Background(), plainServer.URL+"/acme/web/-/merge_requests/1")
+	require.NoError(s.T(), err)
+
+	require.Equal(s.T(), []string{"", "secret", ""}, tokens)
+
+	s.T().Setenv("GITLAB_HOST", "")
+	require.True(s.T(), isGitLabTokenHost(&url.URL{Scheme: "https", Host: "gitlab.com"}))
+	require.False(s.T(), isGitLabTokenHost(&url.URL{Scheme: "https", Host: "gitlab.com.evil.example"}))
+}
+
+func (s *PRSuite) TestFetchPRStates() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/9") {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"state": "closed", "merged": true}`))
+	}))
+	defer server.Close()
+	defer func(old string) { githubAPIURL = old }(githubAPIURL)
+	githubAPIURL = server.URL
+
+	var links []string
+	for _, n := range []string{"1", "2", "1", "9"} {
+		links = append(links, "https://github.com/acme/api/pull/"+n)
+	}
+	states := fetchPRStates(links)
+	require.Len(s.T(), states, 3)
+	require.Equal(s.T(), PRStateMerged, states[links[0]].state)
+	require.Equal(s.T(), PRStateMerged, states[links[1]].state)
+	require.ErrorContains(s.T(), states[links[3]].err, "404")
+}
+
+func (s *PRSuite) TestFetchPRStatesManyFailures() {
+	// Unparsable links fail at once, racing the loop that starts the workers.
+	var links []string
+	for i := range 200 {
+		links = append(links, fmt.Sprintf("not-a-pr-%d", i%150))
+	}
+	states := fetchPRStates(links)
+	require.Len(s.T(), states, 150)
+	for _, state := range states {
+		require.Error(s.T(), state.err)
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/spf13/cobra"
+)
+
+// Profiling flags, for measuring performance on real ticket directories.
+var (
+	cpuProfileFlag string
+	memProfileFlag string
+)
+
+// cpuProfile is the open CPU profile file while profiling is running.
+var cpuProfile *os.File
+
+// startProfiling starts writing a CPU profile to --cpuprofile, if set.
+func startProfiling() error {
+	if cpuProfileFlag == "" {
+		return nil
+	}
+
+	file, err := os.Create(cpuProfileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to create CPU profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to start CPU profile: %w", err)
+	}
+	cpuProfile = file
+	return nil
+}
+
+// stopProfiling finishes the CPU profile and writes the heap profile to
+// --memprofile, if set. Failures are reported on stderr since the command
+// itself has already run.
+func stopProfiling() {
+	if cpuProfile != nil {
+		pprof.StopCPUProfile()
+		if err := cpuProfile.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write CPU profile: %v\n", err)
+		}
+		cpuProfile = nil
+	}
+
+	if memProfileFlag != "" {
+		if err := writeHeapProfile(memProfileFlag); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+}
+
+func writeHeapProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create memory profile: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	// Get up-to-date statistics
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		return fmt.Errorf("failed to write memory profile: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&cpuProfileFlag, "cpuprofile", "", "Write a CPU profile to file")
+	rootCmd.PersistentFlags().StringVar(&memProfileFlag, "memprofile", "", "Write a memory profile to file on exit")
+	cobra.OnFinalize(stopProfiling)
+}
@@ -1,20 +1,36 @@
 package cmd
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
 )
 
+var queryFlags struct {
+	explain bool
+}
+
 var queryCmd = &cobra.Command{
 	Use:   "query [jq-filter]",
 	Short: "Output tickets as JSON, optionally filtered with jq",
 	Long: `Output all tickets as a JSON array. If a jq filter is provided,
 the output will be piped through jq with that filter.
 
+Unbalanced brackets and unterminated strings in the filter are reported with
+their position before jq runs. --explain prints the filter's top-level pipeline
+stages and how many results the filter yields up to each stage instead.
+
 Examples:
   tk query                                    # All tickets as JSON
   tk query '.[] | .ID'                        # List all ticket IDs
@@ -24,22 +40,47 @@ Examples:
   tk query '[.[] | select(.Tags | index("urgent"))]'  # Tagged "urgent"
   tk query '[.[] | select(.Deps | length > 0)]'       # Tickets with deps
   tk query '.[] | {id: .ID, title: .Title}'   # Custom output format
+  tk query --closed-after 2w '.[] | .ID'      # Tickets closed in the last 2 weeks
+  tk query --all '.[] | .ID'                  # Include archived tickets
+  tk query --explain '.[] | select(.Status=="open") | .ID'  # Results per stage
+  tk query '[.[] | select(.Ready and .Priority==0 and .Type=="bug")]'  # Ready P0 bugs
+  tk query '.[] | select(.Blocked) | {ID, BlockedBy}'  # What blocks each ticket
+
+JSON fields: ID, Status, Type, Priority, Estimate, Assignee, Parent, ExternalRef,
+             Tags, Deps, Links, Watchers, PRs, Created, ClosedAt, Resolution,
+             Due, UpdatedAt, LastUpdatedBy, Revision, Locked, LockReason,
+             LeaseUntil, LeaseHolder, Reviews, Title, Description, Sections,
+             Design, Acceptance, Notes
 
-JSON fields: ID, Status, Type, Priority, Assignee, Parent, ExternalRef,
-             Tags, Deps, Links, Created, Title, Description, Design,
-             Acceptance, Notes`,
+Computed fields, over all tickets rather than only the filtered ones (see tk
+export --help): Ready, Blocked, BlockedBy, Blocks, Children, Depth,
+EstimateTotal, EstimateRemaining`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		tickets, err := store.List()
+		if len(args) > 0 {
+			if err := checkFilter(args[0]); err != nil {
+				return err
+			}
+		} else if queryFlags.explain {
+			return trError(msgQueryExplainFilter, nil, nil)
+		}
+
+		tickets, err := listScopedTickets()
 		if err != nil {
 			return err
 		}
+		loaded := len(tickets)
+		matched := filterTickets(tickets, listFlags)
 
-		jsonData, err := json.Marshal(tickets)
+		jsonData, err := json.Marshal(queryTickets(tickets, matched))
 		if err != nil {
 			return fmt.Errorf("failed to marshal tickets: %w", err)
 		}
 
+		if queryFlags.explain {
+			return explainQuery(os.Stdout, args[0], jsonData, loaded, len(matched))
+		}
+
 		// If no jq filter, just output JSON
 		if len(args) == 0 {
 			fmt.Println(string(jsonData))
@@ -48,9 +89,10 @@ JSON fields: ID, Status, Type, Priority, Assignee, Parent, ExternalRef,
 
 		// Pipe through jq
 		jqFilter := args[0]
+		var stderr bytes.Buffer
 		jqCmd := exec.Command("jq", jqFilter)
 		jqCmd.Stdout = os.Stdout
-		jqCmd.Stderr = os.Stderr
+		jqCmd.Stderr = &stderr
 
 		// Create a pipe for stdin
 		stdin, err := jqCmd.StdinPipe()
@@ -66,12 +108,228 @@ JSON fields: ID, Status, Type, Priority, Assignee, Parent, ExternalRef,
 
 		if _, err := stdin.Write(jsonData); err != nil {
 			_ = stdin.Close()
+			// jq exits without reading its input when the filter does not compile
+			if waitErr := jqCmd.Wait(); waitErr != nil {
+				return jqError(waitErr, stderr.String())
+			}
 			return fmt.Errorf("failed to write to jq: %w", err)
 		}
 		if err := stdin.Close(); err != nil {
 			return fmt.Errorf("failed to close stdin: %w", err)
 		}
 
-		return jqCmd.Wait()
+		if err := jqCmd.Wait(); err != nil {
+			return jqError(err, stderr.String())
+		}
+		// Pass on debug and stderr output of a successful filter
+		_, _ = os.Stderr.Write(stderr.Bytes())
+		return nil
 	},
 }
+
+// jqCompileErrorExit is the exit status of jq for a filter that does not compile.
+const jqCompileErrorExit = 3
+
+// filterSyntaxError reports a malformed jq filter at a byte offset.
+type filterSyntaxError struct {
+	filter string
+	pos    int
+	msg    string
+}
+
+func (e *filterSyntaxError) Error() string {
+	lineStart := strings.LastIndex(e.filter[:e.pos], "\n") + 1
+	lineEnd := strings.IndexByte(e.filter[e.pos:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(e.filter)
+	} else {
+		lineEnd += e.pos
+	}
+	line := strings.Count(e.filter[:e.pos], "\n") + 1
+	column := utf8.RuneCountInString(e.filter[lineStart:e.pos]) + 1
+
+	return fmt.Sprintf("invalid jq filter: %s at line %d, column %d\n  %s\n  %s^",
+		e.msg, line, column, e.filter[lineStart:lineEnd], strings.Repeat(" ", column-1))
+}
+
+// checkFilter checks that brackets and strings in a jq filter are balanced,
+// reporting the position of the first problem.
+func checkFilter(filter string) error {
+	_, err := splitFilter(filter)
+	return err
+}
+
+// splitFilter splits a jq filter into its top-level pipeline stages, leaving
+// pipes inside brackets and strings alone. It fails on unbalanced brackets
+// and unterminated strings.
+func splitFilter(filter string) ([]string, error) {
+	closers := map[byte]byte{'(': ')', '[': ']', '{': '}'}
+	// open holds the positions of unclosed brackets; interp marks the
+	// parentheses of string interpolations, which return to the string.
+	type bracket struct {
+		pos    int
+		interp bool
+	}
+	var open []bracket
+	var stages []string
+	stageStart, stringStart := 0, -1
+
+	for i := 0; i < len(filter); i++ {
+		c := filter[i]
+		if stringStart >= 0 {
+			switch c {
+			case '\\':
+				if i+1 < len(filter) && filter[i+1] == '(' {
+					open = append(open, bracket{pos: i + 1, interp: true})
+					stringStart = -1
+				}
+				i++
+			case '"':
+				stringStart = -1
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			stringStart = i
+		case '#':
+			// Comment to end of line
+			for i < len(filter) && filter[i] != '\n' {
+				i++
+			}
+		case '(', '[', '{':
+			open = append(open, bracket{pos: i})
+		case ')', ']', '}':
+			if len(open) == 0 || closers[filter[open[len(open)-1].pos]] != c {
+				return nil, &filterSyntaxError{filter, i, fmt.Sprintf("unexpected %q", c)}
+			}
+			if open[len(open)-1].interp {
+				stringStart = open[len(open)-1].pos
+			}
+			open = open[:len(open)-1]
+		case '|':
+			if len(open) == 0 && (i+1 == len(filter) || filter[i+1] != '=') {
+				stages = append(stages, strings.TrimSpace(filter[stageStart:i]))
+				stageStart = i + 1
+			}
+		}
+	}
+
+	if stringStart >= 0 {
+		return nil, &filterSyntaxError{filter, stringStart, "unterminated string"}
+	}
+	if len(open) > 0 {
+		last := open[len(open)-1]
+		return nil, &filterSyntaxError{filter, last.pos, fmt.Sprintf("unclosed %q", filter[last.pos])}
+	}
+	return append(stages, strings.TrimSpace(filter[stageStart:])), nil
+}
+
+// jqError turns a failed jq run into an error carrying jq's message.
+func jqError(err error, stderr string) error {
+	msg := jqMessage(stderr)
+	if msg == "" {
+		msg = err.Error()
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == jqCompileErrorExit {
+		return trError(msgQueryInvalidFilter, map[string]any{"Error": msg}, nil)
+	}
+	return trError(msgQueryFailed, map[string]any{"Error": msg}, nil)
+}
+
+// jqMessage strips the "jq: error" prefixes and error count trailer from
+// jq's stderr output.
+func jqMessage(stderr string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(stderr), "\n") {
+		if strings.HasPrefix(line, "jq: ") && strings.Contains(line, " compile error") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "jq: error: ")
+		line = strings.TrimPrefix(line, "jq: error ")
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	return strings.Join(lines, "\n  ")
+}
+
+// queryTickets returns the matched tickets with relationship fields computed
+// over all tickets, so dependencies filtered out still count.
+func queryTickets(all, matched []*domain.Ticket) []exportTicket {
+	keep := make(map[*domain.Ticket]bool, len(matched))
+	for _, t := range matched {
+		keep[t] = true
+	}
+	result := make([]exportTicket, 0, len(matched))
+	for _, t := range buildExportTickets(all) {
+		if keep[t.Ticket] {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// explainQuery prints how filter splits into pipeline stages and how many
+// results each cumulative stage yields on the tickets in jsonData.
+func explainQuery(w io.Writer, filter string, jsonData []byte, loaded, matched int) error {
+	if _, err := exec.LookPath("jq"); err != nil {
+		return trWrap(msgQueryExplainJQ, nil, err)
+	}
+	stages, err := splitFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, tr(msgQueryLoaded, map[string]any{"Count": loaded}))
+	fmt.Fprintln(w, tr(msgQueryFiltered, map[string]any{"Count": matched}))
+	fmt.Fprintln(w, tr(msgQueryStages, nil))
+
+	width := 0
+	for _, stage := range stages {
+		width = max(width, utf8.RuneCountInString(stage))
+	}
+	for i, stage := range stages {
+		prefix := strings.Join(stages[:i+1], " | ")
+		fmt.Fprintf(w, "  %d. %-*s  %s\n", i+1, width, stage, countStageResults(prefix, jsonData))
+	}
+	return nil
+}
+
+// countStageResults runs filter with jq and describes how many values it
+// outputs, with the length of a single array result.
+func countStageResults(filter string, jsonData []byte) string {
+	var stdout, stderr bytes.Buffer
+	jqCmd := exec.Command("jq", "-c", filter)
+	jqCmd.Stdin = bytes.NewReader(jsonData)
+	jqCmd.Stdout = &stdout
+	jqCmd.Stderr = &stderr
+	if err := jqCmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == jqCompileErrorExit {
+			// e.g. the binding in ". as $t | ..."
+			return "(incomplete on its own)"
+		}
+		return "error: " + strings.SplitN(cmp.Or(jqMessage(stderr.String()), err.Error()), "\n", 2)[0]
+	}
+
+	values := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(values) == 1 && values[0] == "" {
+		return "0 results"
+	}
+	if len(values) == 1 {
+		var array []json.RawMessage
+		if json.Unmarshal([]byte(values[0]), &array) == nil {
+			return fmt.Sprintf("1 result (array of %d)", len(array))
+		}
+		return "1 result"
+	}
+	return fmt.Sprintf("%d results", len(values))
+}
+
+func init() {
+	addDateFilterFlags(queryCmd)
+	addArchiveFlags(queryCmd)
+	queryCmd.Flags().BoolVar(&queryFlags.explain, "explain", false, "Show the filter's pipeline stages and how many results each yields")
+}
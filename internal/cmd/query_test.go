@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type QuerySuite struct {
+	suite.Suite
+}
+
+func TestQuerySuite(t *testing.T) {
+	suite.Run(t, new(QuerySuite))
+}
+
+func (s *QuerySuite) TestSplitFilter() {
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{".", []string{"."}},
+		{".[] | .ID", []string{".[]", ".ID"}},
+		{"[.[] | select(.Tags | index(\"a|b\"))] | length", []string{"[.[] | select(.Tags | index(\"a|b\"))]", "length"}},
+		{".[] | .Title |= ascii_upcase", []string{".[]", ".Title |= ascii_upcase"}},
+		{`"\(.ID | ascii_upcase)" | length`, []string{`"\(.ID | ascii_upcase)"`, "length"}},
+		{"{a: .ID} # pick | id\n| .a", []string{"{a: .ID} # pick | id", ".a"}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.filter, func() {
+			stages, err := splitFilter(tt.filter)
+			require.NoError(s.T(), err)
+			require.Equal(s.T(), tt.want, stages)
+		})
+	}
+}
+
+func (s *QuerySuite) TestCheckFilterReportsPosition() {
+	tests := []struct {
+		filter  string
+		wantErr string
+	}{
+		{".[] | select(.ID", "unclosed '(' at line 1, column 13\n  .[] | select(.ID\n              ^"},
+		{".[] | .ID)", "unexpected ')' at line 1, column 10"},
+		{"[.[] | .ID}", "unexpected '}' at line 1, column 11"},
+		{".[]\n| select(.Title == \"open)", "unterminated string at line 2, column 20\n  | select(.Title == \"open)\n                     ^"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.filter, func() {
+			err := checkFilter(tt.filter)
+			require.ErrorContains(s.T(), err, "invalid jq filter: "+tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// Effort report groupings.
+const (
+	GroupByTag      = "tag"
+	GroupByAssignee = "assignee"
+)
+
+// EffortGroup sums the estimates of the tickets in one tag or assignee group.
+type EffortGroup struct {
+	Name      string  `json:"name"`
+	Tickets   int     `json:"tickets"`
+	Estimate  float64 `json:"estimate"`
+	Done      float64 `json:"done"`
+	Remaining float64 `json:"remaining"`
+}
+
+// EffortReport is the result of tk report effort. Total counts each ticket
+// once, so with tag grouping it can be less than the sum of the groups.
+type EffortReport struct {
+	GroupBy string        `json:"group_by"`
+	Groups  []EffortGroup `json:"groups"`
+	Total   EffortGroup   `json:"total"`
+}
+
+var reportEffortFlags struct {
+	groupBy string
+	since   time.Time
+	until   time.Time
+	json    bool
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Aggregate reports over tickets",
+}
+
+var reportEffortCmd = &cobra.Command{
+	Use:   "effort",
+	Short: "Sum estimates per tag or assignee",
+	Long: `Sum ticket estimates per tag or assignee, for invoicing and capacity planning.
+
+For each group, ESTIMATE is the total of the tickets' own estimates, DONE the
+part on closed tickets and REMAINING the part still open or in progress.
+--since and --until restrict closed tickets (including archived ones) to those
+closed in that window; open work always counts. A ticket with several tags
+counts toward each of them. A parent's own estimate is left out when its
+children are reported, as in the estimate rollup, so epics are not counted
+twice.
+
+Examples:
+  tk report effort --group-by tag --since 2026-01-01 --until 2026-02-01
+  tk report effort --group-by assignee -T billing --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listFlags.Validate(); err != nil {
+			return err
+		}
+		if reportEffortFlags.groupBy != GroupByTag && reportEffortFlags.groupBy != GroupByAssignee {
+			return trError(msgReportInvalidGroupBy, map[string]any{"Value": strconv.Quote(reportEffortFlags.groupBy), "Tag": GroupByTag, "Assignee": GroupByAssignee}, nil)
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+		archived, err := store.Archive().List()
+		if err != nil {
+			return err
+		}
+
+		var matched []*domain.Ticket
+		for _, t := range append(tickets, archived...) {
+			if listFlags.Matches(t) {
+				matched = append(matched, t)
+			}
+		}
+
+		report := computeEffort(matched, reportEffortFlags.groupBy, reportEffortFlags.since, reportEffortFlags.until)
+		if reportEffortFlags.json {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal report: %w", err)
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return err
+		}
+
+		return runWithPager(func(w io.Writer) error {
+			return outputEffortText(w, report)
+		})
+	},
+}
+
+// computeEffort groups tickets by tag or assignee and sums their estimates.
+// Closed tickets count only if closed in [since, until); zero bounds are open.
+// As in the estimate rollup, a parent's own estimate is left out when its
+// children are among tickets, so epics are not counted twice.
+func computeEffort(tickets []*domain.Ticket, groupBy string, since, until time.Time) EffortReport {
+	report := EffortReport{GroupBy: groupBy, Total: EffortGroup{Name: "total"}}
+	groups := make(map[string]*EffortGroup)
+	rollups := rollupEstimates(tickets)
+
+	for _, t := range tickets {
+		if t.Status == domain.StatusClosed && !closedWithin(t, since, until) {
+			continue
+		}
+		estimate := t.Estimate
+		if _, ok := rollups[t.ID]; ok {
+			estimate = 0
+		}
+
+		names := t.Tags
+		if groupBy == GroupByAssignee {
+			names = []string{cmp.Or(t.Assignee, "unassigned")}
+		} else if len(names) == 0 {
+			names = []string{"untagged"}
+		}
+
+		addEffort(&report.Total, t, estimate)
+		for _, name := range names {
+			g, ok := groups[name]
+			if !ok {
+				g = &EffortGroup{Name: name}
+				groups[name] = g
+			}
+			addEffort(g, t, estimate)
+		}
+	}
+
+	for _, g := range groups {
+		report.Groups = append(report.Groups, *g)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		return report.Groups[i].Name < report.Groups[j].Name
+	})
+	return report
+}
+
+// closedWithin reports whether t was closed in [since, until). Without
+// bounds every closed ticket qualifies, even one lacking a closed-at time.
+func closedWithin(t *domain.Ticket, since, until time.Time) bool {
+	if since.IsZero() && until.IsZero() {
+		return true
+	}
+	if t.ClosedAt.IsZero() {
+		return false
+	}
+	return !t.ClosedAt.Before(since) && (until.IsZero() || t.ClosedAt.Before(until))
+}
+
+func addEffort(g *EffortGroup, t *domain.Ticket, estimate float64) {
+	g.Tickets++
+	g.Estimate += estimate
+	if t.Status == domain.StatusClosed {
+		g.Done += estimate
+	} else {
+		g.Remaining += estimate
+	}
+}
+
+func outputEffortText(w io.Writer, report EffortReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintf(tw, "%s\t%s\n", strings.ToUpper(report.GroupBy), strings.ReplaceAll(tr(msgReportColumns, nil), "|", "\t")); err != nil {
+		return err
+	}
+	for _, g := range append(report.Groups, report.Total) {
+		if _, err := fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", g.Name, g.Tickets,
+			formatEstimate(g.Estimate), formatEstimate(g.Done), formatEstimate(g.Remaining)); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func init() {
+	addMatchFlags(reportEffortCmd, false)
+	reportEffortCmd.Flags().StringVar(&reportEffortFlags.groupBy, "group-by", GroupByTag, "Group by tag or assignee")
+	reportEffortCmd.Flags().Var(timeValue{&reportEffortFlags.since}, "since", "Only count tickets closed at or after time (RFC3339, YYYY-MM-DD, or relative like 2w)")
+	reportEffortCmd.Flags().Var(timeValue{&reportEffortFlags.until}, "until", "Only count tickets closed before time")
+	reportEffortCmd.Flags().BoolVar(&reportEffortFlags.json, "json", false, "Output as JSON")
+	reportCmd.AddCommand(reportEffortCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+type ReportSuite struct {
+	suite.Suite
+}
+
+func TestReportSuite(t *testing.T) {
+	suite.Run(t, new(ReportSuite))
+}
+
+func (s *ReportSuite) TestComputeEffortByAssignee() {
+	jan := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	tickets := []*domain.Ticket{
+		{ID: "a", Status: domain.StatusOpen, Assignee: "bob", Estimate: 2},
+		{ID: "b", Status: domain.StatusInProgress, Estimate: 1},
+		{ID: "c", Status: domain.StatusClosed, Assignee: "bob", Estimate: 4, ClosedAt: jan},
+		{ID: "d", Status: domain.StatusClosed, Assignee: "bob", Estimate: 8},
+	}
+
+	report := computeEffort(tickets, GroupByAssignee, time.Time{}, time.Time{})
+	require.Equal(s.T(), []EffortGroup{
+		{Name: "bob", Tickets: 3, Estimate: 14, Done: 12, Remaining: 2},
+		{Name: "unassigned", Tickets: 1, Estimate: 1, Remaining: 1},
+	}, report.Groups)
+	require.Equal(s.T(), EffortGroup{Name: "total", Tickets: 4, Estimate: 15, Done: 12, Remaining: 3}, report.Total)
+
+	// Tickets closed outside the window, or without a closed-at time, drop out
+	report = computeEffort(tickets, GroupByAssignee, jan, jan.Add(time.Hour))
+	require.Equal(s.T(), EffortGroup{Name: "total", Tickets: 3, Estimate: 7, Done: 4, Remaining: 3}, report.Total)
+	report = computeEffort(tickets, GroupByAssignee, jan.Add(time.Hour), time.Time{})
+	require.Equal(s.T(), EffortGroup{Name: "total", Tickets: 2, Estimate: 3, Remaining: 3}, report.Total)
+}
+
+func (s *ReportSuite) TestComputeEffortByTag() {
+	tickets := []*domain.Ticket{
+		{ID: "a", Status: domain.StatusOpen, Tags: []string{"api", "web"}, Estimate: 2},
+		{ID: "b", Status: domain.StatusOpen, Estimate: 1},
+	}
+
+	report := computeEffort(tickets, GroupByTag, time.Time{}, time.Time{})
+	require.Equal(s.T(), []string{"api", "untagged", "web"}, []string{report.Groups[0].Name, report.Groups[1].Name, report.Groups[2].Name})
+	require.Equal(s.T(), 3.0, report.Total.Estimate)
+}
+
+func (s *ReportSuite) TestComputeEffortSkipsParentEstimates() {
+	tickets := []*domain.Ticket{
+		{ID: "epic", Status: domain.StatusOpen, Estimate: 10},
+		{ID: "a", Status: domain.StatusOpen, Parent: "epic", Estimate: 3},
+		{ID: "b", Status: domain.StatusClosed, Parent: "epic", Estimate: 2},
+	}
+
+	report := computeEffort(tickets, GroupByAssignee, time.Time{}, time.Time{})
+	require.Equal(s.T(), EffortGroup{Name: "total", Tickets: 3, Estimate: 5, Done: 2, Remaining: 3}, report.Total)
+
+	// Without its children the epic counts with its own estimate
+	report = computeEffort(tickets[:1], GroupByAssignee, time.Time{}, time.Time{})
+	require.Equal(s.T(), 10.0, report.Total.Estimate)
+}
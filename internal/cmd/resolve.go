@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// Conflict strategies accepted by tk import --strategy, named like git's.
+const (
+	StrategyOurs   = "ours"
+	StrategyTheirs = "theirs"
+	StrategyNewest = "newest"
+)
+
+// strategyPreferences maps each --strategy to the --prefer it stands for.
+var strategyPreferences = map[string]string{
+	StrategyOurs:   PreferLocal,
+	StrategyTheirs: PreferRemote,
+	StrategyNewest: PreferNewest,
+}
+
+// errMergeAborted is returned when conflicts are left unresolved on purpose.
+var errMergeAborted = errors.New("merge aborted")
+
+// defaultTerminalWidth is used for the resolver panes when COLUMNS is unset.
+const defaultTerminalWidth = 80
+
+// fieldConflict is a ticket field set to different values locally and in an
+// import. Empty local fields are filled without asking, so they never conflict.
+type fieldConflict struct {
+	ID          string
+	Field       string
+	Local       reflect.Value
+	Remote      reflect.Value
+	RemoteNewer bool
+}
+
+// resolveFunc returns the value a conflicting field takes.
+type resolveFunc func(c fieldConflict) (reflect.Value, error)
+
+// preferResolver resolves every conflict following prefer.
+func preferResolver(prefer string) resolveFunc {
+	return func(c fieldConflict) (reflect.Value, error) {
+		if prefer == PreferRemote || (prefer == PreferNewest && c.RemoteNewer) {
+			return c.Remote, nil
+		}
+		return c.Local, nil
+	}
+}
+
+// terminalResolver asks how to resolve each conflict, showing the local,
+// remote and resulting value side by side.
+type terminalResolver struct {
+	in    *bufio.Reader
+	out   io.Writer
+	width int
+	// all is StrategyOurs or StrategyTheirs once chosen for every remaining conflict
+	all string
+}
+
+func newTerminalResolver(in io.Reader, out io.Writer) *terminalResolver {
+	width := defaultTerminalWidth
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		width = n
+	}
+	return &terminalResolver{in: bufio.NewReader(in), out: out, width: width}
+}
+
+// resolve shows c and returns the value chosen. The result starts as the
+// newer side's value, which Enter keeps.
+func (r *terminalResolver) resolve(c fieldConflict) (reflect.Value, error) {
+	switch r.all {
+	case StrategyOurs:
+		return c.Local, nil
+	case StrategyTheirs:
+		return c.Remote, nil
+	}
+
+	result := c.Local
+	if c.RemoteNewer {
+		result = c.Remote
+	}
+	for {
+		_, _ = fmt.Fprintf(r.out, "\n%s\n", tr(msgConflict, map[string]any{"ID": c.ID, "Field": c.Field}))
+		writePanes(r.out, r.width, conflictPaneTitles(),
+			[]string{formatFieldValue(c.Local), formatFieldValue(c.Remote), formatFieldValue(result)})
+		_, _ = fmt.Fprint(r.out, tr(msgConflictPrompt, nil))
+
+		line, err := r.in.ReadString('\n')
+		if err != nil && line == "" {
+			return reflect.Value{}, fmt.Errorf("%w: no answer for %s field %s", errMergeAborted, c.ID, c.Field)
+		}
+		switch choice := strings.TrimSpace(line); choice {
+		case "":
+			return result, nil
+		case "o":
+			return c.Local, nil
+		case "t":
+			return c.Remote, nil
+		case "O":
+			r.all = StrategyOurs
+			return c.Local, nil
+		case "T":
+			r.all = StrategyTheirs
+			return c.Remote, nil
+		case "e":
+			edited, err := editFieldValue(c.ID, c.Field, result)
+			if err != nil {
+				_, _ = fmt.Fprintln(r.out, tr(msgErrorPrefix, nil), err)
+				continue
+			}
+			result = edited
+		case "q":
+			return reflect.Value{}, fmt.Errorf("%w at %s field %s", errMergeAborted, c.ID, c.Field)
+		default:
+			_, _ = fmt.Fprintln(r.out, tr(msgUnknownChoice, map[string]any{"Choice": strconv.Quote(choice)}))
+		}
+	}
+}
+
+// conflictPaneTitles returns the local, remote and result pane titles in the
+// output language, or in English if a translation lacks one.
+func conflictPaneTitles() []string {
+	titles := strings.Split(tr(msgConflictPanes, nil), "|")
+	if len(titles) != 3 {
+		titles = strings.Split(msgConflictPanes.Other, "|")
+	}
+	return titles
+}
+
+// formatFieldValue renders a ticket field value for a resolver pane: text
+// as is, lists comma-separated and notes, sections and reviews as JSON.
+func formatFieldValue(v reflect.Value) string {
+	var s string
+	switch {
+	case v.Type() == reflect.TypeOf(time.Time{}):
+		if t := v.Interface().(time.Time); !t.IsZero() {
+			s = t.Format(time.RFC3339)
+		}
+	case v.Kind() == reflect.String:
+		s = v.String()
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).String()
+		}
+		s = strings.Join(items, ", ")
+	case v.Kind() == reflect.Slice:
+		if v.Len() > 0 {
+			data, _ := json.MarshalIndent(v.Interface(), "", "  ")
+			s = string(data)
+		}
+	default:
+		s = fmt.Sprint(v.Interface())
+	}
+	if s == "" {
+		return "(empty)"
+	}
+	return s
+}
+
+// writePanes writes texts in columns under titles, wrapping each to an equal
+// share of width.
+func writePanes(w io.Writer, width int, titles, texts []string) {
+	const separator = " | "
+	paneWidth := max((width-len(separator)*(len(texts)-1))/len(texts), 10)
+
+	panes := make([][]string, len(texts))
+	rows := 0
+	for i, text := range texts {
+		panes[i] = wrapPane(text, paneWidth)
+		rows = max(rows, len(panes[i]))
+	}
+
+	row := func(cells func(i int) string) {
+		var line strings.Builder
+		for i := range panes {
+			cell := cells(i)
+			if i > 0 {
+				line.WriteString(separator)
+			}
+			line.WriteString(cell)
+			if i < len(panes)-1 {
+				line.WriteString(strings.Repeat(" ", paneWidth-utf8.RuneCountInString(cell)))
+			}
+		}
+		_, _ = fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+	}
+	row(func(i int) string { return titles[i] })
+	row(func(int) string { return strings.Repeat("-", paneWidth) })
+	for r := range rows {
+		row(func(i int) string {
+			if r < len(panes[i]) {
+				return panes[i][r]
+			}
+			return ""
+		})
+	}
+}
+
+// wrapPane splits text into lines of at most width runes.
+func wrapPane(text string, width int) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		runes := []rune(line)
+		for len(runes) > width {
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
+
+// editFieldValue opens v, the value of field on ticket id, in $EDITOR, as
+// plain text for text fields and as JSON otherwise, and returns the edited
+// value after the checks create and update apply to it.
+func editFieldValue(id, field string, v reflect.Value) (reflect.Value, error) {
+	text := v.String()
+	if v.Kind() != reflect.String {
+		data, err := json.MarshalIndent(v.Interface(), "", "  ")
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("failed to encode %s: %w", field, err)
+		}
+		text = string(data)
+	}
+
+	data, err := editInTempFile("tk-resolve-*.txt", text+"\n")
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	edited := reflect.New(v.Type()).Elem()
+	if v.Kind() != reflect.String {
+		if err := json.Unmarshal(data, edited.Addr().Interface()); err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid %s: %w", field, err)
+		}
+		if err := validateEditedField(id, field, edited); err != nil {
+			return reflect.Value{}, err
+		}
+		return edited, nil
+	}
+
+	value := strings.TrimSuffix(string(data), "\n")
+	switch field {
+	case "Status":
+		status, err := domain.ParseStatus(strings.TrimSpace(value))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value = string(status)
+	case "Type":
+		t, err := domain.ParseType(strings.TrimSpace(value))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value = string(t)
+	case "Resolution":
+		if value = strings.TrimSpace(value); value != "" {
+			r, err := domain.ParseResolution(value)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value = string(r)
+		}
+	}
+	edited.SetString(value)
+	return edited, nil
+}
+
+// validateEditedField checks an edited non-text field of ticket id, resolving
+// partial dependency IDs in place.
+func validateEditedField(id, field string, v reflect.Value) error {
+	switch field {
+	case "Priority":
+		if p := int(v.Int()); p < domain.MinPriority || p > domain.MaxPriority {
+			return trError(msgInvalidPriority, map[string]any{"Priority": p, "Min": domain.MinPriority, "Max": domain.MaxPriority}, nil)
+		}
+	case "Estimate":
+		return validateEstimate(v.Float())
+	case "Deps":
+		deps := v.Interface().([]string)
+		for i, dep := range deps {
+			resolved, err := resolveID(store, dep)
+			if err != nil {
+				return fmt.Errorf("invalid dependency: %w", err)
+			}
+			if resolved == id {
+				return fmt.Errorf("ticket cannot depend on itself")
+			}
+			deps[i] = resolved
+		}
+		return checkCycle(id, deps...)
+	case "Reviews":
+		for _, r := range v.Interface().([]domain.Review) {
+			switch r.Decision {
+			case domain.ReviewPending, domain.ReviewApproved, domain.ReviewRejected:
+			default:
+				return fmt.Errorf("invalid review decision: %q", r.Decision)
+			}
+		}
+	}
+	return nil
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
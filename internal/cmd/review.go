@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Request and record ticket reviews",
+	Long: `Ask reviewers to sign off on a ticket and record their decisions in the
+ticket's reviews frontmatter. Tickets with pending reviews are listed by
+tk list --needs-review.
+
+Examples:
+  tk review request abc1 @jane @bob
+  tk review approve abc1 looks good
+  tk review reject abc1 missing migration notes`,
+}
+
+var reviewRequestCmd = &cobra.Command{
+	Use:   "request <id> <@user> [@user...]",
+	Short: "Request a review from one or more users",
+	Long: `Add each user as a pending reviewer. Requesting a review from someone who
+already decided resets their review to pending, e.g. after addressing a rejection.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgResolveFailed, nil, err)
+		}
+
+		now := time.Now().UTC()
+		var reviewers []string
+		for _, arg := range args[1:] {
+			user, err := resolveMe(arg)
+			if err != nil {
+				return err
+			}
+			user = strings.TrimPrefix(strings.TrimSpace(user), "@")
+			if user == "" {
+				return trError(msgInvalidReviewer, map[string]any{"Reviewer": strconv.Quote(arg)}, nil)
+			}
+
+			pending := domain.Review{Reviewer: user, Decision: domain.ReviewPending, At: now}
+			if review := ticket.ReviewBy(user); review != nil {
+				*review = pending
+			} else {
+				ticket.Reviews = append(ticket.Reviews, pending)
+			}
+			reviewers = append(reviewers, user)
+		}
+
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+
+		fmt.Println(tr(msgReviewRequested, map[string]any{"ID": ticket.ID, "Reviewers": strings.Join(reviewers, ", ")}))
+		return nil
+	},
+}
+
+var reviewApproveCmd = &cobra.Command{
+	Use:   "approve <id> [comment]",
+	Short: "Approve a ticket as the current user",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return recordReview(args, domain.ReviewApproved)
+	},
+}
+
+var reviewRejectCmd = &cobra.Command{
+	Use:   "reject <id> [comment]",
+	Short: "Reject a ticket as the current user",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return recordReview(args, domain.ReviewRejected)
+	},
+}
+
+// recordReview records the current user's decision on the ticket in args[0],
+// with the remaining args as an optional comment. Reviewing without having
+// been asked adds the current user as a reviewer.
+func recordReview(args []string, decision domain.ReviewDecision) error {
+	user := currentUser()
+	if user == "" {
+		return trError(msgNoCurrentUser, nil, nil)
+	}
+
+	ticket, err := resolveAndReadTicket(args[0])
+	if err != nil {
+		return trWrap(msgResolveFailed, nil, err)
+	}
+
+	review := ticket.ReviewBy(user)
+	if review == nil {
+		ticket.Reviews = append(ticket.Reviews, domain.Review{Reviewer: user})
+		review = &ticket.Reviews[len(ticket.Reviews)-1]
+	}
+	review.Decision = decision
+	review.Comment = strings.TrimSpace(strings.Join(args[1:], " "))
+	review.At = time.Now().UTC()
+
+	if err := store.Write(ticket); err != nil {
+		return err
+	}
+
+	msg := msgReviewApproved
+	if decision == domain.ReviewRejected {
+		msg = msgReviewRejected
+	}
+	fmt.Println(tr(msg, map[string]any{"ID": ticket.ID}))
+	return nil
+}
+
+func init() {
+	reviewCmd.AddCommand(reviewRequestCmd)
+	reviewCmd.AddCommand(reviewApproveCmd)
+	reviewCmd.AddCommand(reviewRejectCmd)
+}
@@ -19,6 +19,15 @@ var (
 	store   *storage.Storage
 )
 
+// forceFlag overrides per-command safety checks such as WIP limits and policies.
+var forceFlag bool
+
+// overwriteFlag overrides the concurrent modification check.
+var overwriteFlag bool
+
+// asFlag overrides the current user's identity for one invocation.
+var asFlag string
+
 var rootCmd = &cobra.Command{
 	Use:   "tk",
 	Short: "A ticket management CLI",
@@ -27,6 +36,10 @@ var rootCmd = &cobra.Command{
 		printHelp()
 	},
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := startProfiling(); err != nil {
+			return err
+		}
+
 		var err error
 		cfg, err = config.Load()
 		if err != nil {
@@ -37,19 +50,56 @@ var rootCmd = &cobra.Command{
 			Level: slog.LevelInfo,
 		}))
 
+		userName = asFlag
+		if userName == "" {
+			userName = cfg.User
+		}
+		if userName == "" {
+			userName = getGitUserName()
+		}
+		if err := resolveMeFlags(); err != nil {
+			return err
+		}
+
+		if cfg.HealthWarnings {
+			warnStoreHealth(cfg)
+		}
+
 		store = storage.New(cfg.TicketsDir)
+		store.SetActor(currentUser())
+		store.SetOverwrite(overwriteFlag)
+		statusSince = nil
+		if showDiffFlag || cfg.NotifyCommand != "" {
+			store.SetOnChange(onTicketChange)
+		}
 
-		return nil
+		if err := setStatusSymbols(cfg.SymbolSet, cfg.StatusSymbols); err != nil {
+			return err
+		}
+		setASCIIOutput(asciiFlag || cfg.ASCII)
+		if err := setDisplayTime(cfg.Timezone, cfg.DateFormat); err != nil {
+			return err
+		}
+		if err := setLanguage(cmd.Root(), cfg.TicketsDir, cfg.Language); err != nil {
+			return err
+		}
+
+		lineFormat := cfg.LineFormat
+		if lineFormatFlag != "" {
+			lineFormat = lineFormatFlag
+		}
+		return setLineFormat(lineFormat)
 	},
 }
 
 // Execute runs the root command.
 func Execute() error {
+	removeOldBinary()
 	return rootCmd.Execute()
 }
 
-func printHelp() {
-	helpText := `tk - minimal ticket system with dependency tracking
+// helpText is the English output of tk and tk --help.
+const helpText = `tk - minimal ticket system with dependency tracking
 
 Usage:
   tk [command]
@@ -61,76 +111,191 @@ Available Commands:
     --acceptance           Acceptance criteria
     -t, --type             Type (bug|feature|task|epic|chore) [default: task]
     -p, --priority         Priority %d-%d, %d=highest [default: %d]
-    -a, --assignee         Assignee
+    -e, --estimate         Estimate in points (summed onto parents)
+    --due                  Due date (YYYY-MM-DD, or from now like 2w)
+    -a, --assignee         Assignee [default: you] (accepts @me)
     --external-ref         External reference (e.g., gh-123, JIRA-456)
     --parent               Parent ticket ID
     --tags                 Comma-separated tags (e.g., --tags ui,backend,urgent)
+  ensure --external-ref X  Create or update the ticket with ref X; print its ID
+    --title, --status      Fields to set, plus the flags of create (only those passed)
   show <id>                Display a ticket
+    --archived, --all      Look up archived tickets (also on query and search)
+    --section              Print only one body section (e.g. "Test Plan")
   edit <id>                Open ticket in editor
-  start <id>               Set ticket status to in_progress
+    --section              Edit only one body section (added if missing)
+  start <id>               Set ticket status to in_progress (enforces WIP limits; alias: claim)
+    --lease                Let the claim expire after duration unless renewed (e.g. 30m)
+  leases                   List leased claims and when they expire
   close <id>               Set ticket status to closed
+    --reason               Resolution (done|wontfix|duplicate)
+    --comment              Note explaining the resolution
   reopen <id>              Set ticket status to open
   status <id> <status>     Update ticket status (open|in_progress|closed)
+  lock <id>                Refuse all changes to a ticket until unlocked
+    -r, --reason           Why the ticket is locked
+  unlock <id>              Allow changes to a locked ticket again
+  estimate <id> <points>   Set a ticket's estimate (0 clears it)
+  due <id> [date]          Set a ticket's due date (none clears it)
+  review <action> <id>     Request and record reviews (stored in frontmatter)
+    request <id> <@user..> Ask users to review (resets earlier decisions)
+    approve <id> [comment] Approve as the current user
+    reject <id> [comment]  Reject as the current user
   list                     List tickets (alias: ls)
     --status               Filter by status (open|in_progress|closed)
     -t, --type             Filter by type (task|bug|feature|epic|chore)
-    -a, --assignee         Filter by assignee
+    -a, --assignee         Filter by assignee (accepts @me)
     -T, --tag              Filter by tag
-    -s, --sort             Sort by field (priority|created|status|title)
+    --tag-mode             Match all tags or any tag (all|any) [default: all]
+    --not-status           Exclude tickets with status
+    --not-assignee         Exclude tickets assigned to assignee
+    --no-assignee          Only show unassigned tickets (alias: --unassigned)
+    --untagged             Only show tickets without tags
+    --not-tag              Exclude tickets with tag
+    --needs-review         Only show tickets with pending reviews
+    --created-after        Created at or after time (RFC3339, YYYY-MM-DD, or 2w)
+    --created-before       Created before time
+    --closed-after         Closed at or after time
+    --closed-before        Closed before time
+    -s, --sort             Sort by field (priority|created|age|status|title|in-status)
     -r, --reverse          Reverse sort order
+    --line-format          Go template for each line (also on search)
+    --count                Print only the number of matches (also on search)
   ready                    List open/in_progress tickets with resolved deps
-    -t, --type             Filter by type (task|bug|feature|epic|chore)
-    -a, --assignee         Filter by assignee
-    -T, --tag              Filter by tag
-    -s, --sort             Sort by field (priority|created|status|title)
-    -r, --reverse          Reverse sort order
+    --limit                Show at most N tickets (ties broken by ID)
+    --claimable            Only open tickets and ones with an expired lease
+    (accepts the same filter and sort flags as list)
   blocked                  List open/in_progress tickets with unresolved deps
-    -t, --type             Filter by type (task|bug|feature|epic|chore)
-    -a, --assignee         Filter by assignee
-    -T, --tag              Filter by tag
-    -s, --sort             Sort by field (priority|created|status|title)
-    -r, --reverse          Reverse sort order
+    (accepts the same filter and sort flags as list)
+  unblocked                List tickets whose last blocking dep closed recently
+    --since                Window as duration or time [default: 24h]
+    --since-last           Since your previous --since-last run
+    (accepts the same filter and sort flags as list, except --status)
   closed                   List recently closed tickets
     --limit                Limit number of results [default: 20]
-    -t, --type             Filter by type (task|bug|feature|epic|chore)
-    -a, --assignee         Filter by assignee
-    -T, --tag              Filter by tag
-    -s, --sort             Sort by field (priority|created|status|title)
-    -r, --reverse          Reverse sort order
+    --since                Only tickets closed within duration (e.g. 7d)
+    (accepts the same filter, date and sort flags as list, except --status)
+    Filter flags take comma-separated values or can be repeated
+    (e.g., --status open,in_progress or -T ui -T api)
+  mine                     List open/in_progress tickets assigned to you
+    (accepts the same filter and sort flags as list, except --status)
+  mentions                 List tickets @mentioning a user, not assigned to them
+    -u, --user             User to look for [default: you] (accepts @me)
+    (accepts the same filter and sort flags as list)
+  watchlist                Show recent activity on tickets you watch
+    --user                 Watcher [default: you] (accepts @me)
+    --since                Only activity within duration [default: 7d]
+  pick                     Pick a random open ready ticket (alias: random)
+    --round-robin          Take tickets in ID order, after the last picked
+    --claim                Start the picked ticket
+    --lease                With --claim, let the claim expire after duration
+    (accepts the same filter flags as list, except --status)
+  triage                   Step through tickets: prioritize, tag, assign, close, snooze
+    --snooze               How long z snoozes a ticket [default: 7d]
+    (accepts the same filter and sort flags as list)
   dep add <id> <dep-id>    Add dependency (id depends on dep-id)
   dep remove <id> <dep-id> Remove dependency (alias: rm)
+  dep edit <id>            Edit dependency list in $EDITOR (cycle-checked)
   dep tree [id]            Show dependency tree
     --full                 Show full tree for all tickets
   dep check                Check for dependency cycles
+  dep graph                Print the dependency graph as adjacency JSON
+    --format               Output format (json) [default: json]
   undep <id> <dep-id>      Remove dependency (alias for dep remove)
   link <id> <id> [id...]   Link tickets together (symmetric)
   unlink <id> <target-id>  Remove link between tickets
+  watch add <id> [@user]   Watch a ticket (you by default)
+  watch remove <id> [@user] Stop watching a ticket (alias: rm)
+  pr add <id> <url>        Link a GitHub PR or GitLab MR to a ticket
+  pr remove <id> <url>     Unlink a pull request (alias: rm)
+  prs                      Show linked PR states; flag open tickets with merged PRs
+    --open                 Only tickets that are not closed
   add-note <id> [text]     Append timestamped note (text or stdin)
+  reply <id> <n> [text]    Reply to note n (shown threaded in show)
+  note compact <id>        Collapse older notes into one summary note
+    --keep-last            Recent notes to keep [default: 5]
+    --attach               Save compacted notes in full under attachments/
+  touch <id> [reason]      Bump updated-at with a "Touched" note (alias: ping)
   query [jq-filter]        Output tickets as JSON, optionally filtered with jq
+    --explain              Show results per top-level pipeline stage
+    (accepts the date flags of list)
   search <query>           Search tickets by text
     --case-sensitive       Perform case-sensitive search
     --status               Filter by status (open|in_progress|closed)
+  grep <pattern>           Search raw ticket files, grep-style path:text output
+    -i, -n, -l             Ignore case, show line numbers, list files only
+  summary                  One-screen overview of counts, ready, in progress and overdue
+    (accepts the same filter flags as list, except --status)
   stats                    Display project metrics
     --json                 Output as JSON
+    --by-week              Heatmap of closures per day instead
+    --weeks                Weeks in the heatmap [default: 12]
+  forecast                 Monte Carlo P50/P85 completion dates for open tickets
+    --weeks                Weeks of throughput history [default: 12]
+    --trials               Simulation runs [default: 10000]
+    --seed                 Random seed for reproducible output
+    --json                 Output as JSON
+    (accepts the same filter flags as list, except --status)
+  report effort            Sum estimates per tag or assignee, done and remaining
+    --group-by             Group by tag or assignee [default: tag]
+    --since, --until       Only count tickets closed in this window
+    --json                 Output as JSON
+    (accepts the same filter flags as list, except --status)
   export                   Export tickets to JSON or CSV
     --format               Output format (json|csv) [default: json]
-    -o, --output           Output file (default: stdout)
+    -o, --output           Output file (default: stdout), or directory with --split-by
+    --split-by             One file per group (status|assignee|tag)
   import <file>            Import tickets from JSON file
     --skip-existing        Skip tickets that already exist
+    --merge                Merge into existing tickets field by field
+    --prefer               Conflict winner (newest|local|remote) [default: newest]
+    --strategy             Conflict winner without asking (ours|theirs|newest)
+    --mapping              YAML file translating another tracker's vocabulary
+  lint                     Check tickets against policies and for closed deps
+  schema [kind]            Print JSON Schema (ticket|frontmatter|import|stats)
+  i18n extract             Write the English message catalog for translators
+    -o, --output           Output file (default: stdout)
+    --missing              Only messages this language's catalog lacks
   bulk <action>            Bulk operations (close|reopen|start)
     --tag                  Filter by tag
     --status               Filter by status
-    -a, --assignee         Filter by assignee
+    -a, --assignee         Filter by assignee (accepts @me)
     --dry-run              Preview changes without applying
+  activity                 Show recent ticket activity, newest first
+    --since                Only events within duration or after time (e.g. 24h)
+    -a, --assignee         Only tickets assigned to assignee (accepts @me)
+    --limit                Limit number of events [default: 50]
+  history <id>             Show who changed a ticket and how, oldest first
+  diff <id>                Diff a ticket file against its last git-committed version
+  blame <id>               Show the last commit and author of each field and section
+  undo [n]                 Revert your last n mutations [default: 1]
+    --show                 Preview what would be reverted
+  gc                       Archive or delete closed tickets past retention.closed
+    --dry-run              Show what would be cleaned up
+  move <id>... --to <dir>  Move tickets into another tickets directory
   version                  Print version information
   update                   Update tk to the latest version
 
+Global Flags:
+  --force                  Override safety checks (WIP limits, policies, closed deps)
+  --overwrite              Write tickets even if they changed since read (not
+                           part of --force, so overriding a policy never
+                           discards someone else's change)
+  --as <name>              Act as this user instead of TK_USER or git user.name
+  --show-diff              Print a unified diff of every ticket file changed
+  --ascii                  Plain ASCII output with status words instead of symbols
+  --cpuprofile <file>      Write a CPU profile to file
+  --memprofile <file>      Write a memory profile to file on exit
+
 Use "tk [command] --help" for more information about a command.
 
 Tickets stored as markdown files in .tickets/
+Your identity comes from --as, TK_USER, or git user.name
 Supports partial ID matching (e.g., 'tk show 5c4' matches 'nw-5c46')
 `
-	fmt.Printf(helpText, domain.MinPriority, domain.MaxPriority, domain.MinPriority, domain.DefaultPriority)
+
+func printHelp() {
+	fmt.Printf(trFormat(helpMessage), domain.MinPriority, domain.MaxPriority, domain.MinPriority, domain.DefaultPriority)
 }
 
 // GetConfig returns the loaded configuration.
@@ -152,6 +317,7 @@ func init() {
 	// Store the default help function before overriding
 	defaultHelp := rootCmd.HelpFunc()
 	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		setHelpLanguage(cmd.Root())
 		if cmd == rootCmd {
 			printHelp()
 		} else {
@@ -159,7 +325,13 @@ func init() {
 			defaultHelp(cmd, args)
 		}
 	})
+	rootCmd.PersistentFlags().BoolVar(&forceFlag, "force", false, "Override safety checks (WIP limits, policies, closed deps); see --overwrite for changed tickets")
+	rootCmd.PersistentFlags().BoolVar(&overwriteFlag, "overwrite", false, "Write tickets even if they changed since read (kept apart from --force so it never discards a change by accident)")
+	rootCmd.PersistentFlags().StringVar(&asFlag, "as", "", "Act as this user instead of TK_USER or git user.name")
+	rootCmd.PersistentFlags().BoolVar(&showDiffFlag, "show-diff", false, "Print a unified diff of every ticket file changed")
+	rootCmd.PersistentFlags().BoolVar(&asciiFlag, "ascii", false, "Plain ASCII output with status words instead of symbols (for screen readers)")
 	rootCmd.AddCommand(createCmd)
+	rootCmd.AddCommand(ensureCmd)
 	rootCmd.AddCommand(showCmd)
 	rootCmd.AddCommand(editCmd)
 	rootCmd.AddCommand(startCmd)
@@ -169,16 +341,49 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(readyCmd)
 	rootCmd.AddCommand(blockedCmd)
+	rootCmd.AddCommand(unblockedCmd)
 	rootCmd.AddCommand(closedCmd)
+	rootCmd.AddCommand(mentionsCmd)
+	rootCmd.AddCommand(pickCmd)
+	rootCmd.AddCommand(triageCmd)
+	rootCmd.AddCommand(leasesCmd)
+	rootCmd.AddCommand(mineCmd)
 	rootCmd.AddCommand(depCmd)
 	rootCmd.AddCommand(undepCmd)
 	rootCmd.AddCommand(linkCmd)
 	rootCmd.AddCommand(unlinkCmd)
 	rootCmd.AddCommand(addNoteCmd)
+	rootCmd.AddCommand(replyCmd)
+	rootCmd.AddCommand(noteCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(watchlistCmd)
+	rootCmd.AddCommand(prCmd)
+	rootCmd.AddCommand(prsCmd)
+	rootCmd.AddCommand(i18nCmd)
+	rootCmd.AddCommand(touchCmd)
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
+	rootCmd.AddCommand(estimateCmd)
+	rootCmd.AddCommand(dueCmd)
+	rootCmd.AddCommand(reviewCmd)
 	rootCmd.AddCommand(queryCmd)
 	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(grepCmd)
 	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(summaryCmd)
+	rootCmd.AddCommand(forecastCmd)
 	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(schemaCmd)
+	rootCmd.AddCommand(lintCmd)
 	rootCmd.AddCommand(bulkCmd)
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(gcCmd)
+	rootCmd.AddCommand(moveCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(activityCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(blameCmd)
 }
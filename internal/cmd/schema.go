@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect emitted by tk schema.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema kinds accepted by tk schema.
+const (
+	SchemaTicket      = "ticket"
+	SchemaFrontmatter = "frontmatter"
+	SchemaImport      = "import"
+	SchemaStats       = "stats"
+)
+
+// schemaField describes one ticket field for schema generation.
+type schemaField struct {
+	goName      string // JSON key in query/export/import output
+	yamlName    string // frontmatter key, empty for body fields
+	schema      map[string]any
+	frontmatter bool // required in frontmatter
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [ticket|frontmatter|import|stats]",
+	Short: "Print the JSON Schema for tickets",
+	Long: `Print a JSON Schema (draft 2020-12) describing the ticket format.
+
+Kinds:
+  ticket       - a ticket object as produced by 'tk query' and 'tk export' (default)
+  frontmatter  - the YAML frontmatter of a ticket file, for editor validation
+  import       - the array accepted by 'tk import'
+  stats        - the object printed by 'tk stats --json'
+
+Examples:
+  tk schema > ticket.schema.json
+  tk schema frontmatter > .tickets/frontmatter.schema.json`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{SchemaTicket, SchemaFrontmatter, SchemaImport, SchemaStats},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind := SchemaTicket
+		if len(args) == 1 {
+			kind = args[0]
+		}
+
+		schema, err := buildSchema(kind)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal schema: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+// buildSchema returns the JSON Schema for the given kind.
+func buildSchema(kind string) (map[string]any, error) {
+	switch kind {
+	case SchemaTicket:
+		schema := ticketObjectSchema(true)
+		schema["$schema"] = jsonSchemaDraft
+		schema["title"] = "tk ticket"
+		return schema, nil
+	case SchemaFrontmatter:
+		return frontmatterSchema(), nil
+	case SchemaImport:
+		item := ticketObjectSchema(false)
+		item["required"] = []string{}
+		return map[string]any{
+			"$schema":     jsonSchemaDraft,
+			"title":       "tk import",
+			"description": "Tickets accepted by tk import. Missing IDs are generated; status defaults to open and type to task.",
+			"type":        "array",
+			"items":       item,
+		}, nil
+	case SchemaStats:
+		return statsSchema(), nil
+	default:
+		return nil, trError(msgSchemaUnknown, map[string]any{"Kind": strconv.Quote(kind), "Ticket": SchemaTicket, "Frontmatter": SchemaFrontmatter, "Import": SchemaImport, "Stats": SchemaStats}, nil)
+	}
+}
+
+// ticketFields lists the ticket fields in frontmatter and body order.
+func ticketFields() []schemaField {
+	str := func(desc string) map[string]any { return map[string]any{"type": "string", "description": desc} }
+	list := func(desc string) map[string]any {
+		return map[string]any{"type": []string{"array", "null"}, "items": map[string]any{"type": "string"}, "description": desc}
+	}
+	dateTime := func(desc string) map[string]any {
+		return map[string]any{"type": "string", "format": "date-time", "description": desc}
+	}
+
+	return []schemaField{
+		{goName: "ID", yamlName: "id", schema: str("Ticket ID, e.g. tic-a1b2"), frontmatter: true},
+		{goName: "Status", yamlName: "status", schema: map[string]any{"enum": statusStrings(domain.ValidStatuses)}, frontmatter: true},
+		{goName: "Type", yamlName: "type", schema: map[string]any{"enum": typeStrings(domain.ValidTypes)}},
+		{goName: "Priority", yamlName: "priority", schema: map[string]any{
+			"type": "integer", "minimum": domain.MinPriority, "maximum": domain.MaxPriority,
+			"description": fmt.Sprintf("%d is highest", domain.MinPriority),
+		}},
+		{goName: "Estimate", yamlName: "estimate", schema: map[string]any{
+			"type": "number", "minimum": 0, "description": "Estimate in the project's unit, e.g. points",
+		}},
+		{goName: "Assignee", yamlName: "assignee", schema: str("Assigned user")},
+		{goName: "Parent", yamlName: "parent", schema: str("Parent ticket ID")},
+		{goName: "ExternalRef", yamlName: "external-ref", schema: str("External reference, e.g. gh-123")},
+		{goName: "Tags", yamlName: "tags", schema: list("Tags")},
+		{goName: "Deps", yamlName: "deps", schema: list("IDs of tickets this one depends on")},
+		{goName: "Links", yamlName: "links", schema: list("IDs of linked tickets")},
+		{goName: "Watchers", yamlName: "watchers", schema: list("Users following the ticket")},
+		{goName: "PRs", yamlName: "prs", schema: list("URLs of linked GitHub pull requests and GitLab merge requests")},
+		{goName: "Created", yamlName: "created", schema: dateTime("Creation time"), frontmatter: true},
+		{goName: "ClosedAt", yamlName: "closed-at", schema: dateTime("Time the ticket was closed; zero when not closed")},
+		{goName: "Resolution", yamlName: "resolution", schema: map[string]any{
+			"enum":        append([]string{""}, resolutionStrings(domain.ValidResolutions)...),
+			"description": "Why a closed ticket was closed; empty when not closed or closed without one (counted as done)",
+		}},
+		{goName: "Due", yamlName: "due", schema: dateTime("Date the ticket should be closed by; zero when none")},
+		{goName: "UpdatedAt", yamlName: "updated-at", schema: dateTime("Time of the last write")},
+		{goName: "LastUpdatedBy", yamlName: "last-updated-by", schema: str("Identity of the last writer")},
+		{goName: "Revision", yamlName: "revision", schema: map[string]any{
+			"type": "integer", "minimum": 0, "description": "Incremented on every write",
+		}},
+		{goName: "Locked", yamlName: "locked", schema: map[string]any{"type": "boolean", "description": "Whether changes are refused until unlocked"}},
+		{goName: "LockReason", yamlName: "lock-reason", schema: str("Why the ticket is locked")},
+		{goName: "LeaseUntil", yamlName: "lease-until", schema: dateTime("Time the claim expires; zero when not leased")},
+		{goName: "LeaseHolder", yamlName: "lease-holder", schema: str("Identity holding the lease")},
+		{goName: "Reviews", yamlName: "reviews", schema: map[string]any{
+			"type": []string{"array", "null"},
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"reviewer": str("Requested reviewer"),
+					"decision": map[string]any{"enum": []string{"pending", "approved", "rejected"}},
+					"comment":  str("Reviewer comment"),
+					"at":       dateTime("Time the review was requested or decided"),
+				},
+				"required": []string{"reviewer", "decision"},
+			},
+		}},
+		{goName: "Title", schema: str("Title (the # heading)")},
+		{goName: "Description", schema: str("Description (markdown)")},
+		{goName: "Sections", schema: map[string]any{
+			"type": []string{"array", "null"},
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"Name":    str("Section heading (the ## line)"),
+					"Content": str("Section text (markdown), kept verbatim"),
+				},
+				"required": []string{"Name", "Content"},
+			},
+		}},
+		{goName: "Design", schema: str("Design notes (markdown)")},
+		{goName: "Acceptance", schema: str("Acceptance criteria (markdown)")},
+		{goName: "Notes", schema: map[string]any{
+			"type": []string{"array", "null"},
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"Timestamp": dateTime("Note time"),
+					"Content":   str("Note text (markdown)"),
+					"ReplyTo":   map[string]any{"type": "integer", "minimum": 0, "description": "1-based number of the note replied to, 0 for none"},
+				},
+				"required": []string{"Timestamp", "Content"},
+			},
+		}},
+	}
+}
+
+// ticketObjectSchema describes a ticket as JSON. With computed set it also
+// includes the read-only relationship fields added by tk export.
+func ticketObjectSchema(computed bool) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	for _, f := range ticketFields() {
+		properties[f.goName] = f.schema
+		required = append(required, f.goName)
+	}
+
+	if computed {
+		ids := func(desc string) map[string]any {
+			return map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "readOnly": true, "description": desc}
+		}
+		flag := func(desc string) map[string]any {
+			return map[string]any{"type": "boolean", "readOnly": true, "description": desc}
+		}
+		deprecated := func(schema map[string]any) map[string]any {
+			schema["deprecated"] = true
+			return schema
+		}
+		properties["Ready"] = flag("Computed by tk export: not closed and no dependency open, as listed by tk ready")
+		properties["Blocked"] = flag("Computed by tk export: not closed and some dependency open, as listed by tk blocked")
+		properties["BlockedBy"] = ids("Computed by tk export: dependencies not yet closed")
+		properties["Blocks"] = ids("Computed by tk export: tickets depending on this one")
+		properties["Blocking"] = deprecated(ids("Computed by tk export: earlier name of Blocks"))
+		properties["BlockedByOpen"] = deprecated(ids("Computed by tk export: earlier name of BlockedBy"))
+		properties["Children"] = ids("Computed by tk export: tickets with this parent")
+		properties["Depth"] = map[string]any{"type": "integer", "readOnly": true, "description": "Computed by tk export: longest dependency chain below the ticket"}
+		properties["EstimateTotal"] = map[string]any{"type": "number", "readOnly": true, "description": "Computed by tk export: estimate summed over child tickets"}
+		properties["EstimateRemaining"] = map[string]any{"type": "number", "readOnly": true, "description": "Computed by tk export: estimate of child tickets not yet closed"}
+	}
+
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+// frontmatterSchema describes the YAML frontmatter of a ticket file.
+func frontmatterSchema() map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	for _, f := range ticketFields() {
+		if f.yamlName == "" {
+			continue
+		}
+		properties[f.yamlName] = f.schema
+		if f.frontmatter {
+			required = append(required, f.yamlName)
+		}
+	}
+
+	return map[string]any{
+		"$schema":              jsonSchemaDraft,
+		"title":                "tk ticket frontmatter",
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// statsSchema describes the output of tk stats --json.
+func statsSchema() map[string]any {
+	count := func(desc string) map[string]any {
+		return map[string]any{"type": "integer", "minimum": 0, "description": desc}
+	}
+	counts := func(desc string, keys map[string]any) map[string]any {
+		schema := map[string]any{
+			"type":                 "object",
+			"additionalProperties": map[string]any{"type": "integer", "minimum": 0},
+			"description":          desc,
+		}
+		if keys != nil {
+			schema["propertyNames"] = keys
+		}
+		return schema
+	}
+
+	var priorities []string
+	for p := domain.MinPriority; p <= domain.MaxPriority; p++ {
+		priorities = append(priorities, strconv.Itoa(p))
+	}
+
+	properties := map[string]any{
+		"total":       count("Number of tickets"),
+		"ready":       count("Open or in_progress tickets whose dependencies are all closed, as listed by tk ready"),
+		"blocked":     count("Open or in_progress tickets with a dependency not yet closed, as listed by tk blocked"),
+		"overdue":     count("Tickets not closed whose due date has passed"),
+		"by_status":   counts("Tickets per status; statuses without tickets are omitted", map[string]any{"enum": statusStrings(domain.ValidStatuses)}),
+		"by_type":     counts("Tickets per type; types without tickets are omitted", map[string]any{"enum": typeStrings(domain.ValidTypes)}),
+		"by_assignee": counts("Tickets per assignee, with \"unassigned\" for none", nil),
+		"by_priority": counts("Tickets per priority; every priority is present", map[string]any{"pattern": "^[0-9]+$"}),
+
+		"by_resolution": counts("Closed tickets per resolution, those closed without one counted as done; every resolution is present",
+			map[string]any{"enum": resolutionStrings(domain.ValidResolutions)}),
+	}
+	properties["by_priority"].(map[string]any)["required"] = priorities
+	properties["by_resolution"].(map[string]any)["required"] = resolutionStrings(domain.ValidResolutions)
+
+	return map[string]any{
+		"$schema":     jsonSchemaDraft,
+		"title":       "tk stats",
+		"description": "Output of tk stats --json. Fields are only ever added, never renamed or removed.",
+		"type":        "object",
+		"properties":  properties,
+		"required":    slices.Sorted(maps.Keys(properties)),
+	}
+}
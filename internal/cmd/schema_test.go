@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+type SchemaSuite struct {
+	suite.Suite
+}
+
+func TestSchemaSuite(t *testing.T) {
+	suite.Run(t, new(SchemaSuite))
+}
+
+// fullTicket returns a ticket with every field set so no omitempty key is dropped.
+func fullTicket() *domain.Ticket {
+	now := time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC)
+	return &domain.Ticket{
+		ID: "tic-full", Status: domain.StatusClosed, Type: domain.TypeBug, Priority: 1, Estimate: 2.5,
+		Assignee: "a", Parent: "tic-p", ExternalRef: "gh-1", Tags: []string{"t"},
+		Deps: []string{"tic-d"}, Links: []string{"tic-l"}, Watchers: []string{"w"}, PRs: []string{"https://github.com/o/r/pull/1"}, Created: now, ClosedAt: now, Resolution: domain.ResolutionWontfix, Due: now,
+		UpdatedAt: now, LastUpdatedBy: "a", Revision: 1, Locked: true,
+		LockReason: "audit", LeaseUntil: now, LeaseHolder: "a", Reviews: []domain.Review{{Reviewer: "b", Decision: domain.ReviewApproved, Comment: "ok", At: now}},
+		Title: "T", Description: "D", Sections: []domain.Section{{Name: "Test Plan", Content: "P"}}, Design: "X",
+		Acceptance: "A", Notes: []domain.Note{{Timestamp: now, Content: "n", ReplyTo: 1}},
+	}
+}
+
+func (s *SchemaSuite) TestTicketSchemaCoversExport() {
+	schema, err := buildSchema(SchemaTicket)
+	require.NoError(s.T(), err)
+	properties := schema["properties"].(map[string]any)
+
+	data, err := json.Marshal(buildExportTickets([]*domain.Ticket{fullTicket()}))
+	require.NoError(s.T(), err)
+	var exported []map[string]any
+	require.NoError(s.T(), json.Unmarshal(data, &exported))
+
+	for key := range exported[0] {
+		require.Contains(s.T(), properties, key)
+	}
+	for _, field := range schema["required"].([]string) {
+		require.Contains(s.T(), exported[0], field)
+	}
+}
+
+func (s *SchemaSuite) TestFrontmatterSchemaCoversTicketFile() {
+	schema, err := buildSchema(SchemaFrontmatter)
+	require.NoError(s.T(), err)
+	properties := schema["properties"].(map[string]any)
+	require.Equal(s.T(), []string{"id", "status", "created"}, schema["required"])
+
+	data, err := yaml.Marshal(fullTicket())
+	require.NoError(s.T(), err)
+	var frontmatter map[string]any
+	require.NoError(s.T(), yaml.Unmarshal(data, &frontmatter))
+
+	require.Len(s.T(), properties, len(frontmatter))
+	for key := range frontmatter {
+		require.Contains(s.T(), properties, key)
+	}
+}
+
+func (s *SchemaSuite) TestImportSchema() {
+	schema, err := buildSchema(SchemaImport)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "array", schema["type"])
+	item := schema["items"].(map[string]any)
+	require.Empty(s.T(), item["required"])
+	require.NotContains(s.T(), item["properties"], "Depth")
+}
+
+func (s *SchemaSuite) TestStatsSchemaCoversOutput() {
+	schema, err := buildSchema(SchemaStats)
+	require.NoError(s.T(), err)
+	properties := schema["properties"].(map[string]any)
+
+	var buf bytes.Buffer
+	require.NoError(s.T(), outputStatsJSON(&buf, computeStats([]*domain.Ticket{fullTicket()}, time.Now())))
+	var output map[string]any
+	require.NoError(s.T(), json.Unmarshal(buf.Bytes(), &output))
+
+	require.Len(s.T(), properties, len(output))
+	for _, field := range schema["required"].([]string) {
+		require.Contains(s.T(), output, field)
+	}
+	for _, p := range properties["by_priority"].(map[string]any)["required"].([]string) {
+		require.Contains(s.T(), output["by_priority"], p)
+	}
+}
+
+func (s *SchemaSuite) TestUnknownSchema() {
+	_, err := buildSchema("nope")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "unknown schema")
+}
@@ -26,12 +26,13 @@ The search is case-insensitive by default.
 Examples:
   tk search 'authentication'           # Search for "authentication"
   tk search 'bug fix' --case-sensitive # Case-sensitive search
-  tk search 'TODO' --status=open       # Search only open tickets`,
+  tk search 'TODO' --status=open       # Search only open tickets
+  tk search 'outage' --all             # Include archived tickets`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := args[0]
 
-		tickets, err := store.List()
+		tickets, err := listScopedTickets()
 		if err != nil {
 			return err
 		}
@@ -40,6 +41,11 @@ Examples:
 
 		sortSearchMatchesByPriority(matches)
 
+		if countFlag {
+			fmt.Println(len(matches))
+			return nil
+		}
+
 		return runWithPager(func(w io.Writer) error {
 			for _, m := range matches {
 				if _, err := fmt.Fprintln(w, formatTicketLine(m.ticket)); err != nil {
@@ -139,4 +145,7 @@ func sortSearchMatchesByPriority(matches []searchMatch) {
 func init() {
 	searchCmd.Flags().BoolVar(&searchFlags.caseSensitive, "case-sensitive", false, "Perform case-sensitive search")
 	searchCmd.Flags().StringVar(&searchFlags.status, "status", "", "Filter by status (open|in_progress|closed)")
+	addLineFormatFlag(searchCmd)
+	addCountFlag(searchCmd)
+	addArchiveFlags(searchCmd)
 }
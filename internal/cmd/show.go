@@ -4,25 +4,48 @@ import (
 	"fmt"
 	"io"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/radutopala/ticket/internal/domain"
 	"github.com/spf13/cobra"
 )
 
+var showFlags struct {
+	section string
+}
+
 var showCmd = &cobra.Command{
 	Use:   "show <id>",
 	Short: "Display a ticket",
-	Long:  `Display the full contents of a ticket by ID. Supports partial ID matching.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Display the full contents of a ticket by ID. Supports partial ID matching.
+
+Use --archived to look the ticket up in .tickets/archive/, or --all to fall
+back to the archive when no active ticket matches.
+
+Use --section to print only one body section: Description, Design,
+Acceptance Criteria, or any custom "## Name" section.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ticket, err := resolveAndReadTicket(args[0])
+		ticket, err := resolveScopedTicket(args[0])
 		if err != nil {
 			return err
 		}
 
+		if showFlags.section != "" {
+			content, err := ticketSection(ticket, showFlags.section)
+			if err != nil {
+				return err
+			}
+			return runWithPager(func(w io.Writer) error {
+				_, err := fmt.Fprintln(w, content)
+				return err
+			})
+		}
+
 		// Load all tickets once for parent lookup and relationships
-		allTickets, err := store.List()
+		allTickets, err := listScopedTickets()
 		if err != nil {
 			return fmt.Errorf("failed to list tickets: %w", err)
 		}
@@ -33,14 +56,16 @@ var showCmd = &cobra.Command{
 			ticketMap[t.ID] = t
 		}
 
-		// Render the ticket content
-		content, err := ticket.Render()
+		// Render the ticket content; notes are rendered separately as threads
+		withoutNotes := *ticket
+		withoutNotes.Notes = nil
+		content, err := withoutNotes.Render()
 		if err != nil {
 			return fmt.Errorf("failed to render ticket: %w", err)
 		}
 
 		// Add parent comment if present
-		output := string(content)
+		output := displayFrontmatterTimes(string(content), ticket) + renderNoteThreads(ticket)
 		if ticket.Parent != "" {
 			// Find where to insert parent comment (after links line in frontmatter)
 			lines := strings.Split(output, "\n")
@@ -83,6 +108,75 @@ var showCmd = &cobra.Command{
 	},
 }
 
+// displayFrontmatterTimes replaces the UTC times in the rendered frontmatter
+// of ticket with their display form, including the times of its reviews.
+func displayFrontmatterTimes(content string, ticket *domain.Ticket) string {
+	times := map[string]string{
+		"created":     formatTime(ticket.Created),
+		"closed-at":   formatTime(ticket.ClosedAt),
+		"due":         formatDate(ticket.Due),
+		"updated-at":  formatTime(ticket.UpdatedAt),
+		"lease-until": formatTime(ticket.LeaseUntil),
+	}
+
+	lines := strings.Split(content, "\n")
+	inReviews, review := false, -1
+	for i, line := range lines {
+		if i > 0 && line == "---" {
+			break
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == line {
+			inReviews = line == "reviews:"
+		} else if inReviews && strings.HasPrefix(trimmed, "- ") {
+			review++
+			trimmed = strings.TrimPrefix(trimmed, "- ")
+		}
+
+		key, _, ok := strings.Cut(trimmed, ": ")
+		if !ok {
+			continue
+		}
+		if trimmed == line {
+			if value := times[key]; value != "" {
+				lines[i] = key + ": " + value
+			}
+		} else if inReviews && key == "at" && review >= 0 && review < len(ticket.Reviews) {
+			if value := formatTime(ticket.Reviews[review].At); value != "" {
+				lines[i] = line[:len(line)-len(trimmed)] + key + ": " + value
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderNoteThreads renders the ticket's notes numbered and with replies
+// indented under the note they answer.
+func renderNoteThreads(ticket *domain.Ticket) string {
+	if len(ticket.Notes) == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+	buf.WriteString("## Notes\n\n")
+	for _, note := range ticket.NoteThreads() {
+		indent := strings.Repeat("  ", note.Depth)
+		heading := fmt.Sprintf("### #%d %s", note.Number, formatTime(note.Timestamp))
+		if note.ReplyTo > 0 {
+			heading += fmt.Sprintf(" re: #%d", note.ReplyTo)
+		}
+		buf.WriteString(indent + heading + "\n\n")
+		for _, line := range strings.Split(note.Content, "\n") {
+			if line != "" {
+				buf.WriteString(indent)
+			}
+			buf.WriteString(line + "\n")
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
 // getTicketRelationships returns a string with the ticket's relationships.
 func getTicketRelationships(id string, ticket *domain.Ticket, allTickets []*domain.Ticket) string {
 	var blocking []string
@@ -106,24 +200,31 @@ func getTicketRelationships(id string, ticket *domain.Ticket, allTickets []*doma
 
 	var lines []string
 
+	if ticket.Overdue(time.Now()) {
+		lines = append(lines, tr(msgShowOverdue, map[string]any{"Due": formatDate(ticket.Due)}))
+	}
+
 	// Blockers (tickets this one depends on)
 	if len(ticket.Deps) > 0 {
-		lines = append(lines, fmt.Sprintf("Blockers: %s", strings.Join(ticket.Deps, ", ")))
+		lines = append(lines, tr(msgShowBlockers, map[string]any{"IDs": strings.Join(ticket.Deps, ", ")}))
 	}
 
 	// Blocking (tickets that depend on this one)
 	if len(blocking) > 0 {
-		lines = append(lines, fmt.Sprintf("Blocking: %s", strings.Join(blocking, ", ")))
+		lines = append(lines, tr(msgShowBlocking, map[string]any{"IDs": strings.Join(blocking, ", ")}))
 	}
 
 	// Children (tickets with this ticket as parent)
 	if len(children) > 0 {
-		lines = append(lines, fmt.Sprintf("Children: %s", strings.Join(children, ", ")))
+		lines = append(lines, tr(msgShowChildren, map[string]any{"IDs": strings.Join(children, ", ")}))
+		if r := rollupEstimates(allTickets)[id]; r.Total > 0 {
+			lines = append(lines, tr(msgShowRollup, map[string]any{"Total": formatEstimate(r.Total), "Remaining": formatEstimate(r.Remaining)}))
+		}
 	}
 
 	// Links (bidirectionally linked tickets)
 	if len(ticket.Links) > 0 {
-		lines = append(lines, fmt.Sprintf("Links: %s", strings.Join(ticket.Links, ", ")))
+		lines = append(lines, tr(msgShowLinks, map[string]any{"IDs": strings.Join(ticket.Links, ", ")}))
 	}
 
 	if len(lines) == 0 {
@@ -132,3 +233,33 @@ func getTicketRelationships(id string, ticket *domain.Ticket, allTickets []*doma
 
 	return strings.Join(lines, "\n") + "\n"
 }
+
+// ticketSection returns the content of the named body section of ticket.
+func ticketSection(ticket *domain.Ticket, name string) (string, error) {
+	if err := checkSectionName(name); err != nil {
+		return "", err
+	}
+	content, ok := ticket.Section(name)
+	if !ok {
+		return "", trError(msgNoSection, map[string]any{"ID": ticket.ID, "Name": strconv.Quote(name)}, nil)
+	}
+	return content, nil
+}
+
+// checkSectionName rejects the title and notes, which are not plain sections.
+func checkSectionName(name string) error {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "":
+		return trError(msgEmptySectionName, nil, nil)
+	case "notes":
+		return trError(msgNotesNotSection, nil, nil)
+	case "title":
+		return trError(msgTitleNotSection, nil, nil)
+	}
+	return nil
+}
+
+func init() {
+	addArchiveFlags(showCmd)
+	showCmd.Flags().StringVar(&showFlags.section, "section", "", "Print only this body section (e.g. Design, \"Test Plan\")")
+}
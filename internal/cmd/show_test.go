@@ -142,3 +142,27 @@ func (s *ShowSuite) TestGetTicketRelationships_SkipsSelf() {
 	require.Contains(s.T(), result, "Blockers: tic-main")
 	require.NotContains(s.T(), result, "Blocking:")
 }
+
+func (s *ShowSuite) TestDisplayFrontmatterTimes() {
+	loc, layout := displayLocation, displayLayout
+	defer func() { displayLocation, displayLayout = loc, layout }()
+	displayLocation, displayLayout = time.FixedZone("X", 2*60*60), "2006-01-02 15:04"
+
+	at := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	ticket := &domain.Ticket{
+		ID: "tic-times", Status: domain.StatusOpen, Created: at, UpdatedAt: at.Add(time.Hour), LeaseUntil: at.Add(2 * time.Hour),
+		Reviews: []domain.Review{
+			{Reviewer: "bob", Decision: domain.ReviewPending},
+			{Reviewer: "alice", Decision: domain.ReviewApproved, At: at.Add(3 * time.Hour)},
+		},
+	}
+	content, err := ticket.Render()
+	require.NoError(s.T(), err)
+
+	output := displayFrontmatterTimes(string(content), ticket)
+	require.Contains(s.T(), output, "created: 2026-01-01 12:00\n")
+	require.Contains(s.T(), output, "updated-at: 2026-01-01 13:00\n")
+	require.Contains(s.T(), output, "lease-until: 2026-01-01 14:00\n")
+	require.Contains(s.T(), output, "      decision: approved\n      at: 2026-01-01 15:00\n")
+	require.NotContains(s.T(), output, "Z\n")
+}
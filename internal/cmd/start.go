@@ -3,32 +3,164 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/radutopala/ticket/internal/domain"
 	"github.com/radutopala/ticket/internal/storage"
 )
 
+var startFlags struct {
+	lease time.Duration
+}
+
 var startCmd = &cobra.Command{
-	Use:   "start <id>",
-	Short: "Set ticket status to in_progress",
-	Long:  `Set the ticket status to in_progress. Supports partial ID matching. Uses file locking to prevent race conditions.`,
-	Args:  cobra.ExactArgs(1),
+	Use:     "start <id>",
+	Aliases: []string{"claim"},
+	Short:   "Set ticket status to in_progress",
+	Long: `Set the ticket status to in_progress. Supports partial ID matching. Uses file locking to prevent race conditions.
+
+With --lease the claim expires after that duration unless renewed by claiming
+the ticket again, so tickets held by crashed agents become claimable again
+(see tk leases and tk ready --claimable).
+
+Refuses when a WIP limit from the config file would be exceeded: wip_limit caps
+the in_progress tickets assigned to you, wip_limit_tags caps in_progress
+tickets per tag. With --force the limit is reported as a warning instead.
+
+Examples:
+  tk start abc1
+  tk claim abc1 --lease 30m   # Renew by running it again before it expires`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id, err := store.ResolveID(args[0])
+		id, err := resolveID(store, args[0])
 		if err != nil {
 			return err
 		}
 
-		ticket, err := store.AtomicClaim(id)
+		ticket, err := claimTicket(id, startFlags.lease)
 		if err != nil {
-			if errors.Is(err, storage.ErrAlreadyClaimed) {
-				return fmt.Errorf("cannot claim %s: %w", id, err)
-			}
-			return fmt.Errorf("failed to claim ticket: %w", err)
+			return err
 		}
 
-		fmt.Printf("Claimed %s -> in_progress\n", ticket.ID)
+		if !ticket.LeaseUntil.IsZero() {
+			fmt.Println(tr(msgClaimedLease, map[string]any{"ID": ticket.ID, "Until": formatTime(ticket.LeaseUntil)}))
+			return nil
+		}
+		fmt.Println(tr(msgClaimed, map[string]any{"ID": ticket.ID}))
 		return nil
 	},
 }
+
+// claimTicket atomically moves ticket id to in_progress after checking the WIP
+// limits and start policy. A positive lease makes the claim expire.
+func claimTicket(id string, lease time.Duration) (*domain.Ticket, error) {
+	if err := enforceWIPLimits(id); err != nil {
+		return nil, err
+	}
+
+	current, err := store.Read(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := enforcePolicy(current); err != nil {
+		return nil, trWrap(msgStartCannot, map[string]any{"ID": id}, err)
+	}
+
+	ticket, err := store.AtomicClaimLease(id, lease)
+	if err != nil {
+		if errors.Is(err, storage.ErrAlreadyClaimed) {
+			return nil, trWrap(msgStartCannotClaim, map[string]any{"ID": id}, err)
+		}
+		return nil, fmt.Errorf("failed to claim ticket: %w", err)
+	}
+	return ticket, nil
+}
+
+// enforceWIPLimits fails if starting ticket id would exceed a configured WIP
+// limit, or only warns when --force is set.
+func enforceWIPLimits(id string) error {
+	if cfg.WIPLimit <= 0 && len(cfg.WIPLimitTags) == 0 {
+		return nil
+	}
+
+	tickets, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	violations := wipViolations(id, currentUser(), tickets, cfg.WIPLimit, cfg.WIPLimitTags)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if forceFlag {
+		for _, v := range violations {
+			fmt.Fprintln(os.Stderr, tr(msgWarningPrefix, nil), v)
+		}
+		return nil
+	}
+	return trError(msgStartRefused, map[string]any{"ID": id, "Violation": violations[0]}, nil)
+}
+
+// wipViolations describes each WIP limit that starting ticket id would exceed
+// for user, given the per-assignee limit and per-tag limits.
+func wipViolations(id, user string, tickets []*domain.Ticket, limit int, tagLimits map[string]int) []string {
+	var target *domain.Ticket
+	inProgress := make([]*domain.Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		if t.ID == id {
+			target = t
+			continue
+		}
+		if t.Status == domain.StatusInProgress {
+			inProgress = append(inProgress, t)
+		}
+	}
+
+	var violations []string
+	if limit > 0 && user != "" {
+		count := 0
+		for _, t := range inProgress {
+			if t.Assignee == user {
+				count++
+			}
+		}
+		if count >= limit {
+			violations = append(violations, tr(msgWIPAssignee, map[string]any{"User": user, "Count": count, "Limit": limit}))
+		}
+	}
+
+	if target == nil {
+		return violations
+	}
+
+	tags := make([]string, 0, len(target.Tags))
+	for _, tag := range target.Tags {
+		if tagLimits[tag] > 0 {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		count := 0
+		for _, t := range inProgress {
+			if slices.Contains(t.Tags, tag) {
+				count++
+			}
+		}
+		if count >= tagLimits[tag] {
+			violations = append(violations, tr(msgWIPTag, map[string]any{"Count": count, "Tag": tag, "Limit": tagLimits[tag]}))
+		}
+	}
+
+	return violations
+}
+
+func init() {
+	startCmd.Flags().Var(durationValue{&startFlags.lease}, "lease", "Let the claim expire after duration (e.g. 30m, 2h, 1d) unless renewed")
+}
@@ -1,26 +1,57 @@
 package cmd
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/radutopala/ticket/internal/domain"
 )
 
-// Stats holds aggregated ticket statistics.
+// Stats holds aggregated ticket statistics. Its JSON form is a stable
+// interface for monitoring scripts, described by tk schema stats: fields are
+// only ever added.
 type Stats struct {
-	Total      int            `json:"total"`
+	Total int `json:"total"`
+	// Ready and Blocked count the tickets tk ready and tk blocked list:
+	// open or in_progress tickets without and with unclosed dependencies.
+	Ready   int `json:"ready"`
+	Blocked int `json:"blocked"`
+	// Overdue counts tickets that are not closed and past their due date.
+	Overdue    int            `json:"overdue"`
 	ByStatus   map[string]int `json:"by_status"`
 	ByType     map[string]int `json:"by_type"`
 	ByAssignee map[string]int `json:"by_assignee"`
+	// ByPriority counts tickets per priority, with every priority present.
+	ByPriority map[int]int `json:"by_priority"`
+	// ByResolution counts closed tickets per resolution, with every
+	// resolution present. Tickets closed without one count as done.
+	ByResolution map[string]int `json:"by_resolution"`
 }
 
+// Heatmap counts ticket closures per day.
+type Heatmap struct {
+	// Start is the first day shown, a Monday.
+	Start time.Time `json:"start"`
+	// Counts holds the closures per day from Start through today.
+	Counts []int `json:"counts"`
+}
+
+// heatmapShades renders a day's closures from none to the busiest.
+var heatmapShades = unicodeHeatmapShades
+
 var statsFlags struct {
-	json bool
+	json   bool
+	byWeek bool
+	weeks  int
 }
 
 var statsCmd = &cobra.Command{
@@ -28,18 +59,41 @@ var statsCmd = &cobra.Command{
 	Short: "Display project metrics",
 	Long: `Display aggregated statistics about tickets in the project.
 
-Shows total ticket count along with breakdowns by status, type, and assignee.
+Shows total ticket count along with breakdowns by status, resolution of
+closed tickets (done, wontfix, duplicate), type, priority and assignee.
+
+With --by-week, shows a calendar heatmap of closures per day over the last
+--weeks weeks instead, one column per week like a contribution graph.
 
 Examples:
-  tk stats         # Show stats in human-readable format
-  tk stats --json  # Output as JSON`,
+  tk stats                     # Show stats in human-readable format
+  tk stats --json              # Output as JSON
+  tk stats --by-week --weeks 26  # Closures per day over half a year`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		tickets, err := store.List()
 		if err != nil {
 			return err
 		}
 
-		stats := computeStats(tickets)
+		if statsFlags.byWeek {
+			if statsFlags.weeks < 1 {
+				return trError(msgStatsInvalidWeeks, map[string]any{"Weeks": statsFlags.weeks}, nil)
+			}
+			heatmap := computeHeatmap(tickets, time.Now(), statsFlags.weeks)
+			if statsFlags.json {
+				data, err := json.MarshalIndent(heatmap, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal heatmap: %w", err)
+				}
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return err
+			}
+			return runWithPager(func(w io.Writer) error {
+				return outputHeatmapText(w, heatmap)
+			})
+		}
+
+		stats := computeStats(tickets, time.Now())
 
 		if statsFlags.json {
 			return outputStatsJSON(cmd.OutOrStdout(), stats)
@@ -51,16 +105,42 @@ Examples:
 	},
 }
 
-func computeStats(tickets []*domain.Ticket) Stats {
+// computeStats aggregates tickets. Dependencies outside tickets count as
+// resolved when telling ready from blocked tickets.
+func computeStats(tickets []*domain.Ticket, now time.Time) Stats {
 	stats := Stats{
 		Total:      len(tickets),
 		ByStatus:   make(map[string]int),
 		ByType:     make(map[string]int),
 		ByAssignee: make(map[string]int),
+		ByPriority: make(map[int]int),
+
+		ByResolution: make(map[string]int),
+	}
+	for p := domain.MinPriority; p <= domain.MaxPriority; p++ {
+		stats.ByPriority[p] = 0
+	}
+	for _, r := range domain.ValidResolutions {
+		stats.ByResolution[string(r)] = 0
 	}
 
+	openIDs := buildOpenIDSet(tickets)
 	for _, t := range tickets {
 		stats.ByStatus[string(t.Status)]++
+		stats.ByPriority[t.Priority]++
+
+		if t.Status == domain.StatusClosed {
+			stats.ByResolution[string(cmp.Or(t.Resolution, domain.ResolutionDone))]++
+		} else {
+			if slices.ContainsFunc(t.Deps, func(dep string) bool { return openIDs[dep] }) {
+				stats.Blocked++
+			} else {
+				stats.Ready++
+			}
+		}
+		if t.Overdue(now) {
+			stats.Overdue++
+		}
 
 		if t.Type != "" {
 			stats.ByType[string(t.Type)]++
@@ -86,12 +166,15 @@ func outputStatsJSON(w io.Writer, stats Stats) error {
 }
 
 func outputStatsText(w io.Writer, stats Stats) error {
-	if _, err := fmt.Fprintf(w, "Total: %d tickets\n\n", stats.Total); err != nil {
+	if _, err := fmt.Fprintln(w, tr(msgStatsTotal, map[string]any{"Count": stats.Total})); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n\n", tr(msgStatsCounts, map[string]any{"Ready": stats.Ready, "Blocked": stats.Blocked, "Overdue": stats.Overdue})); err != nil {
 		return err
 	}
 
 	// Status breakdown
-	if _, err := fmt.Fprintln(w, "By Status:"); err != nil {
+	if _, err := fmt.Fprintln(w, tr(msgStatsByStatus, nil)); err != nil {
 		return err
 	}
 	statusOrder := statusStrings(domain.ValidStatuses)
@@ -106,8 +189,24 @@ func outputStatsText(w io.Writer, stats Stats) error {
 		return err
 	}
 
+	// Resolution breakdown of closed tickets
+	if _, err := fmt.Fprintln(w, tr(msgStatsByResolution, nil)); err != nil {
+		return err
+	}
+	resolutionOrder := resolutionStrings(domain.ValidResolutions)
+	maxResolutionLen := maxKeyLen(resolutionOrder)
+	for _, resolution := range resolutionOrder {
+		count := stats.ByResolution[resolution]
+		if _, err := fmt.Fprintf(w, "  %-*s %d\n", maxResolutionLen+1, resolution+":", count); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
 	// Type breakdown
-	if _, err := fmt.Fprintln(w, "By Type:"); err != nil {
+	if _, err := fmt.Fprintln(w, tr(msgStatsByType, nil)); err != nil {
 		return err
 	}
 	typeOrder := typeStrings(domain.ValidTypes)
@@ -124,8 +223,21 @@ func outputStatsText(w io.Writer, stats Stats) error {
 		return err
 	}
 
+	// Priority breakdown
+	if _, err := fmt.Fprintln(w, tr(msgStatsByPriority, nil)); err != nil {
+		return err
+	}
+	for _, p := range slices.Sorted(maps.Keys(stats.ByPriority)) {
+		if _, err := fmt.Fprintf(w, "  P%d: %d\n", p, stats.ByPriority[p]); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
 	// Assignee breakdown
-	if _, err := fmt.Fprintln(w, "By Assignee:"); err != nil {
+	if _, err := fmt.Fprintln(w, tr(msgStatsByAssignee, nil)); err != nil {
 		return err
 	}
 	assignees := sortedKeys(stats.ByAssignee)
@@ -140,6 +252,96 @@ func outputStatsText(w io.Writer, stats Stats) error {
 	return nil
 }
 
+// computeHeatmap counts closures per local calendar day over the weeks ending
+// with the week (Monday to Sunday) containing now.
+func computeHeatmap(tickets []*domain.Ticket, now time.Time, weeks int) Heatmap {
+	today := civilDay(now)
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	start := monday.AddDate(0, 0, -7*(weeks-1))
+
+	counts := make([]int, daysBetween(start, today)+1)
+	for _, t := range tickets {
+		if t.Status != domain.StatusClosed || t.ClosedAt.IsZero() {
+			continue
+		}
+		day := civilDay(t.ClosedAt.In(now.Location()))
+		if day.Before(start) || day.After(today) {
+			continue
+		}
+		counts[daysBetween(start, day)]++
+	}
+
+	return Heatmap{Start: start, Counts: counts}
+}
+
+// civilDay returns t's calendar date as midnight UTC, so day arithmetic is
+// unaffected by daylight saving changes.
+func civilDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween returns the number of days from one civil day to another.
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// outputHeatmapText renders the heatmap as a grid of weekday rows and week
+// columns, with month labels above and a legend below.
+func outputHeatmapText(w io.Writer, h Heatmap) error {
+	weeks := (len(h.Counts) + 6) / 7
+	peak, total := 0, 0
+	for _, c := range h.Counts {
+		peak = max(peak, c)
+		total += c
+	}
+
+	// Month labels over the first week of each month, skipping any that would overlap
+	labels := []byte(strings.Repeat(" ", 2*weeks+2))
+	end := 0
+	for col := range weeks {
+		day := h.Start.AddDate(0, 0, 7*col)
+		pos := 2 * col
+		if (col == 0 || day.Day() <= 7) && pos >= end {
+			name := day.Format("Jan")
+			copy(labels[pos:], name)
+			end = pos + len(name) + 1
+		}
+	}
+	if _, err := fmt.Fprintf(w, "    %s\n", strings.TrimRight(string(labels), " ")); err != nil {
+		return err
+	}
+
+	for weekday := range 7 {
+		var row strings.Builder
+		row.WriteString(h.Start.AddDate(0, 0, weekday).Format("Mon"))
+		for col := range weeks {
+			i := 7*col + weekday
+			if i >= len(h.Counts) {
+				break
+			}
+			row.WriteString(" " + heatmapShade(h.Counts[i], peak))
+		}
+		if _, err := fmt.Fprintln(w, row.String()); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "\n    %s\n", tr(msgStatsHeatmapLegend, map[string]any{"Shades": strings.Join(heatmapShades, " ")})); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, tr(msgStatsClosedWeeks, map[string]any{"Count": total, "Weeks": weeks}))
+	return err
+}
+
+// heatmapShade picks the shade for count relative to the busiest day.
+func heatmapShade(count, peak int) string {
+	if count == 0 {
+		return heatmapShades[0]
+	}
+	levels := len(heatmapShades) - 1
+	return heatmapShades[(count*levels+peak-1)/peak]
+}
+
 func sortedKeys(m map[string]int) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -177,6 +379,17 @@ func typeStrings(types []domain.Type) []string {
 	return result
 }
 
+// resolutionStrings converts a slice of Resolution to a slice of strings.
+func resolutionStrings(resolutions []domain.Resolution) []string {
+	result := make([]string, len(resolutions))
+	for i, r := range resolutions {
+		result[i] = string(r)
+	}
+	return result
+}
+
 func init() {
 	statsCmd.Flags().BoolVar(&statsFlags.json, "json", false, "Output as JSON")
+	statsCmd.Flags().BoolVar(&statsFlags.byWeek, "by-week", false, "Show a heatmap of closures per day instead")
+	statsCmd.Flags().IntVar(&statsFlags.weeks, "weeks", 12, "Number of weeks in the --by-week heatmap")
 }
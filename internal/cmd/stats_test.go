@@ -95,7 +95,7 @@ func (s *StatsSuite) TestComputeStats() {
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
-			got := computeStats(tt.tickets)
+			got := computeStats(tt.tickets, now)
 			require.Equal(s.T(), tt.want.Total, got.Total)
 			require.Equal(s.T(), tt.want.ByStatus, got.ByStatus)
 			require.Equal(s.T(), tt.want.ByType, got.ByType)
@@ -104,6 +104,27 @@ func (s *StatsSuite) TestComputeStats() {
 	}
 }
 
+func (s *StatsSuite) TestComputeStatsGraphCounts() {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1)
+	tickets := []*domain.Ticket{
+		{ID: "t1", Status: domain.StatusOpen, Priority: 1, Deps: []string{"t2"}, Due: yesterday},
+		{ID: "t2", Status: domain.StatusInProgress, Priority: 1},
+		{ID: "t3", Status: domain.StatusOpen, Priority: 3, Deps: []string{"t4", "gone"}},
+		{ID: "t4", Status: domain.StatusClosed, Priority: 0, Due: yesterday},
+		{ID: "t5", Status: domain.StatusClosed, Priority: 2, Resolution: domain.ResolutionDuplicate},
+	}
+
+	got := computeStats(tickets, now)
+
+	require.Equal(s.T(), 2, got.Ready)
+	require.Equal(s.T(), 1, got.Blocked)
+	require.Equal(s.T(), 1, got.Overdue)
+	require.Equal(s.T(), map[int]int{0: 1, 1: 2, 2: 1, 3: 1, 4: 0}, got.ByPriority)
+	// Closed without a resolution counts as done
+	require.Equal(s.T(), map[string]int{"done": 1, "wontfix": 0, "duplicate": 1}, got.ByResolution)
+}
+
 func (s *StatsSuite) TestOutputStatsJSON() {
 	stats := Stats{
 		Total: 3,
@@ -163,6 +184,7 @@ func (s *StatsSuite) TestOutputStatsText() {
 
 	// Check total
 	require.Contains(s.T(), output, "Total: 5 tickets")
+	require.Contains(s.T(), output, "Ready: 0, blocked: 0, overdue: 0")
 
 	// Check status section
 	require.Contains(s.T(), output, "By Status:")
@@ -244,3 +266,63 @@ func (s *StatsSuite) TestMaxKeyLen() {
 		})
 	}
 }
+
+func (s *StatsSuite) TestComputeHeatmap() {
+	now := time.Date(2026, 1, 14, 15, 0, 0, 0, time.UTC) // a Wednesday
+	closed := func(at time.Time) *domain.Ticket {
+		return &domain.Ticket{Status: domain.StatusClosed, ClosedAt: at}
+	}
+	tickets := []*domain.Ticket{
+		closed(time.Date(2026, 1, 14, 9, 0, 0, 0, time.UTC)),
+		closed(time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)),
+		closed(time.Date(2026, 1, 5, 23, 0, 0, 0, time.UTC)),
+		closed(time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC)), // before the window
+		{Status: domain.StatusClosed},                        // no closed-at
+		{Status: domain.StatusOpen, ClosedAt: now},
+	}
+
+	heatmap := computeHeatmap(tickets, now, 2)
+
+	require.Equal(s.T(), time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), heatmap.Start)
+	require.Equal(s.T(), []int{1, 0, 0, 0, 0, 0, 0, 0, 0, 2}, heatmap.Counts)
+}
+
+func (s *StatsSuite) TestOutputHeatmapText() {
+	heatmap := Heatmap{
+		Start:  time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		Counts: []int{1, 0, 0, 0, 0, 0, 0, 0, 0, 2},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(s.T(), outputHeatmapText(&buf, heatmap))
+
+	want := "    Jan\n" +
+		"Mon ▒ ·\n" +
+		"Tue · ·\n" +
+		"Wed · █\n" +
+		"Thu ·\n" +
+		"Fri ·\n" +
+		"Sat ·\n" +
+		"Sun ·\n" +
+		"\n    Less · ░ ▒ ▓ █ More\n" +
+		"3 closed in the last 2 weeks\n"
+	require.Equal(s.T(), want, buf.String())
+}
+
+func (s *StatsSuite) TestOutputHeatmapTextASCII() {
+	setASCIIOutput(true)
+	defer func() {
+		setASCIIOutput(false)
+		require.NoError(s.T(), setStatusSymbols("", nil))
+	}()
+	heatmap := Heatmap{
+		Start:  time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		Counts: []int{1, 0, 0, 0, 0, 0, 0, 0, 0, 2},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(s.T(), outputHeatmapText(&buf, heatmap))
+
+	require.Contains(s.T(), buf.String(), "Mon 2 .\nTue . .\nWed . 4\n")
+	require.Contains(s.T(), buf.String(), "Less . 1 2 3 4 More\n")
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// summaryReadyLimit is the number of ready tickets tk summary lists.
+const summaryReadyLimit = 5
+
+// projectSummary is the data shown by tk summary.
+type projectSummary struct {
+	Stats      Stats
+	Ready      []*domain.Ticket
+	ReadyTotal int
+	// InProgress maps assignees ("unassigned" for none) to their in_progress tickets.
+	InProgress map[string][]*domain.Ticket
+	Blocked    int
+	Overdue    []*domain.Ticket
+}
+
+var summaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "One-screen project overview",
+	Long: `Show a one-screen overview of the project: ticket counts by status, the top
+5 ready tickets by priority, in_progress tickets by assignee, the number of
+blocked tickets, and open tickets past their due date.
+
+Examples:
+  tk summary
+  tk summary -T backend   # Only tickets tagged backend`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listFlags.Validate(); err != nil {
+			return err
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+
+		summary := buildSummary(tickets, time.Now())
+		return runWithPager(func(w io.Writer) error {
+			return outputSummaryText(w, summary)
+		})
+	},
+}
+
+// buildSummary summarizes the tickets matching the filter flags. All tickets
+// are needed to tell whether dependencies are resolved.
+func buildSummary(tickets []*domain.Ticket, now time.Time) projectSummary {
+	summary := projectSummary{
+		Stats:      computeStats(filterTickets(tickets, listFlags), now),
+		InProgress: make(map[string][]*domain.Ticket),
+		Blocked:    len(filterByDependencyStatus(tickets, true)),
+	}
+
+	for _, t := range filterByDependencyStatus(tickets, false) {
+		if t.Status == domain.StatusOpen {
+			summary.Ready = append(summary.Ready, t)
+		}
+	}
+	sortTickets(summary.Ready, SortOptions{})
+	summary.ReadyTotal = len(summary.Ready)
+	summary.Ready = summary.Ready[:min(len(summary.Ready), summaryReadyLimit)]
+
+	for _, t := range filterTickets(tickets, listFlags) {
+		if t.Status == domain.StatusInProgress {
+			assignee := cmp.Or(t.Assignee, "unassigned")
+			summary.InProgress[assignee] = append(summary.InProgress[assignee], t)
+		}
+		if t.Overdue(now) {
+			summary.Overdue = append(summary.Overdue, t)
+		}
+	}
+	for _, list := range summary.InProgress {
+		sortTickets(list, SortOptions{})
+	}
+	slices.SortStableFunc(summary.Overdue, func(a, b *domain.Ticket) int {
+		return a.Due.Compare(b.Due)
+	})
+
+	return summary
+}
+
+func outputSummaryText(w io.Writer, s projectSummary) error {
+	var buf strings.Builder
+
+	counts := make([]string, 0, len(domain.ValidStatuses))
+	for _, status := range domain.ValidStatuses {
+		counts = append(counts, fmt.Sprintf("%d %s", s.Stats.ByStatus[string(status)], status))
+	}
+	buf.WriteString(tr(msgSummaryTickets, map[string]any{"Total": s.Stats.Total, "Counts": strings.Join(counts, ", ")}) + "\n")
+	buf.WriteString(tr(msgSummaryBlocked, map[string]any{"Count": s.Blocked}) + "\n")
+	buf.WriteString(tr(msgSummaryOverdueN, map[string]any{"Count": len(s.Overdue)}) + "\n")
+
+	buf.WriteString("\n" + tr(msgSummaryReady, map[string]any{"Count": len(s.Ready), "Total": s.ReadyTotal}) + "\n")
+	writeSummaryLines(&buf, "  ", s.Ready, nil)
+
+	buf.WriteString("\n" + tr(msgSummaryInProgress, nil) + "\n")
+	if len(s.InProgress) == 0 {
+		buf.WriteString("  " + tr(msgSummaryNone, nil) + "\n")
+	}
+	for _, assignee := range slices.Sorted(maps.Keys(s.InProgress)) {
+		fmt.Fprintf(&buf, "  %s (%d)\n", assignee, len(s.InProgress[assignee]))
+		writeSummaryLines(&buf, "    ", s.InProgress[assignee], nil)
+	}
+
+	if len(s.Overdue) > 0 {
+		buf.WriteString("\n" + tr(msgSummaryOverdue, nil) + "\n")
+		writeSummaryLines(&buf, "  ", s.Overdue, func(t *domain.Ticket) string {
+			return " " + tr(msgSummaryDue, map[string]any{"Due": formatDate(t.Due)})
+		})
+	}
+
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// writeSummaryLines writes one ticket line per ticket with indent and an
+// optional suffix, or "none".
+func writeSummaryLines(buf *strings.Builder, indent string, tickets []*domain.Ticket, suffix func(*domain.Ticket) string) {
+	if len(tickets) == 0 {
+		buf.WriteString(indent + tr(msgSummaryNone, nil) + "\n")
+	}
+	for _, t := range tickets {
+		line := indent + formatTicketLine(t)
+		if suffix != nil {
+			line += suffix(t)
+		}
+		buf.WriteString(line + "\n")
+	}
+}
+
+func init() {
+	addMatchFlags(summaryCmd, false)
+}
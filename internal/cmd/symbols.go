@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// Status symbol set names for the symbol_set config key.
+const (
+	SymbolSetASCII = "ascii"
+	SymbolSetEmoji = "emoji"
+	SymbolSetNerd  = "nerd"
+)
+
+// symbolUnknown is the status_symbols key for the symbol shown for statuses
+// without one of their own and for missing tickets.
+const symbolUnknown = "unknown"
+
+// symbolSets are the built-in status symbol sets. The ascii set is
+// domain.StatusSymbols; the nerd set needs a Nerd Font patched terminal font.
+var symbolSets = map[string]map[string]string{
+	SymbolSetASCII: asciiSymbols(),
+	SymbolSetEmoji: {
+		string(domain.StatusOpen):       "⚪",
+		string(domain.StatusInProgress): "🔵",
+		string(domain.StatusClosed):     "✅",
+		symbolUnknown:                   "❓",
+	},
+	SymbolSetNerd: {
+		string(domain.StatusOpen):       "\uf10c", // nf-fa-circle_o
+		string(domain.StatusInProgress): "\uf192", // nf-fa-dot_circle_o
+		string(domain.StatusClosed):     "\uf058", // nf-fa-check_circle
+		symbolUnknown:                   "\uf128", // nf-fa-question
+	},
+}
+
+// statusSymbols holds the active symbol per status and for symbolUnknown.
+var statusSymbols = asciiSymbols()
+
+// asciiSymbols returns domain.StatusSymbols keyed by status name.
+func asciiSymbols() map[string]string {
+	symbols := map[string]string{symbolUnknown: "[?]"}
+	for status, symbol := range domain.StatusSymbols {
+		symbols[string(status)] = symbol
+	}
+	return symbols
+}
+
+// setStatusSymbols activates the named symbol set (ascii when empty) with
+// overrides applied on top. Overrides are keyed by status or "unknown".
+func setStatusSymbols(set string, overrides map[string]string) error {
+	if set == "" {
+		set = SymbolSetASCII
+	}
+	base, ok := symbolSets[set]
+	if !ok {
+		return fmt.Errorf("invalid symbol_set %q: must be one of %s",
+			set, strings.Join(slices.Sorted(maps.Keys(symbolSets)), ", "))
+	}
+
+	symbols := maps.Clone(base)
+	for key, symbol := range overrides {
+		if key != symbolUnknown && !domain.Status(key).IsValid() {
+			return fmt.Errorf("invalid status_symbols key %q: must be a status or %s", key, symbolUnknown)
+		}
+		symbols[key] = symbol
+	}
+
+	statusSymbols = symbols
+	return nil
+}
+
+// statusIndicator returns a status indicator for display.
+func statusIndicator(status domain.Status) string {
+	if symbol, ok := statusSymbols[string(status)]; ok {
+		return symbol
+	}
+	return statusSymbols[symbolUnknown]
+}
+
+// asciiFlag replaces Unicode glyphs and symbols with plain ASCII and status words.
+var asciiFlag bool
+
+// treeGlyphs are the connectors dep tree draws between a ticket and its deps.
+type treeGlyphs struct {
+	branch, last, pipe string
+}
+
+var (
+	unicodeTreeGlyphs = treeGlyphs{branch: "├── ", last: "└── ", pipe: "│   "}
+	asciiTreeGlyphs   = treeGlyphs{branch: "|-- ", last: "`-- ", pipe: "|   "}
+)
+
+// treeConnectors holds the active dep tree connectors.
+var treeConnectors = unicodeTreeGlyphs
+
+// Heatmap shades from no closures to the busiest day. The ASCII shades are
+// levels a screen reader can announce.
+var (
+	unicodeHeatmapShades = []string{"·", "░", "▒", "▓", "█"}
+	asciiHeatmapShades   = []string{".", "1", "2", "3", "4"}
+)
+
+// setASCIIOutput switches dep tree connectors, the stats heatmap and status
+// symbols to plain ASCII when enabled, for screen readers and terminals
+// without Unicode. Status symbols become the status words, e.g. [in_progress],
+// overriding symbol_set and status_symbols.
+func setASCIIOutput(enabled bool) {
+	treeConnectors, heatmapShades = unicodeTreeGlyphs, unicodeHeatmapShades
+	if !enabled {
+		return
+	}
+	treeConnectors, heatmapShades = asciiTreeGlyphs, asciiHeatmapShades
+	words := map[string]string{symbolUnknown: "[" + symbolUnknown + "]"}
+	for status := range domain.StatusSymbols {
+		words[string(status)] = "[" + string(status) + "]"
+	}
+	statusSymbols = words
+}
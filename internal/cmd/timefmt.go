@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateFormats maps the named date formats accepted in config.yaml to layouts.
+var dateFormats = map[string]string{
+	"rfc3339":  time.RFC3339,
+	"datetime": time.DateTime,
+	"date":     time.DateOnly,
+}
+
+// Times are stored in UTC and shown in displayLocation using displayLayout.
+var (
+	displayLocation = time.Local
+	displayLayout   = time.RFC3339
+)
+
+// setDisplayTime sets the timezone and layout times are shown in. An empty
+// timezone means local time; format is a named format or a Go time layout,
+// defaulting to RFC3339.
+func setDisplayTime(timezone, format string) error {
+	loc := time.Local
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+	}
+
+	layout := time.RFC3339
+	if named, ok := dateFormats[format]; ok {
+		layout = named
+	} else if format != "" {
+		layout = format
+	}
+
+	displayLocation, displayLayout = loc, layout
+	return nil
+}
+
+// formatTime formats t in the display timezone and layout, or returns an
+// empty string for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(displayLocation).Format(displayLayout)
+}
+
+// formatDate formats a calendar date such as a due date. Dates are stored as
+// UTC midnight, so they are not converted to the display timezone.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.DateOnly)
+}
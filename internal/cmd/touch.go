@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+var touchCmd = &cobra.Command{
+	Use:     "touch <id> [reason]",
+	Aliases: []string{"ping"},
+	Short:   "Mark a ticket as still relevant",
+	Long: `Bump a ticket's updated-at timestamp and append a short "Touched" note with
+an optional reason, so the ticket shows up as recently active without a fake edit.
+
+Examples:
+  tk touch abc1
+  tk touch abc1 waiting on vendor fix`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticket, err := resolveAndReadTicket(args[0])
+		if err != nil {
+			return trWrap(msgResolveFailed, nil, err)
+		}
+
+		content := "Touched"
+		if reason := strings.TrimSpace(strings.Join(args[1:], " ")); reason != "" {
+			content += ": " + reason
+		}
+		ticket.Notes = append(ticket.Notes, domain.Note{
+			Timestamp: time.Now().UTC(),
+			Content:   content,
+		})
+
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+
+		fmt.Println(tr(msgTouched, map[string]any{"ID": ticket.ID}))
+		return nil
+	},
+}
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// triageSnoozeFileName is the file in the tickets directory recording which
+// tickets tk triage leaves out, and until when.
+const triageSnoozeFileName = ".triage-snoozed"
+
+// defaultTriageSnooze is how long tk triage snoozes a ticket by default.
+const defaultTriageSnooze = 7 * 24 * time.Hour
+
+var triageFlags struct {
+	snooze time.Duration
+}
+
+var triageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Step through tickets one at a time to triage them",
+	Long: `Show the matching tickets one at a time and act on each with a single key
+followed by Enter:
+
+  p  set the priority            t  add tags (-tag removes one)
+  a  assign (@me for you)        c  close and go to the next ticket
+  s  skip to the next ticket     z  snooze and go to the next ticket
+  q  quit
+
+Every change is written as soon as it is made, so quitting halfway loses
+nothing. Several of p, t and a can be applied to the same ticket before
+moving on.
+
+Filter flags narrow the queue; without --status, closed tickets are left out.
+Snoozed tickets are left out of tk triage until the snooze ends (--snooze,
+default 7d), which is recorded in .tickets/` + triageSnoozeFileName + `.
+
+Examples:
+  tk triage --untagged               # Sort out the intake queue
+  tk triage -t bug --unassigned      # Hand out unowned bugs
+  tk triage -T support --snooze 2w   # Snooze what can wait two weeks`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listFlags.Validate(); err != nil {
+			return err
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+		snoozed, err := readTriageSnoozes()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		var queue []*domain.Ticket
+		for _, t := range filterTickets(tickets, listFlags) {
+			if len(listFlags.Status) == 0 && t.Status == domain.StatusClosed {
+				continue
+			}
+			if until, ok := snoozed[t.ID]; ok && now.Before(until) {
+				continue
+			}
+			queue = append(queue, t)
+		}
+		sortTickets(queue, sortFlags)
+
+		if countFlag {
+			fmt.Println(len(queue))
+			return nil
+		}
+		if len(queue) == 0 {
+			fmt.Println(tr(msgTriageNothing, nil))
+			return nil
+		}
+
+		session := &triageSession{in: bufio.NewReader(cmd.InOrStdin()), out: os.Stdout, snoozed: snoozed, now: now}
+		if err := session.run(queue); err != nil {
+			return err
+		}
+		fmt.Println(tr(msgTriageSummary, map[string]any{
+			"Seen": session.seen, "Total": len(queue), "Updated": session.updated,
+			"Closed": session.closed, "Snoozed": session.snoozedCount, "Skipped": session.skipped,
+		}))
+		return nil
+	},
+}
+
+// errTriageQuit ends a triage session early.
+var errTriageQuit = errors.New("quit")
+
+// triageSession reads triage actions from in and keeps count of what they did.
+type triageSession struct {
+	in      *bufio.Reader
+	out     io.Writer
+	snoozed map[string]time.Time
+	now     time.Time
+
+	seen, updated, closed, snoozedCount, skipped int
+}
+
+// run steps through queue until it is done or the user quits.
+func (s *triageSession) run(queue []*domain.Ticket) error {
+	for i, t := range queue {
+		ticket, err := store.Read(t.ID)
+		if err != nil {
+			return err
+		}
+		s.seen++
+		_, _ = fmt.Fprintf(s.out, "\n[%d/%d] %s\n", i+1, len(queue), formatTicketLine(ticket))
+		if err := s.show(ticket); err != nil {
+			return err
+		}
+		if err := s.triage(ticket); err != nil {
+			if errors.Is(err, errTriageQuit) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// show prints ticket as tk show does, without relationships.
+func (s *triageSession) show(ticket *domain.Ticket) error {
+	withoutNotes := *ticket
+	withoutNotes.Notes = nil
+	content, err := withoutNotes.Render()
+	if err != nil {
+		return fmt.Errorf("failed to render ticket: %w", err)
+	}
+	_, err = fmt.Fprint(s.out, displayFrontmatterTimes(string(content), ticket)+renderNoteThreads(ticket))
+	return err
+}
+
+// triage prompts for actions on ticket until one moves on to the next ticket.
+func (s *triageSession) triage(ticket *domain.Ticket) error {
+	// Every write bumps the revision
+	read := ticket.Revision
+	for {
+		choice, ok := s.prompt(tr(msgTriageActions, nil))
+		if !ok {
+			return errTriageQuit
+		}
+
+		var err error
+		switch choice {
+		case "p":
+			err = s.prioritize(ticket)
+		case "t":
+			err = s.tag(ticket)
+		case "a":
+			err = s.assign(ticket)
+		case "c":
+			ticket.SetStatus(domain.StatusClosed, time.Now().UTC())
+			if err = s.write(ticket); err == nil {
+				_, _ = fmt.Fprintln(s.out, tr(msgUpdatedStatus, map[string]any{"ID": ticket.ID, "Status": domain.StatusClosed}))
+				s.closed++
+				return nil
+			}
+		case "s", "":
+			if ticket.Revision != read {
+				s.updated++
+			} else {
+				s.skipped++
+			}
+			return nil
+		case "z":
+			snooze := triageFlags.snooze
+			if snooze == 0 {
+				snooze = defaultTriageSnooze
+			}
+			until := s.now.Add(snooze)
+			s.snoozed[ticket.ID] = until
+			if err = writeTriageSnoozes(s.snoozed, s.now); err == nil {
+				_, _ = fmt.Fprintln(s.out, tr(msgTriageSnoozed, map[string]any{"ID": ticket.ID, "Until": formatTime(until)}))
+				s.snoozedCount++
+				return nil
+			}
+		case "q":
+			if ticket.Revision != read {
+				s.updated++
+			}
+			return errTriageQuit
+		default:
+			_, _ = fmt.Fprintln(s.out, tr(msgTriageUnknownAction, map[string]any{"Action": strconv.Quote(choice)}))
+			continue
+		}
+
+		if err != nil {
+			_, _ = fmt.Fprintln(s.out, tr(msgErrorPrefix, nil), err)
+			// Start over from the stored ticket, which the failed change did not reach
+			fresh, readErr := store.Read(ticket.ID)
+			if readErr != nil {
+				return readErr
+			}
+			*ticket = *fresh
+		}
+	}
+}
+
+// prompt writes question and returns the trimmed answer, or false at the end
+// of input.
+func (s *triageSession) prompt(question string) (string, bool) {
+	_, _ = fmt.Fprint(s.out, question)
+	line, err := s.in.ReadString('\n')
+	if err != nil && line == "" {
+		_, _ = fmt.Fprintln(s.out)
+		return "", false
+	}
+	return strings.TrimSpace(line), true
+}
+
+func (s *triageSession) prioritize(ticket *domain.Ticket) error {
+	answer, ok := s.prompt(tr(msgTriagePriorityPrompt, map[string]any{"Min": domain.MinPriority, "Max": domain.MaxPriority, "Current": ticket.Priority}))
+	if !ok || answer == "" {
+		return nil
+	}
+	priority, err := strconv.Atoi(answer)
+	if err != nil || priority < domain.MinPriority || priority > domain.MaxPriority {
+		return trError(msgInvalidPriority, map[string]any{"Priority": answer, "Min": domain.MinPriority, "Max": domain.MaxPriority}, nil)
+	}
+	ticket.Priority = priority
+	if err := s.write(ticket); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(s.out, tr(msgTriagePrioritySet, map[string]any{"ID": ticket.ID, "Priority": priority}))
+	return nil
+}
+
+func (s *triageSession) tag(ticket *domain.Ticket) error {
+	answer, ok := s.prompt(tr(msgTriageTagsPrompt, nil))
+	if !ok || answer == "" {
+		return nil
+	}
+	for _, tag := range strings.Split(answer, ",") {
+		tag = strings.TrimSpace(tag)
+		if removed, ok := strings.CutPrefix(tag, "-"); ok {
+			ticket.Tags = slices.DeleteFunc(ticket.Tags, func(t string) bool { return t == removed })
+		} else if tag != "" && !slices.Contains(ticket.Tags, tag) {
+			ticket.Tags = append(ticket.Tags, tag)
+		}
+	}
+	if err := s.write(ticket); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(s.out, tr(msgTriageTagged, map[string]any{"ID": ticket.ID, "Tags": strings.Join(ticket.Tags, ", ")}))
+	return nil
+}
+
+func (s *triageSession) assign(ticket *domain.Ticket) error {
+	answer, ok := s.prompt(tr(msgTriageAssignPrompt, map[string]any{"Me": meAlias, "Current": ticket.Assignee}))
+	if !ok || answer == "" {
+		return nil
+	}
+	assignee := ""
+	if answer != "-" {
+		var err error
+		if assignee, err = resolveMe(answer); err != nil {
+			return err
+		}
+	}
+	ticket.Assignee = assignee
+	if err := s.write(ticket); err != nil {
+		return err
+	}
+	if assignee == "" {
+		_, _ = fmt.Fprintln(s.out, tr(msgTriageUnassigned, map[string]any{"ID": ticket.ID}))
+	} else {
+		_, _ = fmt.Fprintln(s.out, tr(msgTriageAssigned, map[string]any{"ID": ticket.ID, "Assignee": assignee}))
+	}
+	return nil
+}
+
+// write saves ticket, checking policies like any other update.
+func (s *triageSession) write(ticket *domain.Ticket) error {
+	if err := enforcePolicy(ticket); err != nil {
+		return trWrap(msgTriageCannotUpdate, map[string]any{"ID": ticket.ID}, err)
+	}
+	if err := store.Write(ticket); err != nil {
+		return fmt.Errorf("failed to update ticket: %w", err)
+	}
+	return nil
+}
+
+// triageSnoozePath returns the path of the triage snooze file.
+func triageSnoozePath() string {
+	return filepath.Join(store.TicketsDir(), triageSnoozeFileName)
+}
+
+// readTriageSnoozes returns when each snoozed ticket comes back to triage.
+func readTriageSnoozes() (map[string]time.Time, error) {
+	snoozed := map[string]time.Time{}
+	data, err := os.ReadFile(triageSnoozePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return snoozed, nil
+		}
+		return nil, fmt.Errorf("failed to read triage snoozes: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &snoozed); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", triageSnoozeFileName, err)
+	}
+	return snoozed, nil
+}
+
+// writeTriageSnoozes records snoozed, dropping snoozes that ended before now.
+func writeTriageSnoozes(snoozed map[string]time.Time, now time.Time) error {
+	for id, until := range snoozed {
+		if !now.Before(until) {
+			delete(snoozed, id)
+		}
+	}
+	data, err := yaml.Marshal(snoozed)
+	if err != nil {
+		return fmt.Errorf("failed to encode triage snoozes: %w", err)
+	}
+	if err := os.WriteFile(triageSnoozePath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write triage snoozes: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	addFilterFlags(triageCmd, true)
+	triageCmd.Flags().Var(durationValue{&triageFlags.snooze}, "snooze", "How long z snoozes a ticket (e.g. 3d, 2w) (default 7d)")
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// unblockedMarkerFileName is the file in the tickets directory recording,
+// per user, when tk unblocked --since-last last ran.
+const unblockedMarkerFileName = ".unblocked-checked.yaml"
+
+// defaultUnblockedWindow is the window used when neither --since nor
+// --since-last is given.
+const defaultUnblockedWindow = 24 * time.Hour
+
+var unblockedFlags struct {
+	since     time.Time
+	sinceLast bool
+}
+
+var unblockedCmd = &cobra.Command{
+	Use:   "unblocked",
+	Short: "List tickets that became unblocked recently",
+	Long: `List open tickets whose last blocking dependency was closed within a window,
+so newly actionable work is noticed right away. The window defaults to the
+last 24h.
+
+With --since-last the window starts at your previous --since-last run (or the
+beginning of time on the first run), and the check time is recorded in
+.tickets/` + unblockedMarkerFileName + `.
+
+Examples:
+  tk unblocked --since 3d           # Unblocked in the last three days
+  tk unblocked --since-last -a @me  # My tickets unblocked since I last looked`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listFlags.Validate(); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		since := unblockedFlags.since
+		if unblockedFlags.sinceLast {
+			var err error
+			if since, err = readUnblockedMarker(currentUser()); err != nil {
+				return err
+			}
+		} else if since.IsZero() {
+			since = now.Add(-defaultUnblockedWindow)
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+		archived, err := store.Archive().List()
+		if err != nil {
+			return err
+		}
+
+		var result []*domain.Ticket
+		for _, t := range newlyUnblocked(tickets, archived, since) {
+			if listFlags.Matches(t) {
+				result = append(result, t)
+			}
+		}
+		sortTickets(result, sortFlags)
+
+		if err := printTickets(result); err != nil {
+			return err
+		}
+
+		if unblockedFlags.sinceLast {
+			return writeUnblockedMarker(currentUser(), now)
+		}
+		return nil
+	},
+}
+
+// newlyUnblocked returns the unclosed tickets in tickets whose dependencies are
+// all closed, the last of them at or after since. Closed dependencies may
+// live in tickets or archived.
+func newlyUnblocked(tickets, archived []*domain.Ticket, since time.Time) []*domain.Ticket {
+	closedAt := make(map[string]time.Time)
+	for _, t := range append(archived, tickets...) {
+		if t.Status == domain.StatusClosed {
+			closedAt[t.ID] = t.ClosedAt
+		}
+	}
+	openIDs := buildOpenIDSet(tickets)
+
+	var result []*domain.Ticket
+	for _, t := range tickets {
+		if t.Status == domain.StatusClosed || len(t.Deps) == 0 {
+			continue
+		}
+
+		var unblockedAt time.Time
+		blocked := false
+		for _, dep := range t.Deps {
+			if openIDs[dep] {
+				blocked = true
+				break
+			}
+			if closedAt[dep].After(unblockedAt) {
+				unblockedAt = closedAt[dep]
+			}
+		}
+
+		if !blocked && !unblockedAt.IsZero() && !unblockedAt.Before(since) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// unblockedMarkerPath returns the path of the --since-last marker file.
+func unblockedMarkerPath() string {
+	return filepath.Join(store.TicketsDir(), unblockedMarkerFileName)
+}
+
+// readUnblockedMarkers reads the last check time of every user.
+func readUnblockedMarkers() (map[string]time.Time, error) {
+	markers := make(map[string]time.Time)
+	data, err := os.ReadFile(unblockedMarkerPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return markers, nil
+		}
+		return nil, fmt.Errorf("failed to read unblocked marker: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &markers); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", unblockedMarkerFileName, err)
+	}
+	if markers == nil {
+		markers = make(map[string]time.Time)
+	}
+	return markers, nil
+}
+
+// readUnblockedMarker returns when user last ran --since-last, or the zero
+// time if they never have.
+func readUnblockedMarker(user string) (time.Time, error) {
+	markers, err := readUnblockedMarkers()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return markers[user], nil
+}
+
+// writeUnblockedMarker records t as user's last --since-last check.
+func writeUnblockedMarker(user string, t time.Time) error {
+	markers, err := readUnblockedMarkers()
+	if err != nil {
+		return err
+	}
+	markers[user] = t
+
+	data, err := yaml.Marshal(markers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal unblocked marker: %w", err)
+	}
+	if err := os.WriteFile(unblockedMarkerPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write unblocked marker: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	addFilterFlags(unblockedCmd, false)
+	unblockedCmd.Flags().Var(timeValue{&unblockedFlags.since}, "since", "Only tickets unblocked within duration or after time (default 24h)")
+	unblockedCmd.Flags().BoolVar(&unblockedFlags.sinceLast, "since-last", false, "Only tickets unblocked since your previous --since-last run")
+	unblockedCmd.MarkFlagsMutuallyExclusive("since", "since-last")
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/storage"
+)
+
+var undoFlags struct {
+	show bool
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [n]",
+	Short: "Revert your last mutation(s)",
+	Long: `Revert the last n mutations (default 1) you made, restoring each ticket's
+previous file contents from the event journal. Created tickets are deleted.
+
+Only your own changes are reverted. If someone else has changed an affected
+ticket since, or its file was changed without being journaled (e.g. edited by
+hand), undo refuses rather than overwrite that work.
+
+Examples:
+  tk undo              # Revert the last change
+  tk undo 5            # Revert the last 5 changes (e.g. a bulk close)
+  tk undo 5 --show     # Preview what would be reverted`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n := 1
+		if len(args) == 1 {
+			var err error
+			n, err = strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return trError(msgUndoInvalidCount, map[string]any{"Count": strconv.Quote(args[0])}, nil)
+			}
+		}
+
+		plan, err := store.PlanUndo(store.Actor(), n)
+		if err != nil {
+			return err
+		}
+
+		if len(plan) == 0 {
+			fmt.Println(tr(msgUndoNothing, nil))
+			return nil
+		}
+
+		if undoFlags.show {
+			return runWithPager(func(w io.Writer) error {
+				for _, ev := range plan {
+					if _, err := fmt.Fprintln(w, formatEvent(ev)); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+
+		for _, ev := range plan {
+			if err := store.Revert(ev); err != nil {
+				return err
+			}
+			fmt.Println(tr(msgUndoReverted, map[string]any{"Event": formatEvent(ev)}))
+		}
+		return nil
+	},
+}
+
+// formatEvent renders a journal event as a one-line summary.
+func formatEvent(ev storage.Event) string {
+	line := fmt.Sprintf("%s %s %s", ev.Time.In(displayLocation).Format(time.DateTime), ev.Ticket, ev.Action)
+	if changes := formatChanges(ev); changes != "" {
+		line += " (" + changes + ")"
+	}
+	return line
+}
+
+// formatChanges summarizes an event's field changes, showing old and new
+// values for scalar fields and only the name for lists.
+func formatChanges(ev storage.Event) string {
+	fields := make([]string, 0, len(ev.Changes))
+	for name := range ev.Changes {
+		fields = append(fields, name)
+	}
+	slices.Sort(fields)
+
+	var parts []string
+	for _, name := range fields {
+		change := ev.Changes[name]
+		switch change.To.(type) {
+		case string, float64, int:
+			parts = append(parts, fmt.Sprintf("%s: %s -> %s", name, formatChangeValue(change.From), formatChangeValue(change.To)))
+		default:
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatChangeValue renders a scalar journal value, showing unset values as "-".
+func formatChangeValue(v any) string {
+	if v == nil || v == "" {
+		return "-"
+	}
+	return fmt.Sprint(v)
+}
+
+func init() {
+	undoCmd.Flags().BoolVar(&undoFlags.show, "show", false, "Preview what would be reverted without changing anything")
+}
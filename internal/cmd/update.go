@@ -5,6 +5,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/spf13/cobra"
@@ -20,8 +22,20 @@ import (
 const (
 	repoOwner = "radutopala"
 	repoName  = "ticket"
+
+	// oldBinarySuffix is appended to the replaced binary, which Windows
+	// cannot delete while it is still running.
+	oldBinarySuffix = ".old"
+	// updateLockSuffix names the lock file that keeps two updates from racing.
+	updateLockSuffix = ".update.lock"
+	// updateLockStale is how old an update lock must be to be considered
+	// left behind by a crashed update.
+	updateLockStale = 10 * time.Minute
 )
 
+// errUpdateInProgress is returned when another tk update holds the update lock.
+var errUpdateInProgress = errors.New("another tk update is in progress")
+
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update tk to the latest version",
@@ -73,6 +87,12 @@ func doUpdate() error {
 		return fmt.Errorf("failed to resolve symlinks: %w", err)
 	}
 
+	release, err := acquireUpdateLock(exe + updateLockSuffix)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Download and extract new binary
 	if err := downloadAndReplace(latestVersion, exe); err != nil {
 		return fmt.Errorf("failed to update: %w", err)
@@ -146,8 +166,9 @@ func downloadAndReplace(version, exePath string) error {
 		return fmt.Errorf("download failed: %s", resp.Status)
 	}
 
-	// Create temp file for new binary
-	tmpFile, err := os.CreateTemp("", "tk-update-*")
+	// Create temp file for new binary next to the old one, so the final
+	// rename does not cross filesystems
+	tmpFile, err := os.CreateTemp(filepath.Dir(exePath), ".tk-update-*")
 	if err != nil {
 		return err
 	}
@@ -173,24 +194,92 @@ func downloadAndReplace(version, exePath string) error {
 		return err
 	}
 
-	// Replace old binary
-	oldPath := exePath + ".old"
+	return replaceExecutable(tmpPath, exePath)
+}
+
+// replaceExecutable moves newPath over exePath. Windows refuses to delete or
+// overwrite a running binary but allows renaming it, so the current binary is
+// first renamed aside and only removed if possible; a leftover is removed by
+// removeOldBinary on the next start.
+func replaceExecutable(newPath, exePath string) error {
+	oldPath := exePath + oldBinarySuffix
+	if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove previous %s: %w", oldPath, err)
+	}
+
 	if err := os.Rename(exePath, oldPath); err != nil {
 		return err
 	}
 
-	if err := os.Rename(tmpPath, exePath); err != nil {
+	if err := os.Rename(newPath, exePath); err != nil {
 		// Try to restore old binary
 		os.Rename(oldPath, exePath)
 		return err
 	}
 
-	// Remove old binary
+	// Fails on Windows while the old binary is still running
 	os.Remove(oldPath)
 
 	return nil
 }
 
+// removeOldBinary deletes a binary left behind by an earlier update of the
+// running executable.
+func removeOldBinary() {
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err == nil {
+		_ = os.Remove(exe + oldBinarySuffix)
+	}
+}
+
+// acquireUpdateLock creates the lock file at path, failing with
+// errUpdateInProgress if another update holds it. A lock older than
+// updateLockStale is assumed abandoned and taken over. The returned function
+// releases the lock.
+func acquireUpdateLock(path string) (func(), error) {
+	for attempt := 0; ; attempt++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create update lock: %w", err)
+		}
+
+		info, statErr := os.Stat(path)
+		if attempt > 0 || statErr != nil || time.Since(info.ModTime()) < updateLockStale ||
+			!removeStaleLock(path) {
+			return nil, fmt.Errorf("%w (remove %s if it is not)", errUpdateInProgress, path)
+		}
+	}
+}
+
+// removeStaleLock removes the lock at path if it is still older than
+// updateLockStale, reporting whether it did. Several updates may have found
+// the same lock stale, and by now one of them may have replaced it with its
+// own, so the lock is first renamed to a name of this process's own and
+// checked there: a fresh lock is put back instead of being removed.
+func removeStaleLock(path string) bool {
+	taken := fmt.Sprintf("%s.%d", path, os.Getpid())
+	if err := os.Rename(path, taken); err != nil {
+		// Another update took it over first
+		return false
+	}
+	defer os.Remove(taken)
+
+	info, err := os.Stat(taken)
+	if err != nil || time.Since(info.ModTime()) < updateLockStale {
+		os.Link(taken, path)
+		return false
+	}
+	return true
+}
+
 func extractTarGz(r io.Reader, w io.Writer) error {
 	gzr, err := gzip.NewReader(r)
 	if err != nil {
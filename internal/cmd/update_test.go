@@ -5,7 +5,10 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -243,3 +246,74 @@ func (s *UpdateSuite) TestExtractZipInvalidArchive() {
 	err := extractZip(bytes.NewReader([]byte("not a zip")), &out)
 	require.Error(s.T(), err)
 }
+
+func (s *UpdateSuite) TestReplaceExecutable() {
+	dir := s.T().TempDir()
+	exe := filepath.Join(dir, "tk")
+	newBinary := filepath.Join(dir, ".tk-update-1")
+	require.NoError(s.T(), os.WriteFile(exe, []byte("old"), 0755))
+	require.NoError(s.T(), os.WriteFile(newBinary, []byte("new"), 0755))
+	// A leftover from an earlier update must not block the rename
+	require.NoError(s.T(), os.WriteFile(exe+oldBinarySuffix, []byte("older"), 0755))
+
+	require.NoError(s.T(), replaceExecutable(newBinary, exe))
+
+	data, err := os.ReadFile(exe)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "new", string(data))
+	require.NoFileExists(s.T(), exe+oldBinarySuffix)
+	require.NoFileExists(s.T(), newBinary)
+}
+
+func (s *UpdateSuite) TestReplaceExecutableRestoresOnFailure() {
+	dir := s.T().TempDir()
+	exe := filepath.Join(dir, "tk")
+	require.NoError(s.T(), os.WriteFile(exe, []byte("old"), 0755))
+
+	require.Error(s.T(), replaceExecutable(filepath.Join(dir, "missing"), exe))
+
+	data, err := os.ReadFile(exe)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "old", string(data))
+}
+
+func (s *UpdateSuite) TestAcquireUpdateLock() {
+	path := filepath.Join(s.T().TempDir(), "tk"+updateLockSuffix)
+
+	release, err := acquireUpdateLock(path)
+	require.NoError(s.T(), err)
+
+	_, err = acquireUpdateLock(path)
+	require.ErrorIs(s.T(), err, errUpdateInProgress)
+
+	release()
+	release, err = acquireUpdateLock(path)
+	require.NoError(s.T(), err)
+	defer release()
+
+	// An abandoned lock is taken over
+	stale := time.Now().Add(-2 * updateLockStale)
+	require.NoError(s.T(), os.Chtimes(path, stale, stale))
+	again, err := acquireUpdateLock(path)
+	require.NoError(s.T(), err)
+	again()
+	require.NoFileExists(s.T(), path)
+}
+
+func (s *UpdateSuite) TestRemoveStaleLockKeepsFreshLock() {
+	path := filepath.Join(s.T().TempDir(), "tk"+updateLockSuffix)
+
+	// Another update found the lock stale first and replaced it with its own
+	require.NoError(s.T(), os.WriteFile(path, []byte("4242\n"), 0644))
+	require.False(s.T(), removeStaleLock(path))
+	data, err := os.ReadFile(path)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "4242\n", string(data))
+
+	stale := time.Now().Add(-2 * updateLockStale)
+	require.NoError(s.T(), os.Chtimes(path, stale, stale))
+	require.True(s.T(), removeStaleLock(path))
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), entries)
+}
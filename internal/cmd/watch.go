@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radutopala/ticket/internal/config"
+	"github.com/radutopala/ticket/internal/domain"
+	"github.com/radutopala/ticket/internal/storage"
+)
+
+// watchlistDefaultSince is how far back tk watchlist shows activity by default.
+const watchlistDefaultSince = 7 * 24 * time.Hour
+
+var watchlistFlags struct {
+	user  string
+	since time.Time
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Manage ticket watchers",
+	Long: `Follow tickets you are not assigned to. Watchers are stored in the ticket's
+frontmatter, listed by tk watchlist, and passed to the command in
+TK_NOTIFY_COMMAND when a watched ticket changes.`,
+}
+
+var watchAddCmd = &cobra.Command{
+	Use:   "add <id> [@user]",
+	Short: "Watch a ticket (you by default)",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticket, user, err := resolveWatchArgs(args)
+		if err != nil {
+			return err
+		}
+		if isWatching(ticket, user) {
+			fmt.Println(tr(msgWatchAlready, map[string]any{"User": user, "ID": ticket.ID}))
+			return nil
+		}
+
+		ticket.Watchers = append(ticket.Watchers, user)
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+		fmt.Println(tr(msgWatchAdded, map[string]any{"User": user, "ID": ticket.ID}))
+		return nil
+	},
+}
+
+var watchRemoveCmd = &cobra.Command{
+	Use:     "remove <id> [@user]",
+	Aliases: []string{"rm"},
+	Short:   "Stop watching a ticket",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticket, user, err := resolveWatchArgs(args)
+		if err != nil {
+			return err
+		}
+		if !isWatching(ticket, user) {
+			return trError(msgWatchNotWatching, map[string]any{"User": user, "ID": ticket.ID}, nil)
+		}
+
+		ticket.Watchers = slices.DeleteFunc(ticket.Watchers, func(w string) bool {
+			return watcherMatches(w, user)
+		})
+		if err := store.Write(ticket); err != nil {
+			return err
+		}
+		fmt.Println(tr(msgWatchRemoved, map[string]any{"User": user, "ID": ticket.ID}))
+		return nil
+	},
+}
+
+var watchlistCmd = &cobra.Command{
+	Use:   "watchlist",
+	Short: "Show recent activity on watched tickets",
+	Long: `List the tickets a user watches (you by default), each followed by its
+journal events since --since, oldest first.
+
+Examples:
+  tk watchlist                       # Your watched tickets, last 7 days
+  tk watchlist --user @alice --since 24h`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		user := strings.TrimPrefix(watchlistFlags.user, "@")
+		if user == "" {
+			user = currentUser()
+		}
+		if user == "" {
+			return trError(msgNoCurrentUser, nil, nil)
+		}
+		since := watchlistFlags.since
+		if since.IsZero() {
+			since = time.Now().Add(-watchlistDefaultSince)
+		}
+
+		tickets, err := store.List()
+		if err != nil {
+			return err
+		}
+		var watched []*domain.Ticket
+		for _, t := range tickets {
+			if isWatching(t, user) {
+				watched = append(watched, t)
+			}
+		}
+		if len(watched) == 0 {
+			fmt.Println(tr(msgWatchlistEmpty, map[string]any{"User": user}))
+			return nil
+		}
+		sortTickets(watched, SortOptions{})
+
+		events, err := store.ReadJournal()
+		if err != nil {
+			return err
+		}
+		recent := make(map[string][]storage.Event)
+		for _, ev := range events {
+			if !ev.Time.Before(since) {
+				recent[ev.Ticket] = append(recent[ev.Ticket], ev)
+			}
+		}
+
+		return runWithPager(func(w io.Writer) error {
+			return outputWatchlistText(w, watched, recent)
+		})
+	},
+}
+
+func outputWatchlistText(w io.Writer, watched []*domain.Ticket, recent map[string][]storage.Event) error {
+	var buf strings.Builder
+	for _, t := range watched {
+		buf.WriteString(formatTicketLine(t) + "\n")
+		if len(recent[t.ID]) == 0 {
+			buf.WriteString("  " + tr(msgWatchlistIdle, nil) + "\n")
+		}
+		for _, ev := range recent[t.ID] {
+			line := fmt.Sprintf("  %s  %s", ev.Time.In(displayLocation).Format("2006-01-02 15:04"), describeEvent(ev))
+			if ev.Actor != "" {
+				line += "  (" + ev.Actor + ")"
+			}
+			buf.WriteString(line + "\n")
+		}
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// resolveWatchArgs reads the ticket named by args[0] and returns it with the
+// watcher named by args[1], or the current user.
+func resolveWatchArgs(args []string) (*domain.Ticket, string, error) {
+	ticket, err := resolveAndReadTicket(args[0])
+	if err != nil {
+		return nil, "", trWrap(msgResolveFailed, nil, err)
+	}
+
+	user := meAlias
+	if len(args) > 1 {
+		user = args[1]
+	}
+	user, err = resolveMe(user)
+	if err != nil {
+		return nil, "", err
+	}
+	user = strings.TrimPrefix(user, "@")
+	if user == "" {
+		return nil, "", trError(msgWatchEmptyUser, nil, nil)
+	}
+	return ticket, user, nil
+}
+
+// isWatching reports whether user watches t.
+func isWatching(t *domain.Ticket, user string) bool {
+	return slices.ContainsFunc(t.Watchers, func(w string) bool {
+		return watcherMatches(w, user)
+	})
+}
+
+// watcherMatches reports whether a stored watcher is user, given either as a
+// full name or as a mention handle.
+func watcherMatches(watcher, user string) bool {
+	return strings.EqualFold(watcher, user) || domain.MentionMatches(watcher, user) || domain.MentionMatches(user, watcher)
+}
+
+// ticketChange is a change to a ticket file awaiting watcher notification.
+type ticketChange struct {
+	id, path      string
+	before, after []byte
+}
+
+// pendingNotifications holds the changes of the running command, notified
+// by runPendingNotifications when it finishes.
+var pendingNotifications []ticketChange
+
+// runPendingNotifications runs the notify command for each ticket file changed
+// by the command that just finished, after it released its ticket locks. A
+// file changed several times is notified once with its net change, so a
+// change that WithLock rolled back is not notified at all.
+func runPendingNotifications() {
+	changes := pendingNotifications
+	pendingNotifications = nil
+
+	var net []ticketChange
+	for _, c := range changes {
+		i := slices.IndexFunc(net, func(n ticketChange) bool { return n.path == c.path })
+		if i < 0 {
+			net = append(net, c)
+			continue
+		}
+		net[i].after = c.after
+	}
+	for _, c := range net {
+		if !bytes.Equal(c.before, c.after) {
+			notifyWatchers(cfg.NotifyCommand, c.id, c.before, c.after)
+		}
+	}
+}
+
+// notifyWatchers runs command after a change to a watched ticket, unless the
+// change only touched the watchers. The command gets TK_TICKET, TK_TITLE,
+// TK_STATUS, TK_EVENT (created, updated or deleted), TK_CHANGES (the changed
+// fields), TK_ACTOR and TK_WATCHERS (comma separated, excluding the actor).
+// Failures are reported on stderr since the change itself succeeded.
+func notifyWatchers(command, id string, before, after []byte) {
+	var old, cur *domain.Ticket
+	if before != nil {
+		old, _ = domain.Parse(before)
+	}
+	if after != nil {
+		cur, _ = domain.Parse(after)
+	}
+	t := cmp.Or(cur, old)
+	if t == nil {
+		return
+	}
+
+	event := "updated"
+	var changed []string
+	switch {
+	case old == nil:
+		event = "created"
+	case cur == nil:
+		event = "deleted"
+	default:
+		changes := storage.Changes(old, cur)
+		delete(changes, "watchers")
+		if len(changes) == 0 {
+			return
+		}
+		changed = slices.Sorted(maps.Keys(changes))
+	}
+
+	actor := store.Actor()
+	watchers := slices.DeleteFunc(slices.Clone(t.Watchers), func(w string) bool {
+		return actor != "" && watcherMatches(w, actor)
+	})
+	if len(watchers) == 0 {
+		return
+	}
+
+	hook := shellCommand(command)
+	hook.Env = append(os.Environ(),
+		"TK_TICKET="+id,
+		"TK_TITLE="+t.Title,
+		"TK_STATUS="+string(t.Status),
+		"TK_EVENT="+event,
+		"TK_CHANGES="+strings.Join(changed, ","),
+		"TK_ACTOR="+actor,
+		"TK_WATCHERS="+strings.Join(watchers, ","),
+	)
+	// Keep stdout for the command's own output
+	hook.Stdout = os.Stderr
+	hook.Stderr = os.Stderr
+	if err := hook.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, tr(msgWarningPrefix, nil), tr(msgNotifyFailed, map[string]any{"Command": config.EnvNotifyCommand, "ID": id, "Error": err}))
+	}
+}
+
+// shellCommand returns a command running line in the platform's shell:
+// %ComSpec% /C on Windows and sh -c elsewhere.
+func shellCommand(line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command(cmp.Or(os.Getenv("ComSpec"), "cmd.exe"), "/C", line)
+	}
+	return exec.Command("sh", "-c", line)
+}
+
+func init() {
+	cobra.OnFinalize(runPendingNotifications)
+	watchCmd.AddCommand(watchAddCmd)
+	watchCmd.AddCommand(watchRemoveCmd)
+	watchlistCmd.Flags().StringVar(&watchlistFlags.user, "user", "", "Show tickets watched by user (default: you)")
+	watchlistCmd.Flags().Var(timeValue{&watchlistFlags.since}, "since", "Only activity within duration or after time [default: 7d]")
+	addLineFormatFlag(watchlistCmd)
+}
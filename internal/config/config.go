@@ -5,21 +5,102 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
 )
 
 const (
 	// EnvTicketsDir is the environment variable for the tickets directory.
 	EnvTicketsDir = "TICKETS_DIR"
+	// EnvUser is the environment variable overriding the current user's identity.
+	EnvUser = "TK_USER"
+	// EnvLang is the environment variable overriding the language config key.
+	EnvLang = "TK_LANG"
+	// EnvHealthWarnings is the environment variable overriding the
+	// health_warnings config key, e.g. 0 to silence them.
+	EnvHealthWarnings = "TK_HEALTH_WARNINGS"
+	// EnvNotifyCommand is the environment variable holding the shell command
+	// run after changes to watched tickets.
+	EnvNotifyCommand = "TK_NOTIFY_COMMAND"
 	// DefaultTicketsDir is the default directory for tickets.
 	DefaultTicketsDir = ".tickets"
+	// FileName is the name of the optional config file inside the tickets directory.
+	FileName = "config.yaml"
 )
 
 // Config holds the application configuration.
 type Config struct {
-	TicketsDir string
+	TicketsDir string `yaml:"-"`
+	// User is the identity from TK_USER; empty means fall back to git user.name.
+	User string `yaml:"-"`
+
+	// LineFormat is a text/template used to render one-line ticket summaries.
+	LineFormat string `yaml:"line_format"`
+
+	// SymbolSet names the built-in status symbol set: ascii (default), emoji or nerd.
+	SymbolSet string `yaml:"symbol_set"`
+	// StatusSymbols overrides the symbol of individual statuses, or of "unknown".
+	StatusSymbols map[string]string `yaml:"status_symbols"`
+	// ASCII replaces box-drawing characters, shades and status symbols with
+	// plain ASCII and status words, like the --ascii flag.
+	ASCII bool `yaml:"ascii"`
+
+	// Timezone is the IANA timezone times are shown in, e.g. Europe/Berlin;
+	// empty means local time. Times are always stored in UTC.
+	Timezone string `yaml:"timezone"`
+	// DateFormat is how times are shown: rfc3339 (default), datetime, date, or a Go time layout.
+	DateFormat string `yaml:"date_format"`
+
+	// Language is the BCP 47 tag of the output language, e.g. de or pt-BR;
+	// empty means the LC_ALL, LC_MESSAGES or LANG locale. English is built in,
+	// other languages come from catalogs in the locales directory.
+	Language string `yaml:"language"`
+
+	// HealthWarnings prints a warning on every command when the tickets
+	// directory is missing, shadowed by one further up, or very large.
+	// Defaults to true.
+	HealthWarnings bool `yaml:"health_warnings"`
+
+	// NotifyCommand is a shell command run after changes to watched tickets,
+	// with the ticket and its watchers in TK_* environment variables. It comes
+	// only from TK_NOTIFY_COMMAND, so a committed config file cannot make tk
+	// run commands for everyone who clones the repository.
+	NotifyCommand string `yaml:"-"`
+
+	// WIPLimit is the maximum number of in_progress tickets per assignee; 0 means no limit.
+	WIPLimit int `yaml:"wip_limit"`
+	// WIPLimitTags maps tags to the maximum number of in_progress tickets carrying them.
+	WIPLimitTags map[string]int `yaml:"wip_limit_tags"`
+
+	// Policies maps ticket types to the field requirements their tickets must meet.
+	Policies map[string]TypePolicy `yaml:"policies"`
+
+	// IDPrefixes maps ticket types to the prefix of newly generated IDs (e.g. bug: bug).
+	IDPrefixes map[string]string `yaml:"id_prefixes"`
+
+	// Retention controls how long closed tickets stay in the working set.
+	Retention Retention `yaml:"retention"`
+}
+
+// Retention is the closed-ticket retention policy enforced by tk gc.
+type Retention struct {
+	// Closed is how long tickets stay after closing, e.g. 180d; empty keeps them.
+	Closed string `yaml:"closed"`
+	// Action is what happens to expired tickets: archive (default) or delete.
+	Action string `yaml:"action"`
+}
+
+// TypePolicy declares field requirements for tickets of one type.
+type TypePolicy struct {
+	// Required lists fields that must be non-empty (e.g. acceptance, assignee).
+	Required []string `yaml:"required"`
+	// MaxPriority is the lowest allowed priority (highest number), if set.
+	MaxPriority *int `yaml:"max_priority"`
 }
 
-// Load reads configuration from environment variables.
+// Load reads configuration from environment variables and the optional
+// config file in the tickets directory.
 func Load() (*Config, error) {
 	ticketsDir := os.Getenv(EnvTicketsDir)
 	if ticketsDir == "" {
@@ -31,7 +112,49 @@ func Load() (*Config, error) {
 		ticketsDir = filepath.Join(cwd, DefaultTicketsDir)
 	}
 
-	return &Config{
-		TicketsDir: ticketsDir,
-	}, nil
+	cfg := &Config{HealthWarnings: true}
+	if err := cfg.loadFile(filepath.Join(ticketsDir, FileName)); err != nil {
+		return nil, err
+	}
+	cfg.TicketsDir = ticketsDir
+	cfg.User = os.Getenv(EnvUser)
+	cfg.NotifyCommand = os.Getenv(EnvNotifyCommand)
+	if lang := os.Getenv(EnvLang); lang != "" {
+		cfg.Language = lang
+	}
+	if value := os.Getenv(EnvHealthWarnings); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: use true or false", EnvHealthWarnings, value)
+		}
+		cfg.HealthWarnings = enabled
+	}
+
+	return cfg, nil
+}
+
+// LoadDir reads the optional config file in ticketsDir, without the
+// environment overrides Load applies.
+func LoadDir(ticketsDir string) (*Config, error) {
+	cfg := &Config{HealthWarnings: true, TicketsDir: ticketsDir}
+	if err := cfg.loadFile(filepath.Join(ticketsDir, FileName)); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile merges settings from a YAML config file. A missing file is not an error.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
 }
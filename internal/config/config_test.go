@@ -46,3 +46,165 @@ func (s *ConfigSuite) TestConstants() {
 	require.Equal(s.T(), "TICKETS_DIR", EnvTicketsDir)
 	require.Equal(s.T(), ".tickets", DefaultTicketsDir)
 }
+
+func (s *ConfigSuite) TestLoadUserFromEnv() {
+	s.T().Setenv(EnvTicketsDir, s.T().TempDir())
+	s.T().Setenv(EnvUser, "Jane Doe")
+
+	cfg, err := Load()
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Jane Doe", cfg.User)
+}
+
+func (s *ConfigSuite) TestLoadConfigFile() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	content := "line_format: \"{{.ID}} {{.Title}}\"\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644))
+
+	cfg, err := Load()
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), dir, cfg.TicketsDir)
+	require.Equal(s.T(), "{{.ID}} {{.Title}}", cfg.LineFormat)
+}
+
+func (s *ConfigSuite) TestLoadWIPLimits() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	content := "wip_limit: 3\nwip_limit_tags:\n  backend: 5\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644))
+
+	cfg, err := Load()
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 3, cfg.WIPLimit)
+	require.Equal(s.T(), map[string]int{"backend": 5}, cfg.WIPLimitTags)
+}
+
+func (s *ConfigSuite) TestLoadPolicies() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	content := "policies:\n  bug:\n    required: [acceptance]\n    max_priority: 2\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644))
+
+	cfg, err := Load()
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []string{"acceptance"}, cfg.Policies["bug"].Required)
+	require.NotNil(s.T(), cfg.Policies["bug"].MaxPriority)
+	require.Equal(s.T(), 2, *cfg.Policies["bug"].MaxPriority)
+}
+
+func (s *ConfigSuite) TestLoadIDPrefixes() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	content := "id_prefixes:\n  bug: bug-\n  epic: epc\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644))
+
+	cfg, err := Load()
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[string]string{"bug": "bug-", "epic": "epc"}, cfg.IDPrefixes)
+}
+
+func (s *ConfigSuite) TestLoadRetention() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	content := "retention:\n  closed: 180d\n  action: delete\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644))
+
+	cfg, err := Load()
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), Retention{Closed: "180d", Action: "delete"}, cfg.Retention)
+}
+
+func (s *ConfigSuite) TestLoadStatusSymbols() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	content := "symbol_set: nerd\nstatus_symbols:\n  closed: done\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644))
+
+	cfg, err := Load()
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "nerd", cfg.SymbolSet)
+	require.Equal(s.T(), map[string]string{"closed": "done"}, cfg.StatusSymbols)
+}
+
+func (s *ConfigSuite) TestLoadASCII() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte("ascii: true\n"), 0644))
+
+	cfg, err := Load()
+
+	require.NoError(s.T(), err)
+	require.True(s.T(), cfg.ASCII)
+}
+
+func (s *ConfigSuite) TestLoadDisplayTime() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	content := "timezone: Europe/Berlin\ndate_format: datetime\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644))
+
+	cfg, err := Load()
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Europe/Berlin", cfg.Timezone)
+	require.Equal(s.T(), "datetime", cfg.DateFormat)
+}
+
+func (s *ConfigSuite) TestLoadLanguage() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	s.T().Setenv(EnvLang, "")
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte("language: de\n"), 0644))
+
+	cfg, err := Load()
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "de", cfg.Language)
+
+	s.T().Setenv(EnvLang, "pt-BR")
+	cfg, err = Load()
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "pt-BR", cfg.Language)
+}
+
+func (s *ConfigSuite) TestLoadHealthWarnings() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	s.T().Setenv(EnvHealthWarnings, "")
+
+	cfg, err := Load()
+	require.NoError(s.T(), err)
+	require.True(s.T(), cfg.HealthWarnings, "on by default")
+
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte("health_warnings: false\n"), 0644))
+	cfg, err = Load()
+	require.NoError(s.T(), err)
+	require.False(s.T(), cfg.HealthWarnings)
+
+	s.T().Setenv(EnvHealthWarnings, "1")
+	cfg, err = Load()
+	require.NoError(s.T(), err)
+	require.True(s.T(), cfg.HealthWarnings)
+
+	s.T().Setenv(EnvHealthWarnings, "sometimes")
+	_, err = Load()
+	require.ErrorContains(s.T(), err, "invalid TK_HEALTH_WARNINGS")
+}
+
+func (s *ConfigSuite) TestLoadConfigFileInvalid() {
+	dir := s.T().TempDir()
+	s.T().Setenv(EnvTicketsDir, dir)
+	require.NoError(s.T(), os.WriteFile(filepath.Join(dir, FileName), []byte("line_format: [\n"), 0644))
+
+	_, err := Load()
+
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "failed to parse config file")
+}
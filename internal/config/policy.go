@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// policyField describes a field that a type policy can require.
+type policyField struct {
+	label string // human name used in messages
+	hint  string // how to set the field
+	isSet func(t *domain.Ticket) bool
+}
+
+// policyFields lists the fields a policy may require, keyed by config name.
+var policyFields = map[string]policyField{
+	"description":  {"description", "add text below the title", func(t *domain.Ticket) bool { return t.Description != "" }},
+	"design":       {"design notes", `add a "## Design" section or use --design`, func(t *domain.Ticket) bool { return t.Design != "" }},
+	"acceptance":   {"acceptance criteria", `add an "## Acceptance Criteria" section or use --acceptance`, func(t *domain.Ticket) bool { return t.Acceptance != "" }},
+	"assignee":     {"assignee", "use --assignee", func(t *domain.Ticket) bool { return t.Assignee != "" }},
+	"tags":         {"tags", "use --tags", func(t *domain.Ticket) bool { return len(t.Tags) > 0 }},
+	"parent":       {"parent", "use --parent", func(t *domain.Ticket) bool { return t.Parent != "" }},
+	"external-ref": {"external reference", "use --external-ref", func(t *domain.Ticket) bool { return t.ExternalRef != "" }},
+	"deps":         {"dependencies", "use tk dep add", func(t *domain.Ticket) bool { return len(t.Deps) > 0 }},
+}
+
+// PolicyFieldNames returns the sorted names of fields a policy may require.
+func PolicyFieldNames() []string {
+	names := make([]string, 0, len(policyFields))
+	for name := range policyFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidatePolicies rejects policies naming unknown types or fields.
+func ValidatePolicies(policies map[string]TypePolicy) error {
+	for typ, policy := range policies {
+		if !domain.Type(typ).IsValid() {
+			return fmt.Errorf("invalid policy in %s: unknown type %q", FileName, typ)
+		}
+		for _, name := range policy.Required {
+			if _, ok := policyFields[name]; !ok {
+				return fmt.Errorf("invalid policy for %s in %s: unknown field %q (must be one of %s)",
+					typ, FileName, name, strings.Join(PolicyFieldNames(), ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// PolicyViolations describes each way the ticket fails its type's policy.
+func PolicyViolations(t *domain.Ticket, policies map[string]TypePolicy) []string {
+	policy, ok := policies[string(t.Type)]
+	if !ok {
+		return nil
+	}
+
+	var violations []string
+	for _, name := range policy.Required {
+		field, ok := policyFields[name]
+		if !ok || field.isSet(t) {
+			continue
+		}
+		violations = append(violations, fmt.Sprintf("%s tickets require %s (%s)", t.Type, field.label, field.hint))
+	}
+	if policy.MaxPriority != nil && t.Priority > *policy.MaxPriority {
+		violations = append(violations, fmt.Sprintf("%s tickets require priority %d or higher (P0-P%d), got P%d (use --priority)",
+			t.Type, *policy.MaxPriority, *policy.MaxPriority, t.Priority))
+	}
+	return violations
+}
+
+// idPrefixPattern matches a valid configured ID prefix.
+var idPrefixPattern = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// IDPrefix returns the ID prefix configured for tickets of type t, or an
+// empty string for the default prefix. A trailing dash in the config ("bug-")
+// is accepted.
+func (c *Config) IDPrefix(t domain.Type) (string, error) {
+	for typ, prefix := range c.IDPrefixes {
+		if !domain.Type(typ).IsValid() {
+			return "", fmt.Errorf("invalid id_prefixes in %s: unknown type %q", FileName, typ)
+		}
+		if !idPrefixPattern.MatchString(strings.TrimSuffix(prefix, "-")) {
+			return "", fmt.Errorf("invalid id_prefixes for %s in %s: %q must be lowercase letters and digits", typ, FileName, prefix)
+		}
+	}
+	return strings.TrimSuffix(c.IDPrefixes[string(t)], "-"), nil
+}
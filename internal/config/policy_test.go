@@ -0,0 +1,84 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+type PolicySuite struct {
+	suite.Suite
+}
+
+func TestPolicySuite(t *testing.T) {
+	suite.Run(t, new(PolicySuite))
+}
+
+func bugPolicy() map[string]TypePolicy {
+	maxPriority := 2
+	return map[string]TypePolicy{
+		"bug": {Required: []string{"acceptance", "assignee"}, MaxPriority: &maxPriority},
+	}
+}
+
+func (s *PolicySuite) TestPolicyViolations() {
+	tests := []struct {
+		name   string
+		ticket *domain.Ticket
+		want   []string
+	}{
+		{
+			name:   "compliant bug",
+			ticket: &domain.Ticket{Type: domain.TypeBug, Priority: 1, Acceptance: "- [ ] fixed", Assignee: "alice"},
+		},
+		{
+			name:   "other type is unconstrained",
+			ticket: &domain.Ticket{Type: domain.TypeTask, Priority: 4},
+		},
+		{
+			name:   "missing fields and low priority",
+			ticket: &domain.Ticket{Type: domain.TypeBug, Priority: 3},
+			want: []string{
+				`bug tickets require acceptance criteria (add an "## Acceptance Criteria" section or use --acceptance)`,
+				"bug tickets require assignee (use --assignee)",
+				"bug tickets require priority 2 or higher (P0-P2), got P3 (use --priority)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			require.Equal(s.T(), tt.want, PolicyViolations(tt.ticket, bugPolicy()))
+		})
+	}
+}
+
+func (s *PolicySuite) TestValidatePolicies() {
+	require.NoError(s.T(), ValidatePolicies(bugPolicy()))
+
+	err := ValidatePolicies(map[string]TypePolicy{"bogus": {}})
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), `unknown type "bogus"`)
+
+	err = ValidatePolicies(map[string]TypePolicy{"bug": {Required: []string{"colour"}}})
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), `unknown field "colour"`)
+}
+
+func (s *PolicySuite) TestIDPrefix() {
+	cfg := &Config{IDPrefixes: map[string]string{"bug": "bug-", "epic": "epc"}}
+
+	prefix, err := cfg.IDPrefix(domain.TypeBug)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "bug", prefix)
+	prefix, err = cfg.IDPrefix(domain.TypeTask)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), prefix)
+
+	cfg.IDPrefixes["chore"] = "Chore"
+	_, err = cfg.IDPrefix(domain.TypeTask)
+	require.ErrorContains(s.T(), err, "must be lowercase letters and digits")
+}
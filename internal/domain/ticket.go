@@ -6,8 +6,11 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"gopkg.in/yaml.v3"
 )
@@ -107,6 +110,37 @@ func ParseType(s string) (Type, error) {
 	}
 }
 
+// Resolution records why a closed ticket was closed.
+type Resolution string
+
+const (
+	ResolutionDone      Resolution = "done"
+	ResolutionWontfix   Resolution = "wontfix"
+	ResolutionDuplicate Resolution = "duplicate"
+)
+
+// ValidResolutions contains all valid resolution values in display order.
+var ValidResolutions = []Resolution{ResolutionDone, ResolutionWontfix, ResolutionDuplicate}
+
+// String returns the string representation of the resolution.
+func (r Resolution) String() string {
+	return string(r)
+}
+
+// ParseResolution parses a string into a Resolution.
+func ParseResolution(s string) (Resolution, error) {
+	switch s {
+	case "done":
+		return ResolutionDone, nil
+	case "wontfix":
+		return ResolutionWontfix, nil
+	case "duplicate":
+		return ResolutionDuplicate, nil
+	default:
+		return "", fmt.Errorf("invalid resolution: %s", s)
+	}
+}
+
 // Priority constants define the valid range for ticket priorities.
 // Lower values indicate higher priority (0 = highest, 4 = lowest).
 const (
@@ -119,6 +153,72 @@ const (
 type Note struct {
 	Timestamp time.Time
 	Content   string
+	// ReplyTo is the 1-based number of the note this one replies to, or 0.
+	ReplyTo int
+}
+
+// ThreadedNote is a note positioned in its reply thread.
+type ThreadedNote struct {
+	Note
+	// Number is the 1-based position of the note in the ticket.
+	Number int
+	// Depth is the reply nesting level, 0 for top-level notes.
+	Depth int
+}
+
+// NoteThreads orders the ticket's notes into reply threads: each top-level
+// note is followed by its replies, depth first, in the order they were added.
+// Replies to unknown or later notes are treated as top-level.
+func (t *Ticket) NoteThreads() []ThreadedNote {
+	replies := make(map[int][]int)
+	var roots []int
+	for i, note := range t.Notes {
+		number := i + 1
+		if note.ReplyTo > 0 && note.ReplyTo < number {
+			replies[note.ReplyTo] = append(replies[note.ReplyTo], number)
+			continue
+		}
+		roots = append(roots, number)
+	}
+
+	threads := make([]ThreadedNote, 0, len(t.Notes))
+	var walk func(number, depth int)
+	walk = func(number, depth int) {
+		threads = append(threads, ThreadedNote{Note: t.Notes[number-1], Number: number, Depth: depth})
+		for _, reply := range replies[number] {
+			walk(reply, depth+1)
+		}
+	}
+	for _, number := range roots {
+		walk(number, 0)
+	}
+	return threads
+}
+
+// ReviewDecision is the state of a requested review.
+type ReviewDecision string
+
+// Review decision constants.
+const (
+	ReviewPending  ReviewDecision = "pending"
+	ReviewApproved ReviewDecision = "approved"
+	ReviewRejected ReviewDecision = "rejected"
+)
+
+// Review records a reviewer asked to sign off on a ticket and their decision.
+// JSON keys match the frontmatter so exports and ticket files agree.
+type Review struct {
+	Reviewer string         `yaml:"reviewer" json:"reviewer"`
+	Decision ReviewDecision `yaml:"decision" json:"decision"`
+	Comment  string         `yaml:"comment,omitempty" json:"comment,omitempty"`
+	// At is when the review was requested or decided.
+	At time.Time `yaml:"at,omitempty" json:"at,omitzero"`
+}
+
+// Section is a custom "## Name" body section, kept verbatim.
+type Section struct {
+	Name    string
+	Content string
 }
 
 // Ticket represents a ticket in the system.
@@ -128,20 +228,209 @@ type Ticket struct {
 	Status      Status    `yaml:"status"`
 	Type        Type      `yaml:"type,omitempty"`
 	Priority    int       `yaml:"priority,omitempty"`
+	Estimate    float64   `yaml:"estimate,omitempty"`
 	Assignee    string    `yaml:"assignee,omitempty"`
 	Parent      string    `yaml:"parent,omitempty"`
 	ExternalRef string    `yaml:"external-ref,omitempty"`
 	Tags        []string  `yaml:"tags,omitempty"`
 	Deps        []string  `yaml:"deps,omitempty"`
 	Links       []string  `yaml:"links,omitempty"`
+	Watchers    []string  `yaml:"watchers,omitempty"`
+	PRs         []string  `yaml:"prs,omitempty"`
 	Created     time.Time `yaml:"created"`
+	ClosedAt    time.Time `yaml:"closed-at,omitempty"`
+	// Resolution is why a closed ticket was closed; empty on tickets closed
+	// without one, which count as done.
+	Resolution Resolution `yaml:"resolution,omitempty"`
+	// Due is the date the ticket should be closed by; only its date counts.
+	Due time.Time `yaml:"due,omitempty"`
+	// UpdatedAt is the time of the last write.
+	UpdatedAt time.Time `yaml:"updated-at,omitempty"`
+	// LastUpdatedBy is the identity of whoever last wrote the ticket.
+	LastUpdatedBy string `yaml:"last-updated-by,omitempty"`
+	// Revision is incremented on every write and used to detect concurrent changes.
+	Revision int `yaml:"revision,omitempty"`
+	// Locked freezes the ticket: writes are refused until it is unlocked.
+	Locked     bool   `yaml:"locked,omitempty"`
+	LockReason string `yaml:"lock-reason,omitempty"`
+	// LeaseUntil is when the claim on an in_progress ticket expires, after
+	// which it can be claimed again; zero means the claim never expires.
+	LeaseUntil time.Time `yaml:"lease-until,omitempty"`
+	// LeaseHolder is the identity that claimed the ticket with a lease.
+	LeaseHolder string `yaml:"lease-holder,omitempty"`
+	// Reviews lists requested reviewers and their decisions.
+	Reviews []Review `yaml:"reviews,omitempty"`
 
 	// Body fields (not in frontmatter)
 	Title       string `yaml:"-"`
 	Description string `yaml:"-"`
 	Design      string `yaml:"-"`
 	Acceptance  string `yaml:"-"`
-	Notes       []Note `yaml:"-"`
+	// Sections holds custom sections in order, rendered after the description.
+	Sections []Section `yaml:"-"`
+	Notes    []Note    `yaml:"-"`
+}
+
+// Overdue reports whether the ticket is still open after its due date,
+// comparing calendar dates in UTC.
+func (t *Ticket) Overdue(now time.Time) bool {
+	if t.Due.IsZero() || t.Status == StatusClosed {
+		return false
+	}
+	return t.Due.UTC().Format(time.DateOnly) < now.UTC().Format(time.DateOnly)
+}
+
+// SetStatus updates the ticket status and maintains the closed-at timestamp:
+// it is stamped with now when the ticket is closed and cleared, along with
+// the resolution, otherwise. Leaving in_progress releases any lease.
+func (t *Ticket) SetStatus(status Status, now time.Time) {
+	t.Status = status
+	if status != StatusInProgress {
+		t.LeaseUntil = time.Time{}
+		t.LeaseHolder = ""
+	}
+	if status != StatusClosed {
+		t.ClosedAt = time.Time{}
+		t.Resolution = ""
+		return
+	}
+	if t.ClosedAt.IsZero() {
+		t.ClosedAt = now
+	}
+}
+
+// LeaseExpired reports whether t is in_progress under a lease that has
+// expired by now, e.g. because the agent working on it crashed.
+func (t *Ticket) LeaseExpired(now time.Time) bool {
+	return t.Status == StatusInProgress && !t.LeaseUntil.IsZero() && !now.Before(t.LeaseUntil)
+}
+
+// Claimable reports whether t can be claimed: it is open, or its lease expired.
+func (t *Ticket) Claimable(now time.Time) bool {
+	return t.Status == StatusOpen || t.LeaseExpired(now)
+}
+
+// Section returns the content of the named body section, matched ignoring
+// case. Besides custom sections it accepts the built-in "Description",
+// "Design" and "Acceptance Criteria" (or "Acceptance"), which always exist.
+func (t *Ticket) Section(name string) (string, bool) {
+	if field := t.builtinSection(name); field != nil {
+		return *field, true
+	}
+	for _, section := range t.Sections {
+		if strings.EqualFold(section.Name, name) {
+			return section.Content, true
+		}
+	}
+	return "", false
+}
+
+// SetSection replaces the content of the named body section, appending a
+// custom section if none matches.
+func (t *Ticket) SetSection(name, content string) {
+	if field := t.builtinSection(name); field != nil {
+		*field = content
+		return
+	}
+	for i := range t.Sections {
+		if strings.EqualFold(t.Sections[i].Name, name) {
+			t.Sections[i].Content = content
+			return
+		}
+	}
+	t.Sections = append(t.Sections, Section{Name: name, Content: content})
+}
+
+// builtinSection returns the field holding a built-in body section, or nil.
+func (t *Ticket) builtinSection(name string) *string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "description":
+		return &t.Description
+	case "design":
+		return &t.Design
+	case "acceptance", "acceptance criteria":
+		return &t.Acceptance
+	default:
+		return nil
+	}
+}
+
+// NeedsReview reports whether any requested review is still pending.
+func (t *Ticket) NeedsReview() bool {
+	for _, r := range t.Reviews {
+		if r.Decision == ReviewPending {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewBy returns the review of the named user, or nil if there is none.
+// Reviewers are matched like @handles (see MentionMatches).
+func (t *Ticket) ReviewBy(user string) *Review {
+	for i := range t.Reviews {
+		r := &t.Reviews[i]
+		if strings.EqualFold(r.Reviewer, user) || MentionMatches(r.Reviewer, user) {
+			return r
+		}
+	}
+	return nil
+}
+
+// mentionPattern matches @handle mentions that are not part of an email address.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9][\w.-]*)`)
+
+// Mentions returns the distinct @handles mentioned in the ticket's title,
+// description, design, acceptance criteria, custom sections and notes, without
+// the @, in order of first appearance.
+func (t *Ticket) Mentions() []string {
+	texts := []string{t.Title, t.Description, t.Design, t.Acceptance}
+	for _, section := range t.Sections {
+		texts = append(texts, section.Content)
+	}
+	for _, note := range t.Notes {
+		texts = append(texts, note.Content)
+	}
+
+	var mentions []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+			handle := strings.TrimRight(m[1], ".-")
+			key := strings.ToLower(handle)
+			if handle == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			mentions = append(mentions, handle)
+		}
+	}
+	return mentions
+}
+
+// MentionMatches reports whether an @handle refers to the named user. Handles
+// match case-insensitively against the full name with spaces and punctuation
+// removed (e.g. @janedoe, @jane.doe) or against the first name (@jane).
+func MentionMatches(handle, user string) bool {
+	handle = normalizeHandle(strings.TrimPrefix(handle, "@"))
+	if handle == "" {
+		return false
+	}
+	if handle == normalizeHandle(user) {
+		return true
+	}
+	first, _, _ := strings.Cut(strings.TrimSpace(user), " ")
+	return handle == normalizeHandle(first)
+}
+
+// normalizeHandle lowercases s and drops everything but letters and digits.
+func normalizeHandle(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
 }
 
 // ParseFromFile reads and parses a ticket from a file.
@@ -208,7 +497,7 @@ func (t *Ticket) Render() ([]byte, error) {
 // ParseMarkdownBody parses the markdown body and populates body fields.
 func (t *Ticket) ParseMarkdownBody(content string) {
 	scanner := bufio.NewScanner(strings.NewReader(content))
-	var currentSection string
+	var currentSection, customName string
 	var sectionContent strings.Builder
 
 	flushSection := func() {
@@ -226,6 +515,8 @@ func (t *Ticket) ParseMarkdownBody(content string) {
 			t.Design = text
 		case "acceptance":
 			t.Acceptance = text
+		case "custom":
+			t.Sections = append(t.Sections, Section{Name: customName, Content: text})
 		case "notes":
 			t.Notes = parseNotes(text)
 		}
@@ -254,9 +545,8 @@ func (t *Ticket) ParseMarkdownBody(content string) {
 			case "notes":
 				currentSection = "notes"
 			default:
-				currentSection = "description"
-				sectionContent.WriteString(line)
-				sectionContent.WriteString("\n")
+				currentSection = "custom"
+				customName = header
 			}
 			continue
 		}
@@ -290,6 +580,17 @@ func (t *Ticket) RenderMarkdownBody() string {
 		buf.WriteString("\n\n")
 	}
 
+	// Custom sections
+	for _, section := range t.Sections {
+		buf.WriteString("## ")
+		buf.WriteString(section.Name)
+		buf.WriteString("\n\n")
+		if section.Content != "" {
+			buf.WriteString(section.Content)
+			buf.WriteString("\n\n")
+		}
+	}
+
 	// Design
 	if t.Design != "" {
 		buf.WriteString("## Design\n\n")
@@ -308,7 +609,11 @@ func (t *Ticket) RenderMarkdownBody() string {
 	if len(t.Notes) > 0 {
 		buf.WriteString("## Notes\n\n")
 		for _, note := range t.Notes {
-			buf.WriteString(fmt.Sprintf("### %s\n\n", note.Timestamp.Format(time.RFC3339)))
+			buf.WriteString(fmt.Sprintf("### %s", note.Timestamp.Format(time.RFC3339)))
+			if note.ReplyTo > 0 {
+				buf.WriteString(fmt.Sprintf(" re: #%d", note.ReplyTo))
+			}
+			buf.WriteString("\n\n")
 			buf.WriteString(note.Content)
 			buf.WriteString("\n\n")
 		}
@@ -356,12 +661,16 @@ func parseNotes(content string) []Note {
 				noteContent.Reset()
 			}
 
-			timestamp := strings.TrimPrefix(line, "### ")
+			heading := strings.TrimPrefix(line, "### ")
+			timestamp, reply, _ := strings.Cut(heading, " re: #")
 			t, err := time.Parse(time.RFC3339, timestamp)
 			if err != nil {
 				continue
 			}
 			currentNote = &Note{Timestamp: t}
+			if n, err := strconv.Atoi(reply); err == nil && n > 0 {
+				currentNote.ReplyTo = n
+			}
 			continue
 		}
 
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -83,6 +84,32 @@ func (s *TicketSuite) TestParseType() {
 	}
 }
 
+func (s *TicketSuite) TestParseResolution() {
+	for _, r := range ValidResolutions {
+		got, err := ParseResolution(r.String())
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), r, got)
+	}
+	_, err := ParseResolution("fixed")
+	require.Error(s.T(), err)
+}
+
+func (s *TicketSuite) TestResolutionRoundTripAndReopen() {
+	ticket := &Ticket{ID: "tic-res", Status: StatusOpen, Created: time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC)}
+	ticket.SetStatus(StatusClosed, time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC))
+	ticket.Resolution = ResolutionWontfix
+
+	rendered, err := ticket.Render()
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), string(rendered), "resolution: wontfix")
+	parsed, err := Parse(rendered)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), ResolutionWontfix, parsed.Resolution)
+
+	ticket.SetStatus(StatusOpen, time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC))
+	require.Empty(s.T(), ticket.Resolution)
+}
+
 func (s *TicketSuite) TestTypeString() {
 	require.Equal(s.T(), "task", TypeTask.String())
 	require.Equal(s.T(), "bug", TypeBug.String())
@@ -207,6 +234,57 @@ func (s *TicketSuite) TestRender() {
 	require.Contains(s.T(), content, "## Notes")
 }
 
+func (s *TicketSuite) TestCustomSectionsPreserved() {
+	content := `---
+id: tic-sec1
+status: open
+created: 2026-01-31T10:00:00Z
+---
+# Title
+
+Description text.
+
+## Test Plan
+
+- run it
+
+## Rollout
+
+## Design
+
+Design notes.
+
+`
+	ticket, err := Parse([]byte(content))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Description text.", ticket.Description)
+	require.Equal(s.T(), []Section{{Name: "Test Plan", Content: "- run it"}, {Name: "Rollout"}}, ticket.Sections)
+	require.Equal(s.T(), "Design notes.", ticket.Design)
+
+	rendered, err := ticket.Render()
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), content, string(rendered))
+}
+
+func (s *TicketSuite) TestSectionAccess() {
+	ticket := &Ticket{Description: "D", Sections: []Section{{Name: "Test Plan", Content: "P"}}}
+
+	content, ok := ticket.Section("test plan")
+	require.True(s.T(), ok)
+	require.Equal(s.T(), "P", content)
+	content, ok = ticket.Section("Description")
+	require.True(s.T(), ok)
+	require.Equal(s.T(), "D", content)
+	_, ok = ticket.Section("Rollout")
+	require.False(s.T(), ok)
+
+	ticket.SetSection("TEST PLAN", "P2")
+	ticket.SetSection("Rollout", "R")
+	ticket.SetSection("acceptance", "A")
+	require.Equal(s.T(), []Section{{Name: "Test Plan", Content: "P2"}, {Name: "Rollout", Content: "R"}}, ticket.Sections)
+	require.Equal(s.T(), "A", ticket.Acceptance)
+}
+
 func (s *TicketSuite) TestRoundTrip() {
 	original := &Ticket{
 		ID:       "tic-round1",
@@ -241,6 +319,127 @@ func (s *TicketSuite) TestRoundTrip() {
 	require.Equal(s.T(), original.Title, parsed.Title)
 }
 
+func (s *TicketSuite) TestSetStatusMaintainsClosedAt() {
+	now := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	ticket := &Ticket{ID: "tic-close", Status: StatusOpen}
+
+	ticket.SetStatus(StatusClosed, now)
+	require.Equal(s.T(), StatusClosed, ticket.Status)
+	require.Equal(s.T(), now, ticket.ClosedAt)
+
+	ticket.SetStatus(StatusClosed, now.Add(time.Hour))
+	require.Equal(s.T(), now, ticket.ClosedAt, "re-closing keeps the original timestamp")
+
+	ticket.SetStatus(StatusOpen, now)
+	require.Equal(s.T(), StatusOpen, ticket.Status)
+	require.True(s.T(), ticket.ClosedAt.IsZero())
+}
+
+func (s *TicketSuite) TestClosedAtRoundTrip() {
+	ticket := &Ticket{
+		ID:      "tic-closed",
+		Status:  StatusOpen,
+		Created: time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC),
+	}
+
+	rendered, err := ticket.Render()
+	require.NoError(s.T(), err)
+	require.NotContains(s.T(), string(rendered), "closed-at")
+
+	ticket.SetStatus(StatusClosed, time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC))
+	rendered, err = ticket.Render()
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), string(rendered), "closed-at: 2026-02-01T10:00:00Z")
+
+	parsed, err := Parse(rendered)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), ticket.ClosedAt, parsed.ClosedAt)
+}
+
+func (s *TicketSuite) TestNoteRepliesRoundTrip() {
+	ts := time.Date(2026, 1, 31, 11, 0, 0, 0, time.UTC)
+	ticket := &Ticket{
+		ID:      "tic-thread",
+		Status:  StatusOpen,
+		Created: ts,
+		Notes: []Note{
+			{Timestamp: ts, Content: "Question"},
+			{Timestamp: ts, Content: "Answer", ReplyTo: 1},
+		},
+	}
+
+	rendered, err := ticket.Render()
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), string(rendered), "### 2026-01-31T11:00:00Z re: #1\n")
+
+	parsed, err := Parse(rendered)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 0, parsed.Notes[0].ReplyTo)
+	require.Equal(s.T(), 1, parsed.Notes[1].ReplyTo)
+	require.Equal(s.T(), "Answer", parsed.Notes[1].Content)
+}
+
+func (s *TicketSuite) TestNoteThreads() {
+	ticket := &Ticket{Notes: []Note{
+		{Content: "one"},
+		{Content: "two"},
+		{Content: "reply to one", ReplyTo: 1},
+		{Content: "reply to reply", ReplyTo: 3},
+		{Content: "forward reference", ReplyTo: 9},
+	}}
+
+	var got []string
+	for _, n := range ticket.NoteThreads() {
+		got = append(got, fmt.Sprintf("%d:%d", n.Number, n.Depth))
+	}
+	require.Equal(s.T(), []string{"1:0", "3:1", "4:2", "2:0", "5:0"}, got)
+}
+
+func (s *TicketSuite) TestMentions() {
+	ticket := &Ticket{
+		Title:       "Ping @bob",
+		Description: "Ask @alice. Also @Bob again, not mail@example.com.",
+		Notes:       []Note{{Content: "(@carol-d) done"}},
+	}
+	require.Equal(s.T(), []string{"bob", "alice", "carol-d"}, ticket.Mentions())
+}
+
+func (s *TicketSuite) TestMentionMatches() {
+	tests := []struct {
+		handle string
+		user   string
+		want   bool
+	}{
+		{handle: "@jane", user: "Jane Doe", want: true},
+		{handle: "janedoe", user: "Jane Doe", want: true},
+		{handle: "jane.doe", user: "Jane Doe", want: true},
+		{handle: "JANE_DOE", user: "jane doe", want: true},
+		{handle: "doe", user: "Jane Doe", want: false},
+		{handle: "john", user: "Jane Doe", want: false},
+		{handle: "", user: "Jane Doe", want: false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.handle+"/"+tt.user, func() {
+			require.Equal(s.T(), tt.want, MentionMatches(tt.handle, tt.user))
+		})
+	}
+}
+
+func (s *TicketSuite) TestReviews() {
+	ticket := &Ticket{Reviews: []Review{
+		{Reviewer: "jane", Decision: ReviewApproved},
+		{Reviewer: "Bob Smith", Decision: ReviewPending},
+	}}
+	require.True(s.T(), ticket.NeedsReview())
+	require.Equal(s.T(), "jane", ticket.ReviewBy("Jane Doe").Reviewer)
+	require.Equal(s.T(), "Bob Smith", ticket.ReviewBy("bob smith").Reviewer)
+	require.Nil(s.T(), ticket.ReviewBy("Carol"))
+
+	ticket.Reviews[1].Decision = ReviewRejected
+	require.False(s.T(), ticket.NeedsReview())
+}
+
 func TestTitlePreservationAfterStatusChange(t *testing.T) {
 	content := `---
 id: test-1234
@@ -276,3 +475,32 @@ created: 2026-01-31T17:10:46.21915Z
 	
 	require.Equal(t, "My Test Title", ticket2.Title, "Title should be preserved after render and re-parse")
 }
+
+func (s *TicketSuite) TestOverdue() {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	due := func(status Status, due time.Time) *Ticket { return &Ticket{Status: status, Due: due} }
+
+	require.False(s.T(), due(StatusOpen, time.Time{}).Overdue(now))
+	require.False(s.T(), due(StatusOpen, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)).Overdue(now), "due today")
+	require.True(s.T(), due(StatusOpen, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)).Overdue(now))
+	require.True(s.T(), due(StatusInProgress, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)).Overdue(now))
+	require.False(s.T(), due(StatusClosed, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)).Overdue(now))
+}
+
+func (s *TicketSuite) TestLeaseExpired() {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	leased := func(status Status, until time.Time) *Ticket { return &Ticket{Status: status, LeaseUntil: until} }
+
+	require.False(s.T(), leased(StatusInProgress, time.Time{}).LeaseExpired(now), "no lease")
+	require.False(s.T(), leased(StatusInProgress, now.Add(time.Minute)).LeaseExpired(now))
+	require.True(s.T(), leased(StatusInProgress, now).LeaseExpired(now))
+	require.False(s.T(), leased(StatusInProgress, now.Add(time.Minute)).Claimable(now))
+	require.True(s.T(), leased(StatusInProgress, now.Add(-time.Minute)).Claimable(now))
+	require.True(s.T(), leased(StatusOpen, time.Time{}).Claimable(now))
+
+	ticket := leased(StatusInProgress, now)
+	ticket.LeaseHolder = "agent-1"
+	ticket.SetStatus(StatusClosed, now)
+	require.True(s.T(), ticket.LeaseUntil.IsZero())
+	require.Empty(s.T(), ticket.LeaseHolder)
+}
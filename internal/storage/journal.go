@@ -0,0 +1,476 @@
+package storage
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+// JournalFileName is the name of the append-only mutation journal
+// kept in the tickets directory.
+const JournalFileName = ".journal.ndjson"
+
+// Journal event actions.
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionStatus  = "status"
+	ActionDep     = "dep"
+	ActionLink    = "link"
+	ActionNote    = "note"
+	ActionReview  = "review"
+	ActionWatch   = "watch"
+	ActionDelete  = "delete"
+	ActionArchive = "archive"
+	ActionUndo    = "undo"
+)
+
+// ErrUndoConflict is returned when a mutation cannot be undone because another
+// actor has changed the same ticket since.
+var ErrUndoConflict = errors.New("ticket modified by someone else since")
+
+// Change records the value of a field before and after a mutation.
+type Change struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// Event is a single journal entry describing one mutation of one ticket.
+type Event struct {
+	ID      string            `json:"id"`
+	Time    time.Time         `json:"ts"`
+	Actor   string            `json:"actor,omitempty"`
+	Action  string            `json:"action"`
+	Ticket  string            `json:"ticket"`
+	Changes map[string]Change `json:"changes,omitempty"`
+	// Before holds the previous file contents, empty for created tickets.
+	Before string `json:"before,omitempty"`
+	// Undoes is the ID of the event reverted by an undo event.
+	Undoes string `json:"undoes,omitempty"`
+	// Digest is the SHA-256 of the ticket file after the mutation, empty for
+	// deletions. Undo uses it to detect changes made outside tk.
+	Digest string `json:"digest,omitempty"`
+}
+
+// journalField describes a ticket field tracked in journal diffs.
+type journalField struct {
+	name string
+	get  func(t *domain.Ticket) any
+}
+
+// journalFields lists the tracked fields in frontmatter/body order.
+var journalFields = []journalField{
+	{"status", func(t *domain.Ticket) any { return string(t.Status) }},
+	{"type", func(t *domain.Ticket) any { return string(t.Type) }},
+	{"priority", func(t *domain.Ticket) any { return t.Priority }},
+	{"estimate", func(t *domain.Ticket) any { return t.Estimate }},
+	{"assignee", func(t *domain.Ticket) any { return t.Assignee }},
+	{"parent", func(t *domain.Ticket) any { return t.Parent }},
+	{"external-ref", func(t *domain.Ticket) any { return t.ExternalRef }},
+	{"tags", func(t *domain.Ticket) any { return nonNil(t.Tags) }},
+	{"deps", func(t *domain.Ticket) any { return nonNil(t.Deps) }},
+	{"links", func(t *domain.Ticket) any { return nonNil(t.Links) }},
+	{"watchers", func(t *domain.Ticket) any { return nonNil(t.Watchers) }},
+	{"prs", func(t *domain.Ticket) any { return nonNil(t.PRs) }},
+	{"closed-at", func(t *domain.Ticket) any { return formatJournalTime(t.ClosedAt) }},
+	{"resolution", func(t *domain.Ticket) any { return string(t.Resolution) }},
+	{"due", func(t *domain.Ticket) any { return formatJournalTime(t.Due) }},
+	{"locked", func(t *domain.Ticket) any { return t.Locked }},
+	{"reviews", func(t *domain.Ticket) any { return reviewStates(t.Reviews) }},
+	{"title", func(t *domain.Ticket) any { return t.Title }},
+	{"description", func(t *domain.Ticket) any { return t.Description }},
+	{"design", func(t *domain.Ticket) any { return t.Design }},
+	{"acceptance", func(t *domain.Ticket) any { return t.Acceptance }},
+	{"sections", func(t *domain.Ticket) any { return sectionContents(t.Sections) }},
+	{"notes", func(t *domain.Ticket) any { return len(t.Notes) }},
+}
+
+// SetActor sets the identity recorded on journal events.
+func (s *Storage) SetActor(actor string) {
+	s.actor = actor
+}
+
+// Actor returns the identity recorded on journal events.
+func (s *Storage) Actor() string {
+	return s.actor
+}
+
+// JournalPath returns the path of the journal file.
+func (s *Storage) JournalPath() string {
+	return s.journalPath
+}
+
+// ReadJournal returns all journal events in the order they were recorded.
+// A missing journal yields no events.
+func (s *Storage) ReadJournal() ([]Event, error) {
+	file, err := os.Open(s.journalPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			return nil, fmt.Errorf("failed to parse journal line %d: %w", line, err)
+		}
+		events = append(events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	return events, nil
+}
+
+// PlanUndo returns up to n of actor's most recent mutations that have not
+// already been undone, newest first. It fails with ErrUndoConflict if another
+// actor has since changed one of the affected tickets.
+func (s *Storage) PlanUndo(actor string, n int) ([]Event, error) {
+	events, err := s.ReadJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	undone := make(map[string]bool)
+	for _, ev := range events {
+		if ev.Action == ActionUndo {
+			undone[ev.Undoes] = true
+		}
+	}
+
+	var plan []Event
+	for i := len(events) - 1; i >= 0 && len(plan) < n; i-- {
+		ev := events[i]
+		if ev.Action == ActionUndo || undone[ev.ID] || ev.Actor != actor {
+			continue
+		}
+		for _, later := range events[i+1:] {
+			if later.Ticket == ev.Ticket && later.Actor != actor && later.Action != ActionUndo && !undone[later.ID] {
+				return nil, fmt.Errorf("cannot undo %s on %s: %w (%s)", ev.Action, ev.Ticket, ErrUndoConflict, later.Actor)
+			}
+		}
+		plan = append(plan, ev)
+	}
+
+	return plan, nil
+}
+
+// Revert restores ev's ticket to its contents before ev, deleting it if ev
+// created it or moving it back if ev archived it, and journals the undo.
+// It fails with ErrUndoConflict if the ticket file changed after its last
+// journaled mutation, e.g. by a direct edit or a write that changed no
+// journaled field, and refuses to unarchive over an active ticket with the
+// same ID. Restored contents get a revision past the
+// current one, so readers of the undone version detect the change.
+func (s *Storage) Revert(ev Event) error {
+	path := filepath.Join(s.ticketsDir, ev.Ticket+".md")
+
+	if ev.Action == ActionArchive {
+		if s.Exists(ev.Ticket) {
+			return fmt.Errorf("cannot unarchive %s: an active ticket with that ID exists", ev.Ticket)
+		}
+		archived := filepath.Join(s.ticketsDir, ArchiveDirName, ev.Ticket+".md")
+		if err := os.Rename(archived, path); err != nil {
+			return fmt.Errorf("failed to unarchive ticket %s: %w", ev.Ticket, err)
+		}
+		undo := Event{Action: ActionUndo, Ticket: ev.Ticket, Undoes: ev.ID}
+		if data, err := os.ReadFile(path); err == nil {
+			undo.Digest = digest(data)
+			if s.onChange != nil {
+				s.onChange(ev.Ticket, archived, data, nil)
+				s.onChange(ev.Ticket, path, nil, data)
+			}
+		}
+		return s.appendEvent(undo)
+	}
+
+	if file, err := os.OpenFile(path, os.O_RDWR, 0644); err == nil {
+		defer func() { _ = file.Close() }()
+		unlock, err := s.lock(file, path)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	current, err := readIfExists(path)
+	if err != nil {
+		return err
+	}
+	old, _ := domain.Parse(current)
+	if err := s.checkUnchangedSinceJournal(ev, current); err != nil {
+		return err
+	}
+
+	var restored *domain.Ticket
+	if ev.Before != "" {
+		if restored, err = domain.Parse([]byte(ev.Before)); err != nil {
+			return fmt.Errorf("failed to parse ticket %s before %s: %w", ev.Ticket, ev.Action, err)
+		}
+	}
+	if old != nil && old.Locked && (restored == nil || !onlyLockChanged(old, restored)) {
+		return LockedError(old)
+	}
+
+	undo := Event{
+		Action: ActionUndo,
+		Ticket: ev.Ticket,
+		Before: string(current),
+		Undoes: ev.ID,
+	}
+	var data []byte
+	if restored == nil {
+		if current != nil {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("failed to delete ticket %s: %w", ev.Ticket, err)
+			}
+		}
+	} else {
+		if old != nil {
+			restored.Revision = max(restored.Revision, old.Revision) + 1
+		}
+		if data, err = restored.Render(); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to restore ticket %s: %w", ev.Ticket, err)
+		}
+		undo.Digest = digest(data)
+		if old != nil {
+			undo.Changes = diffTickets(old, restored)
+		}
+	}
+
+	if s.onChange != nil {
+		s.onChange(ev.Ticket, path, current, data)
+	}
+
+	return s.appendEvent(undo)
+}
+
+// checkUnchangedSinceJournal fails with ErrUndoConflict if current, the
+// ticket file ev applies to, is not what the last journaled mutation of that
+// ticket left behind.
+func (s *Storage) checkUnchangedSinceJournal(ev Event, current []byte) error {
+	events, err := s.ReadJournal()
+	if err != nil {
+		return err
+	}
+	var last *Event
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Ticket == ev.Ticket {
+			last = &events[i]
+			break
+		}
+	}
+	if last == nil || current == nil {
+		return nil
+	}
+
+	switch {
+	case last.Action == ActionDelete:
+		return fmt.Errorf("cannot undo %s on %s: %w (the ticket was recreated)", ev.Action, ev.Ticket, ErrUndoConflict)
+	case last.Digest != "" && digest(current) != last.Digest:
+		// Events journaled before digests were recorded cannot be checked
+		return fmt.Errorf("cannot undo %s on %s: %w (the file changed after its last journaled change)", ev.Action, ev.Ticket, ErrUndoConflict)
+	}
+	return nil
+}
+
+// digest returns the hex SHA-256 of data.
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordChange journals the mutation from before to after. before is nil for
+// created tickets and after is nil for deleted ones. Unchanged writes are not
+// recorded.
+func (s *Storage) recordChange(id string, before []byte, after *domain.Ticket) error {
+	var rendered []byte
+	if after != nil {
+		// Rendering is deterministic, so this matches what was written
+		rendered, _ = after.Render()
+	}
+	return s.recordFile(id, before, rendered, after)
+}
+
+// recordFile is recordChange for a ticket whose file was written as rendered
+// rather than rendered from after.
+func (s *Storage) recordFile(id string, before, rendered []byte, after *domain.Ticket) error {
+	var old *domain.Ticket
+	if before != nil {
+		// Unparseable previous content is still journaled so it can be restored
+		old, _ = domain.Parse(before)
+	}
+
+	if s.onChange != nil {
+		s.onChange(id, filepath.Join(s.ticketsDir, id+".md"), before, rendered)
+	}
+
+	ev := Event{
+		Ticket: id,
+		Before: string(before),
+	}
+	if rendered != nil {
+		ev.Digest = digest(rendered)
+	}
+
+	switch {
+	case after == nil:
+		ev.Action = ActionDelete
+	case before == nil:
+		ev.Action = ActionCreate
+	default:
+		ev.Changes = diffTickets(old, after)
+		if len(ev.Changes) == 0 {
+			return nil
+		}
+		ev.Action = classifyChanges(ev.Changes)
+	}
+
+	return s.appendEvent(ev)
+}
+
+// appendEvent stamps ev with an ID, time and actor and appends it to the journal.
+func (s *Storage) appendEvent(ev Event) error {
+	idBytes := make([]byte, 6)
+	if _, err := rand.Read(idBytes); err != nil {
+		return fmt.Errorf("failed to generate event ID: %w", err)
+	}
+	ev.ID = hex.EncodeToString(idBytes)
+	ev.Time = time.Now().UTC()
+	ev.Actor = s.actor
+
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal event: %w", err)
+	}
+	data = append(data, '\n')
+
+	file, err := os.OpenFile(s.journalPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("failed to lock journal: %w", err)
+	}
+	defer func() { _ = unlockFile(file) }()
+
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// Changes returns the fields tracked by the journal that differ between two
+// versions of a ticket. A nil old ticket reports every field as changed.
+func Changes(old, new *domain.Ticket) map[string]Change {
+	return diffTickets(old, new)
+}
+
+// diffTickets returns the tracked fields that differ between old and new.
+// A nil old ticket (unparseable previous content) reports every field as changed.
+func diffTickets(old, new *domain.Ticket) map[string]Change {
+	changes := make(map[string]Change)
+	for _, f := range journalFields {
+		to := f.get(new)
+		if old == nil {
+			changes[f.name] = Change{To: to}
+			continue
+		}
+		from := f.get(old)
+		if !reflect.DeepEqual(from, to) {
+			changes[f.name] = Change{From: from, To: to}
+		}
+	}
+	return changes
+}
+
+// classifyChanges maps a set of changed fields to the most specific action.
+func classifyChanges(changes map[string]Change) string {
+	only := func(names ...string) bool {
+		for name := range changes {
+			if !slices.Contains(names, name) {
+				return false
+			}
+		}
+		return len(changes) > 0
+	}
+
+	switch {
+	case only("status", "closed-at", "resolution"):
+		return ActionStatus
+	case only("deps"):
+		return ActionDep
+	case only("links"):
+		return ActionLink
+	case only("notes"):
+		return ActionNote
+	case only("reviews"):
+		return ActionReview
+	case only("watchers"):
+		return ActionWatch
+	default:
+		return ActionUpdate
+	}
+}
+
+// reviewStates summarizes reviews as reviewer=decision entries.
+func reviewStates(reviews []domain.Review) []string {
+	states := make([]string, 0, len(reviews))
+	for _, r := range reviews {
+		states = append(states, r.Reviewer+"="+string(r.Decision))
+	}
+	return states
+}
+
+// sectionContents maps custom section names to their contents.
+func sectionContents(sections []domain.Section) map[string]string {
+	contents := make(map[string]string, len(sections))
+	for _, section := range sections {
+		contents[section.Name] = section.Content
+	}
+	return contents
+}
+
+// nonNil normalizes nil slices so empty and missing lists compare equal.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// formatJournalTime formats t as RFC3339, or empty for the zero time.
+func formatJournalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,305 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/radutopala/ticket/internal/domain"
+)
+
+type JournalSuite struct {
+	suite.Suite
+	tempDir string
+	storage *Storage
+}
+
+func TestJournalSuite(t *testing.T) {
+	suite.Run(t, new(JournalSuite))
+}
+
+func (s *JournalSuite) SetupTest() {
+	var err error
+	s.tempDir, err = os.MkdirTemp("", "ticket-journal-test-*")
+	require.NoError(s.T(), err)
+
+	s.storage = New(s.tempDir)
+	s.storage.SetActor("Tester")
+}
+
+func (s *JournalSuite) TearDownTest() {
+	_ = os.RemoveAll(s.tempDir)
+}
+
+func (s *JournalSuite) newTicket(id string) *domain.Ticket {
+	return &domain.Ticket{
+		ID:       id,
+		Status:   domain.StatusOpen,
+		Type:     domain.TypeTask,
+		Priority: 2,
+		Created:  time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC),
+		Title:    "Journal test",
+	}
+}
+
+func (s *JournalSuite) TestReadJournal_Missing() {
+	events, err := s.storage.ReadJournal()
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), events)
+}
+
+func (s *JournalSuite) TestRecordsMutations() {
+	ticket := s.newTicket("tic-j1")
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	ticket.SetStatus(domain.StatusClosed, time.Now().UTC())
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	ticket.Deps = []string{"tic-other"}
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	ticket.Links = []string{"tic-other"}
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	ticket.Notes = append(ticket.Notes, domain.Note{Timestamp: time.Now().UTC(), Content: "note"})
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	ticket.Title = "Renamed"
+	ticket.Priority = 0
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	require.NoError(s.T(), s.storage.Delete("tic-j1"))
+
+	events, err := s.storage.ReadJournal()
+	require.NoError(s.T(), err)
+
+	var actions []string
+	for _, ev := range events {
+		actions = append(actions, ev.Action)
+		require.Equal(s.T(), "tic-j1", ev.Ticket)
+		require.Equal(s.T(), "Tester", ev.Actor)
+		require.NotEmpty(s.T(), ev.ID)
+		require.False(s.T(), ev.Time.IsZero())
+	}
+	require.Equal(s.T(), []string{
+		ActionCreate, ActionStatus, ActionDep, ActionLink, ActionNote, ActionUpdate, ActionDelete,
+	}, actions)
+
+	require.Empty(s.T(), events[0].Before)
+	require.Equal(s.T(), Change{From: "open", To: "closed"}, events[1].Changes["status"])
+	require.Equal(s.T(), Change{From: "Journal test", To: "Renamed"}, events[5].Changes["title"])
+	require.Contains(s.T(), events[6].Before, "# Renamed")
+}
+
+func (s *JournalSuite) TestSkipsUnchangedWrites() {
+	ticket := s.newTicket("tic-j2")
+	require.NoError(s.T(), s.storage.Write(ticket))
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	events, err := s.storage.ReadJournal()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), events, 1)
+}
+
+func (s *JournalSuite) TestAtomicClaimRecorded() {
+	require.NoError(s.T(), s.storage.Write(s.newTicket("tic-j3")))
+
+	_, err := s.storage.AtomicClaim("tic-j3")
+	require.NoError(s.T(), err)
+
+	events, err := s.storage.ReadJournal()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), events, 2)
+	require.Equal(s.T(), ActionStatus, events[1].Action)
+	require.Equal(s.T(), Change{From: "open", To: "in_progress"}, events[1].Changes["status"])
+}
+
+func (s *JournalSuite) TestArchiveSharesJournal() {
+	archive := s.storage.Archive()
+	require.NoError(s.T(), archive.EnsureDir())
+	require.Equal(s.T(), s.storage.JournalPath(), archive.JournalPath())
+	require.Equal(s.T(), filepath.Join(s.tempDir, JournalFileName), archive.JournalPath())
+
+	require.NoError(s.T(), archive.Write(s.newTicket("tic-j4")))
+
+	events, err := s.storage.ReadJournal()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), events, 1)
+	require.Equal(s.T(), "Tester", events[0].Actor)
+}
+
+func (s *JournalSuite) TestOnChangeGetsStorePath() {
+	var paths []string
+	s.storage.SetOnChange(func(id, path string, before, after []byte) {
+		paths = append(paths, path)
+	})
+
+	require.NoError(s.T(), s.storage.Write(s.newTicket("tic-oc1")))
+	archive := s.storage.Archive()
+	require.NoError(s.T(), archive.EnsureDir())
+	require.NoError(s.T(), archive.Write(s.newTicket("tic-oc2")))
+
+	require.Equal(s.T(), []string{
+		filepath.Join(s.tempDir, "tic-oc1.md"),
+		filepath.Join(s.tempDir, ArchiveDirName, "tic-oc2.md"),
+	}, paths)
+}
+
+func (s *JournalSuite) TestOnChangeSeesArchiveMoves() {
+	require.NoError(s.T(), s.storage.Write(s.newTicket("tic-oc3")))
+	content, err := os.ReadFile(filepath.Join(s.tempDir, "tic-oc3.md"))
+	require.NoError(s.T(), err)
+
+	type change struct {
+		path          string
+		before, after []byte
+	}
+	var changes []change
+	s.storage.SetOnChange(func(id, path string, before, after []byte) {
+		changes = append(changes, change{path, before, after})
+	})
+
+	active := filepath.Join(s.tempDir, "tic-oc3.md")
+	archived := filepath.Join(s.tempDir, ArchiveDirName, "tic-oc3.md")
+	require.NoError(s.T(), s.storage.ArchiveTicket("tic-oc3"))
+	require.Equal(s.T(), []change{{active, content, nil}, {archived, nil, content}}, changes)
+
+	changes = nil
+	plan, err := s.storage.PlanUndo("Tester", 1)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), s.storage.Revert(plan[0]))
+	require.Equal(s.T(), []change{{archived, content, nil}, {active, nil, content}}, changes)
+}
+
+func (s *JournalSuite) TestRevert() {
+	ticket := s.newTicket("tic-u1")
+	require.NoError(s.T(), s.storage.Write(ticket))
+	ticket.SetStatus(domain.StatusClosed, time.Now().UTC())
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	plan, err := s.storage.PlanUndo("Tester", 1)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), plan, 1)
+	require.Equal(s.T(), ActionStatus, plan[0].Action)
+
+	require.NoError(s.T(), s.storage.Revert(plan[0]))
+	restored, err := s.storage.Read("tic-u1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.StatusOpen, restored.Status)
+
+	// The undone status change is skipped; next comes the create.
+	plan, err = s.storage.PlanUndo("Tester", 5)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), plan, 1)
+	require.Equal(s.T(), ActionCreate, plan[0].Action)
+
+	require.NoError(s.T(), s.storage.Revert(plan[0]))
+	require.False(s.T(), s.storage.Exists("tic-u1"))
+
+	plan, err = s.storage.PlanUndo("Tester", 1)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), plan)
+
+	events, err := s.storage.ReadJournal()
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), ActionUndo, events[len(events)-1].Action)
+	require.Equal(s.T(), events[0].ID, events[len(events)-1].Undoes)
+}
+
+func (s *JournalSuite) TestRevert_BumpsRevision() {
+	ticket := s.newTicket("tic-u5")
+	require.NoError(s.T(), s.storage.Write(ticket))
+	ticket.Title = "Renamed"
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	plan, err := s.storage.PlanUndo("Tester", 1)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), s.storage.Revert(plan[0]))
+
+	restored, err := s.storage.Read("tic-u5")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Journal test", restored.Title)
+	require.Equal(s.T(), 3, restored.Revision)
+
+	// A copy read before the undo is now stale
+	ticket.Title = "Stale"
+	require.ErrorIs(s.T(), s.storage.Write(ticket), ErrConflict)
+}
+
+func (s *JournalSuite) TestRevert_FileChangedOutsideJournal() {
+	ticket := s.newTicket("tic-u6")
+	require.NoError(s.T(), s.storage.Write(ticket))
+	ticket.SetStatus(domain.StatusClosed, time.Now().UTC())
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	path := filepath.Join(s.tempDir, "tic-u6.md")
+	data, err := os.ReadFile(path)
+	require.NoError(s.T(), err)
+	edited := strings.Replace(string(data), "# Journal test", "# Edited by hand", 1)
+	require.NoError(s.T(), os.WriteFile(path, []byte(edited), 0644))
+
+	plan, err := s.storage.PlanUndo("Tester", 1)
+	require.NoError(s.T(), err)
+	require.ErrorIs(s.T(), s.storage.Revert(plan[0]), ErrUndoConflict)
+
+	data, err = os.ReadFile(path)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), edited, string(data))
+}
+
+func (s *JournalSuite) TestPlanUndo_OnlyOwnEvents() {
+	require.NoError(s.T(), s.storage.Write(s.newTicket("tic-u2")))
+
+	other := New(s.tempDir)
+	other.SetActor("Someone Else")
+	require.NoError(s.T(), other.Write(s.newTicket("tic-u3")))
+
+	plan, err := s.storage.PlanUndo("Tester", 5)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), plan, 1)
+	require.Equal(s.T(), "tic-u2", plan[0].Ticket)
+}
+
+func (s *JournalSuite) TestPlanUndo_Conflict() {
+	ticket := s.newTicket("tic-u4")
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	other := New(s.tempDir)
+	other.SetActor("Someone Else")
+	ticket.Title = "Changed by someone else"
+	require.NoError(s.T(), other.Write(ticket))
+
+	_, err := s.storage.PlanUndo("Tester", 1)
+	require.ErrorIs(s.T(), err, ErrUndoConflict)
+}
+
+func (s *JournalSuite) TestArchiveTicketAndUndo() {
+	ticket := &domain.Ticket{ID: "tic-arch", Status: domain.StatusClosed, Created: time.Now().UTC()}
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	require.NoError(s.T(), s.storage.ArchiveTicket("tic-arch"))
+	require.False(s.T(), s.storage.Exists("tic-arch"))
+	require.True(s.T(), s.storage.Archive().Exists("tic-arch"))
+
+	plan, err := s.storage.PlanUndo(s.storage.Actor(), 1)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), plan, 1)
+	require.Equal(s.T(), ActionArchive, plan[0].Action)
+
+	// An active ticket recreated under the same ID is not replaced
+	require.NoError(s.T(), s.storage.Write(&domain.Ticket{ID: "tic-arch", Status: domain.StatusOpen, Created: time.Now().UTC()}))
+	require.ErrorContains(s.T(), s.storage.Revert(plan[0]), "an active ticket with that ID exists")
+	require.True(s.T(), s.storage.Archive().Exists("tic-arch"))
+	require.NoError(s.T(), os.Remove(filepath.Join(s.tempDir, "tic-arch.md")))
+
+	require.NoError(s.T(), s.storage.Revert(plan[0]))
+	require.True(s.T(), s.storage.Exists("tic-arch"))
+	require.False(s.T(), s.storage.Archive().Exists("tic-arch"))
+
+	require.ErrorIs(s.T(), s.storage.ArchiveTicket("tic-none"), ErrNotFound)
+}
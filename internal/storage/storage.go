@@ -2,13 +2,18 @@
 package storage
 
 import (
+	"bytes"
+	"cmp"
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/radutopala/ticket/internal/domain"
 )
@@ -16,6 +21,15 @@ import (
 // ErrAlreadyClaimed is returned when trying to claim a ticket that is not open.
 var ErrAlreadyClaimed = errors.New("ticket already claimed")
 
+// ErrNotFound is returned when no ticket matches an ID.
+var ErrNotFound = errors.New("ticket not found")
+
+// ErrConflict is returned when a ticket was modified between being read and written.
+var ErrConflict = errors.New("ticket changed since read")
+
+// ErrLocked is returned when changing a locked ticket.
+var ErrLocked = errors.New("ticket is locked")
+
 const (
 	// TicketsDirName is the name of the tickets directory.
 	TicketsDirName = ".tickets"
@@ -23,17 +37,34 @@ const (
 	IDPrefix = "tic"
 	// IDRandomLength is the length of the random part of the ID.
 	IDRandomLength = 4
+	// ArchiveDirName is the name of the archive directory inside the tickets directory.
+	ArchiveDirName = "archive"
 )
 
 // Storage handles ticket file operations.
+// Every mutation is recorded in the journal at journalPath.
 type Storage struct {
-	ticketsDir string
+	ticketsDir  string
+	journalPath string
+	actor       string
+	overwrite   bool
+	// held records the ticket files locked by WithLock, which writes
+	// inside the callback must not lock again.
+	held     map[string]bool
+	onChange ChangeFunc
 }
 
+// ChangeFunc is called with a ticket file's path and contents before and after
+// each mutation. before is nil for created tickets and after is nil for
+// deleted ones. The path is in whichever store made the change, e.g. the archive.
+type ChangeFunc func(id, path string, before, after []byte)
+
 // New creates a new Storage instance.
 func New(ticketsDir string) *Storage {
 	return &Storage{
-		ticketsDir: ticketsDir,
+		ticketsDir:  ticketsDir,
+		journalPath: filepath.Join(ticketsDir, JournalFileName),
+		held:        make(map[string]bool),
 	}
 }
 
@@ -64,13 +95,30 @@ func (s *Storage) TicketsDir() string {
 	return s.ticketsDir
 }
 
-// GenerateID generates a unique ticket ID.
-func GenerateID() (string, error) {
+// Archive returns a Storage for the archived tickets kept in the
+// archive subdirectory of the tickets directory.
+func (s *Storage) Archive() *Storage {
+	return &Storage{
+		ticketsDir:  filepath.Join(s.ticketsDir, ArchiveDirName),
+		journalPath: s.journalPath,
+		actor:       s.actor,
+		overwrite:   s.overwrite,
+		held:        s.held,
+		onChange:    s.onChange,
+	}
+}
+
+// GenerateID generates a unique ticket ID starting with prefix and a dash.
+// An empty prefix uses IDPrefix.
+func GenerateID(prefix string) (string, error) {
+	if prefix == "" {
+		prefix = IDPrefix
+	}
 	bytes := make([]byte, IDRandomLength)
 	if _, err := rand.Read(bytes); err != nil {
 		return "", fmt.Errorf("failed to generate random bytes: %w", err)
 	}
-	return fmt.Sprintf("%s-%s", IDPrefix, hex.EncodeToString(bytes)[:IDRandomLength]), nil
+	return fmt.Sprintf("%s-%s", prefix, hex.EncodeToString(bytes)[:IDRandomLength]), nil
 }
 
 // List returns all tickets in the storage directory.
@@ -106,19 +154,340 @@ func (s *Storage) Read(id string) (*domain.Ticket, error) {
 	return domain.ParseFromFile(path)
 }
 
-// Write saves a ticket to storage.
+// SetOverwrite disables the revision check in Write, letting stale tickets
+// overwrite concurrent changes.
+func (s *Storage) SetOverwrite(overwrite bool) {
+	s.overwrite = overwrite
+}
+
+// SetOnChange registers fn to be called after every mutation.
+func (s *Storage) SetOnChange(fn ChangeFunc) {
+	s.onChange = fn
+}
+
+// Write saves a ticket to storage, stamping it with the actor and update time,
+// and records the change in the journal.
+// If the ticket file exists and is no longer at the revision the ticket was
+// read at (0 for tickets written before revisions existed), someone else has
+// written it since and Write fails with ErrConflict unless overwrite is set.
+// The ticket's revision is incremented on success.
 func (s *Storage) Write(ticket *domain.Ticket) error {
 	path := filepath.Join(s.ticketsDir, ticket.ID+".md")
-	return ticket.WriteToFile(path)
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to open ticket file: %w", err)
+	}
+
+	var before []byte
+	current := 0
+	if file != nil {
+		defer func() { _ = file.Close() }()
+
+		unlock, err := s.lock(file, path)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		before, err = os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read ticket file: %w", err)
+		}
+		if existing, err := domain.Parse(before); err == nil {
+			current = existing.Revision
+			if existing.Locked && !onlyLockChanged(existing, ticket) {
+				return LockedError(existing)
+			}
+		}
+	}
+
+	if !s.overwrite && before != nil && current != ticket.Revision {
+		return fmt.Errorf("%w: %s is at revision %d, expected %d (re-run the command or use --overwrite)",
+			ErrConflict, ticket.ID, current, ticket.Revision)
+	}
+
+	read, updatedBy, updatedAt := ticket.Revision, ticket.LastUpdatedBy, ticket.UpdatedAt
+	ticket.Revision = max(read, current) + 1
+	ticket.UpdatedAt = time.Now().UTC()
+	if s.actor != "" {
+		ticket.LastUpdatedBy = s.actor
+	}
+	if err := ticket.WriteToFile(path); err != nil {
+		ticket.Revision, ticket.LastUpdatedBy, ticket.UpdatedAt = read, updatedBy, updatedAt
+		return err
+	}
+
+	return s.recordChange(ticket.ID, before, ticket)
 }
 
-// Delete removes a ticket from storage.
+// WriteFile saves data as ticket id's file, keeping it exactly as given apart
+// from the revision and update stamps, so hand edits keep their formatting and
+// any frontmatter keys tk does not know. data must parse as ticket id, and the
+// checks are those of Write: a locked ticket is refused, and so is a file no
+// longer at revision unless overwrite is set.
+func (s *Storage) WriteFile(id string, data []byte, revision int) error {
+	ticket, err := domain.Parse(data)
+	if err != nil {
+		return fmt.Errorf("invalid ticket: %w", err)
+	}
+	if ticket.ID != id {
+		return fmt.Errorf("invalid ticket: id cannot be changed from %s to %s", id, ticket.ID)
+	}
+
+	path := filepath.Join(s.ticketsDir, id+".md")
+	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		return fmt.Errorf("failed to open ticket file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	unlock, err := s.lock(file, path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read ticket file: %w", err)
+	}
+	current := 0
+	if existing, err := domain.Parse(before); err == nil {
+		current = existing.Revision
+		if existing.Locked {
+			return LockedError(existing)
+		}
+	}
+	if !s.overwrite && current != revision {
+		return fmt.Errorf("%w: %s is at revision %d, expected %d (re-run the command or use --overwrite)",
+			ErrConflict, id, current, revision)
+	}
+
+	ticket.Revision = max(revision, current) + 1
+	ticket.UpdatedAt = time.Now().UTC()
+	data = stampFrontmatter(data, "revision", strconv.Itoa(ticket.Revision))
+	data = stampFrontmatter(data, "updated-at", ticket.UpdatedAt.Format(time.RFC3339Nano))
+	if s.actor != "" {
+		ticket.LastUpdatedBy = s.actor
+		data = stampFrontmatter(data, "last-updated-by", strconv.Quote(s.actor))
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write ticket file: %w", err)
+	}
+
+	return s.recordFile(id, before, data, ticket)
+}
+
+// stampFrontmatter sets the top-level frontmatter key in data to value,
+// replacing its line or adding one at the end of the frontmatter.
+func stampFrontmatter(data []byte, key, value string) []byte {
+	lines := strings.SplitAfter(string(data), "\n")
+	line := key + ": " + value + "\n"
+	for i := 1; i < len(lines); i++ {
+		if lines[i] == "---\n" {
+			return []byte(strings.Join(slices.Insert(lines, i, line), ""))
+		}
+		if strings.HasPrefix(lines[i], key+":") {
+			lines[i] = line
+			return []byte(strings.Join(lines, ""))
+		}
+	}
+	return data
+}
+
+// Restore writes a new ticket exactly as given, keeping its revision and
+// update stamps, and records the creation in the journal. It is used to load
+// backups and fails if the ticket already exists.
+func (s *Storage) Restore(ticket *domain.Ticket) error {
+	data, err := ticket.Render()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.ticketsDir, ticket.ID+".md")
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("ticket %s already exists", ticket.ID)
+		}
+		return fmt.Errorf("failed to create ticket file: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write ticket file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write ticket file: %w", err)
+	}
+
+	return s.recordChange(ticket.ID, nil, ticket)
+}
+
+// Delete removes a ticket from storage and records the deletion in the journal.
 func (s *Storage) Delete(id string) error {
 	path := filepath.Join(s.ticketsDir, id+".md")
+
+	before, err := readIfExists(path)
+	if err != nil {
+		return err
+	}
+
+	if existing, err := domain.Parse(before); err == nil && existing.Locked {
+		return LockedError(existing)
+	}
+
 	if err := os.Remove(path); err != nil {
 		return fmt.Errorf("failed to delete ticket %s: %w", id, err)
 	}
-	return nil
+
+	return s.recordChange(id, before, nil)
+}
+
+// WithLock holds an exclusive lock on ticket id's file while fn runs, so no
+// other process can write it in the meantime. Writes to id inside fn reuse the
+// lock. If fn returns an error, the file is restored to its contents when the
+// lock was taken, so nesting WithLock calls makes multi-ticket changes commit
+// or roll back together. Nested locks should be taken in a consistent (e.g.
+// sorted) order to avoid deadlocks.
+func (s *Storage) WithLock(id string, fn func() error) error {
+	path := filepath.Join(s.ticketsDir, id+".md")
+	if s.held[path] {
+		return fn()
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		return fmt.Errorf("failed to open ticket file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	unlock, err := s.lock(file, path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	snapshot, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read ticket file: %w", err)
+	}
+
+	s.held[path] = true
+	err = fn()
+	delete(s.held, path)
+	if err == nil {
+		return nil
+	}
+
+	if rollbackErr := s.rollback(id, path, snapshot); rollbackErr != nil {
+		return errors.Join(err, rollbackErr)
+	}
+	return err
+}
+
+// lock takes the exclusive lock on file unless WithLock already holds it,
+// returning the matching unlock function.
+func (s *Storage) lock(file *os.File, path string) (func(), error) {
+	if s.held[path] {
+		return func() {}, nil
+	}
+	if err := lockFile(file); err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	return func() { _ = unlockFile(file) }, nil
+}
+
+// rollback restores the ticket file at path to snapshot if it has changed,
+// journaling the restoration.
+func (s *Storage) rollback(id, path string, snapshot []byte) error {
+	current, err := readIfExists(path)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(current, snapshot) {
+		return nil
+	}
+
+	if err := os.WriteFile(path, snapshot, 0644); err != nil {
+		return fmt.Errorf("failed to roll back ticket %s: %w", id, err)
+	}
+	restored, err := domain.Parse(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to parse ticket %s: %w", id, err)
+	}
+	return s.recordFile(id, current, snapshot, restored)
+}
+
+// ArchiveTicket moves ticket id into the archive directory and journals the move.
+func (s *Storage) ArchiveTicket(id string) error {
+	path := filepath.Join(s.ticketsDir, id+".md")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		return fmt.Errorf("failed to read ticket %s: %w", id, err)
+	}
+
+	archive := s.Archive()
+	if err := archive.EnsureDir(); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	if archive.Exists(id) {
+		return fmt.Errorf("ticket %s is already archived", id)
+	}
+	archivedPath := filepath.Join(archive.ticketsDir, id+".md")
+	if err := os.Rename(path, archivedPath); err != nil {
+		return fmt.Errorf("failed to archive ticket %s: %w", id, err)
+	}
+
+	// The move is reported as the removal of one file and the creation of the other
+	if s.onChange != nil {
+		s.onChange(id, path, data, nil)
+		s.onChange(id, archivedPath, nil, data)
+	}
+
+	return s.appendEvent(Event{Action: ActionArchive, Ticket: id})
+}
+
+// LockedError describes why a locked ticket cannot be changed.
+func LockedError(t *domain.Ticket) error {
+	if t.LockReason != "" {
+		return fmt.Errorf("%w: %s (%s); unlock it with tk unlock", ErrLocked, t.ID, t.LockReason)
+	}
+	return fmt.Errorf("%w: %s; unlock it with tk unlock", ErrLocked, t.ID)
+}
+
+// onlyLockChanged reports whether updated differs from stored in nothing but
+// its lock and write stamps, the only change a locked ticket accepts.
+func onlyLockChanged(stored, updated *domain.Ticket) bool {
+	normalize := func(t domain.Ticket) ([]byte, error) {
+		t.Locked, t.LockReason = false, ""
+		t.Revision, t.UpdatedAt, t.LastUpdatedBy = 0, time.Time{}, ""
+		return t.Render()
+	}
+	a, errA := normalize(*stored)
+	b, errB := normalize(*updated)
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// readIfExists returns the contents of path, or nil if it does not exist.
+func readIfExists(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read ticket file: %w", err)
+	}
+	return data, nil
 }
 
 // Exists checks if a ticket exists.
@@ -128,14 +497,15 @@ func (s *Storage) Exists(id string) bool {
 	return err == nil
 }
 
-// ResolveID resolves a partial ID to a full ticket ID.
+// ResolveID resolves a partial ID to a full ticket ID. Any part of the ID
+// matches, so IDs with different prefixes (tic-a1b2, bug-c3d4) resolve alike.
 // Returns the full ID if exactly one match is found.
 // Returns an error if no match or multiple matches are found.
 func (s *Storage) ResolveID(partial string) (string, error) {
 	entries, err := os.ReadDir(s.ticketsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return "", fmt.Errorf("ticket not found: %s", partial)
+			return "", fmt.Errorf("%w: %s", ErrNotFound, partial)
 		}
 		return "", fmt.Errorf("failed to read tickets directory: %w", err)
 	}
@@ -154,7 +524,7 @@ func (s *Storage) ResolveID(partial string) (string, error) {
 
 	switch len(matches) {
 	case 0:
-		return "", fmt.Errorf("ticket not found: %s", partial)
+		return "", fmt.Errorf("%w: %s", ErrNotFound, partial)
 	case 1:
 		return matches[0], nil
 	default:
@@ -192,6 +562,13 @@ func (s *Storage) EnsureDir() error {
 // checking the current status, and updating to in_progress only if the ticket is open.
 // Returns ErrAlreadyClaimed if the ticket is not in open status.
 func (s *Storage) AtomicClaim(id string) (*domain.Ticket, error) {
+	return s.AtomicClaimLease(id, 0)
+}
+
+// AtomicClaimLease is like AtomicClaim, but a positive lease makes the claim
+// expire after that duration. An in_progress ticket whose lease has expired
+// can be claimed again, and the holder of a lease can renew it.
+func (s *Storage) AtomicClaimLease(id string, lease time.Duration) (*domain.Ticket, error) {
 	path := filepath.Join(s.ticketsDir, id+".md")
 
 	// Open file for read/write
@@ -202,10 +579,11 @@ func (s *Storage) AtomicClaim(id string) (*domain.Ticket, error) {
 	defer func() { _ = file.Close() }()
 
 	// Acquire exclusive lock (blocking)
-	if err := lockFile(file); err != nil {
-		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	unlock, err := s.lock(file, path)
+	if err != nil {
+		return nil, err
 	}
-	defer func() { _ = unlockFile(file) }()
+	defer unlock()
 
 	// Read current content
 	data, err := os.ReadFile(path)
@@ -219,12 +597,31 @@ func (s *Storage) AtomicClaim(id string) (*domain.Ticket, error) {
 	}
 
 	// Check if claimable
-	if ticket.Status != domain.StatusOpen {
+	if ticket.Locked {
+		return nil, LockedError(ticket)
+	}
+	now := time.Now().UTC()
+	renew := !ticket.LeaseUntil.IsZero() && s.actor != "" && ticket.LeaseHolder == s.actor
+	if !ticket.Claimable(now) && !renew {
+		if !ticket.LeaseUntil.IsZero() {
+			return nil, fmt.Errorf("%w: leased by %s until %s", ErrAlreadyClaimed,
+				cmp.Or(ticket.LeaseHolder, "unknown"), ticket.LeaseUntil.Format(time.RFC3339))
+		}
 		return nil, fmt.Errorf("%w: status is %s", ErrAlreadyClaimed, ticket.Status)
 	}
 
-	// Update status
-	ticket.Status = domain.StatusInProgress
+	// Update status and lease
+	ticket.SetStatus(domain.StatusInProgress, now)
+	ticket.LeaseUntil, ticket.LeaseHolder = time.Time{}, ""
+	if lease > 0 {
+		ticket.LeaseUntil = now.Add(lease).Truncate(time.Second)
+		ticket.LeaseHolder = s.actor
+	}
+	ticket.Revision++
+	ticket.UpdatedAt = now
+	if s.actor != "" {
+		ticket.LastUpdatedBy = s.actor
+	}
 
 	// Write back (truncate and write)
 	newData, err := ticket.Render()
@@ -242,5 +639,9 @@ func (s *Storage) AtomicClaim(id string) (*domain.Ticket, error) {
 		return nil, fmt.Errorf("failed to write ticket: %w", err)
 	}
 
+	if err := s.recordChange(id, data, ticket); err != nil {
+		return nil, err
+	}
+
 	return ticket, nil
 }
@@ -1,8 +1,10 @@
 package storage
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,17 +40,21 @@ func (s *StorageSuite) TearDownTest() {
 }
 
 func (s *StorageSuite) TestGenerateID() {
-	id, err := GenerateID()
+	id, err := GenerateID("")
 	require.NoError(s.T(), err)
 	require.True(s.T(), len(id) > 0)
 	require.Contains(s.T(), id, IDPrefix+"-")
+
+	id, err = GenerateID("bug")
+	require.NoError(s.T(), err)
+	require.Regexp(s.T(), `^bug-[0-9a-f]{4}$`, id)
 }
 
 func (s *StorageSuite) TestGenerateIDUnique() {
 	ids := make(map[string]bool)
 	// Test fewer iterations since we have limited ID space (4 hex chars = 65536 values)
 	for range 10 {
-		id, err := GenerateID()
+		id, err := GenerateID("")
 		require.NoError(s.T(), err)
 		require.False(s.T(), ids[id], "duplicate ID generated: %s", id)
 		ids[id] = true
@@ -374,6 +380,81 @@ func (s *StorageSuite) TestAtomicClaim_Success() {
 	require.Equal(s.T(), domain.StatusInProgress, read.Status)
 }
 
+func (s *StorageSuite) TestAtomicClaimLease() {
+	ticket := &domain.Ticket{
+		ID:      "tic-lease1",
+		Status:  domain.StatusOpen,
+		Title:   "Leased",
+		Created: time.Now().UTC(),
+	}
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	s.storage.SetActor("agent-1")
+	claimed, err := s.storage.AtomicClaimLease("tic-lease1", time.Hour)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "agent-1", claimed.LeaseHolder)
+	require.WithinDuration(s.T(), time.Now().Add(time.Hour), claimed.LeaseUntil, time.Minute)
+
+	// The holder renews, anyone else is refused while the lease runs
+	_, err = s.storage.AtomicClaimLease("tic-lease1", 2*time.Hour)
+	require.NoError(s.T(), err)
+	s.storage.SetActor("agent-2")
+	_, err = s.storage.AtomicClaimLease("tic-lease1", time.Hour)
+	require.ErrorIs(s.T(), err, ErrAlreadyClaimed)
+	require.ErrorContains(s.T(), err, "leased by agent-1")
+
+	// An expired lease can be taken over
+	read, err := s.storage.Read("tic-lease1")
+	require.NoError(s.T(), err)
+	read.LeaseUntil = time.Now().UTC().Add(-time.Minute)
+	require.NoError(s.T(), s.storage.Write(read))
+	claimed, err = s.storage.AtomicClaimLease("tic-lease1", 0)
+	require.NoError(s.T(), err)
+	require.True(s.T(), claimed.LeaseUntil.IsZero())
+	require.Empty(s.T(), claimed.LeaseHolder)
+}
+
+func (s *StorageSuite) TestLockedTicketRefusesChanges() {
+	ticket := &domain.Ticket{
+		ID:      "tic-lock1",
+		Status:  domain.StatusOpen,
+		Title:   "Signed off",
+		Created: time.Now().UTC(),
+	}
+	require.NoError(s.T(), s.storage.Write(ticket))
+	ticket.Locked = true
+	ticket.LockReason = "under audit"
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	ticket.Title = "Changed"
+	err := s.storage.Write(ticket)
+	require.ErrorIs(s.T(), err, ErrLocked)
+	require.Contains(s.T(), err.Error(), "under audit")
+
+	s.storage.SetOverwrite(true)
+	require.ErrorIs(s.T(), s.storage.Write(ticket), ErrLocked)
+	s.storage.SetOverwrite(false)
+
+	// Unlocking in the same write as another change is refused too
+	ticket.Locked = false
+	require.ErrorIs(s.T(), s.storage.Write(ticket), ErrLocked)
+
+	_, err = s.storage.AtomicClaim("tic-lock1")
+	require.ErrorIs(s.T(), err, ErrLocked)
+	require.ErrorIs(s.T(), s.storage.Delete("tic-lock1"), ErrLocked)
+
+	read, err := s.storage.Read("tic-lock1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Signed off", read.Title)
+
+	// Unlocking is allowed, after which writes succeed again
+	read.Locked = false
+	read.LockReason = ""
+	require.NoError(s.T(), s.storage.Write(read))
+	read.Title = "Changed"
+	require.NoError(s.T(), s.storage.Write(read))
+}
+
 func (s *StorageSuite) TestAtomicClaim_AlreadyInProgress() {
 	ticket := &domain.Ticket{
 		ID:      "tic-claim2",
@@ -490,3 +571,196 @@ func (s *StorageSuite) TestRead_NotFound() {
 	_, err := s.storage.Read("nonexistent")
 	require.Error(s.T(), err)
 }
+
+func (s *StorageSuite) TestArchive() {
+	archive := s.storage.Archive()
+	require.Equal(s.T(), filepath.Join(s.storage.TicketsDir(), ArchiveDirName), archive.TicketsDir())
+	require.NoError(s.T(), archive.EnsureDir())
+
+	active := &domain.Ticket{ID: "tic-active", Status: domain.StatusOpen, Created: time.Now().UTC()}
+	archived := &domain.Ticket{ID: "tic-archived", Status: domain.StatusClosed, Created: time.Now().UTC()}
+	require.NoError(s.T(), s.storage.Write(active))
+	require.NoError(s.T(), archive.Write(archived))
+
+	tickets, err := s.storage.List()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), tickets, 1)
+	require.Equal(s.T(), "tic-active", tickets[0].ID)
+
+	tickets, err = archive.List()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), tickets, 1)
+	require.Equal(s.T(), "tic-archived", tickets[0].ID)
+
+	id, err := archive.ResolveID("archived")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "tic-archived", id)
+}
+
+func (s *StorageSuite) TestWrite_IncrementsRevision() {
+	ticket := &domain.Ticket{ID: "tic-rev1", Status: domain.StatusOpen, Created: time.Now().UTC()}
+	require.NoError(s.T(), s.storage.Write(ticket))
+	require.Equal(s.T(), 1, ticket.Revision)
+	require.False(s.T(), ticket.UpdatedAt.IsZero())
+
+	read, err := s.storage.Read("tic-rev1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, read.Revision)
+
+	read.Title = "Updated"
+	require.NoError(s.T(), s.storage.Write(read))
+	require.Equal(s.T(), 2, read.Revision)
+
+	claimed, err := s.storage.AtomicClaim("tic-rev1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 3, claimed.Revision)
+}
+
+func (s *StorageSuite) TestWrite_ConflictOnStaleRevision() {
+	ticket := &domain.Ticket{ID: "tic-rev2", Status: domain.StatusOpen, Created: time.Now().UTC()}
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	first, err := s.storage.Read("tic-rev2")
+	require.NoError(s.T(), err)
+	second, err := s.storage.Read("tic-rev2")
+	require.NoError(s.T(), err)
+
+	first.Title = "First writer"
+	require.NoError(s.T(), s.storage.Write(first))
+
+	second.Title = "Second writer"
+	err = s.storage.Write(second)
+	require.ErrorIs(s.T(), err, ErrConflict)
+	require.Contains(s.T(), err.Error(), "ticket changed since read")
+
+	current, err := s.storage.Read("tic-rev2")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "First writer", current.Title)
+
+	s.storage.SetOverwrite(true)
+	require.NoError(s.T(), s.storage.Write(second))
+	require.Equal(s.T(), 3, second.Revision)
+
+	current, err = s.storage.Read("tic-rev2")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Second writer", current.Title)
+}
+
+func (s *StorageSuite) TestWrite_ConflictOnTicketWithoutRevision() {
+	// Written before revisions existed
+	path := filepath.Join(s.storage.TicketsDir(), "tic-rev3.md")
+	require.NoError(s.T(), os.WriteFile(path, []byte("---\nid: tic-rev3\nstatus: open\n---\n# Legacy\n"), 0644))
+
+	first, err := s.storage.Read("tic-rev3")
+	require.NoError(s.T(), err)
+	require.Zero(s.T(), first.Revision)
+	second, err := s.storage.Read("tic-rev3")
+	require.NoError(s.T(), err)
+
+	first.Title = "First writer"
+	require.NoError(s.T(), s.storage.Write(first))
+
+	second.Title = "Second writer"
+	require.ErrorIs(s.T(), s.storage.Write(second), ErrConflict)
+}
+
+func (s *StorageSuite) TestWriteFile_KeepsContent() {
+	path := filepath.Join(s.storage.TicketsDir(), "tic-raw.md")
+	require.NoError(s.T(), os.WriteFile(path, []byte("---\nid: tic-raw\nstatus: open\nrevision: 1\n---\n# Raw\n"), 0644))
+
+	edited := "---\nid: tic-raw\nstatus: open\nrevision: 1\nsprint: 42   # custom key\n---\n# Raw\n\nHand   formatted.\n"
+	require.NoError(s.T(), s.storage.WriteFile("tic-raw", []byte(edited), 1))
+
+	data, err := os.ReadFile(path)
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), string(data), "revision: 2\nsprint: 42   # custom key\nupdated-at: ")
+	require.True(s.T(), strings.HasSuffix(string(data), "---\n# Raw\n\nHand   formatted.\n"))
+
+	events, err := s.storage.ReadJournal()
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "tic-raw", events[len(events)-1].Ticket)
+
+	// The checks of Write apply
+	require.ErrorIs(s.T(), s.storage.WriteFile("tic-raw", []byte(edited), 1), ErrConflict)
+	require.ErrorContains(s.T(), s.storage.WriteFile("tic-raw", []byte("# no frontmatter\n"), 2), "invalid ticket")
+	require.ErrorContains(s.T(), s.storage.WriteFile("tic-raw", []byte(strings.Replace(edited, "id: tic-raw", "id: tic-other", 1)), 2), "id cannot be changed")
+	require.NoError(s.T(), os.WriteFile(path, []byte("---\nid: tic-raw\nstatus: open\nlocked: true\nlock-reason: release\nrevision: 2\n---\n# Raw\n"), 0644))
+	err = s.storage.WriteFile("tic-raw", []byte(edited), 2)
+	require.ErrorIs(s.T(), err, ErrLocked)
+	require.ErrorContains(s.T(), err, "release")
+}
+
+func (s *StorageSuite) TestWithLock_WritesInsideLock() {
+	ticket := &domain.Ticket{ID: "tic-lock1", Status: domain.StatusOpen, Created: time.Now().UTC()}
+	require.NoError(s.T(), s.storage.Write(ticket))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.storage.WithLock("tic-lock1", func() error {
+			read, err := s.storage.Read("tic-lock1")
+			if err != nil {
+				return err
+			}
+			read.Title = "Locked write"
+			if err := s.storage.Write(read); err != nil {
+				return err
+			}
+			_, err = s.storage.AtomicClaim("tic-lock1")
+			return err
+		})
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(s.T(), err)
+	case <-time.After(5 * time.Second):
+		s.T().Fatal("write inside WithLock deadlocked")
+	}
+
+	current, err := s.storage.Read("tic-lock1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Locked write", current.Title)
+	require.Equal(s.T(), domain.StatusInProgress, current.Status)
+}
+
+func (s *StorageSuite) TestWithLock_RollsBackOnError() {
+	a := &domain.Ticket{ID: "tic-lock-a", Status: domain.StatusOpen, Title: "A", Created: time.Now().UTC()}
+	b := &domain.Ticket{ID: "tic-lock-b", Status: domain.StatusOpen, Title: "B", Created: time.Now().UTC()}
+	require.NoError(s.T(), s.storage.Write(a))
+	require.NoError(s.T(), s.storage.Write(b))
+
+	failure := errors.New("second half failed")
+	err := s.storage.WithLock("tic-lock-a", func() error {
+		return s.storage.WithLock("tic-lock-b", func() error {
+			for _, id := range []string{"tic-lock-a", "tic-lock-b"} {
+				t, err := s.storage.Read(id)
+				if err != nil {
+					return err
+				}
+				t.Links = []string{"linked"}
+				if err := s.storage.Write(t); err != nil {
+					return err
+				}
+			}
+			return failure
+		})
+	})
+	require.ErrorIs(s.T(), err, failure)
+
+	for _, id := range []string{"tic-lock-a", "tic-lock-b"} {
+		t, err := s.storage.Read(id)
+		require.NoError(s.T(), err)
+		require.Empty(s.T(), t.Links, id)
+		require.Equal(s.T(), 1, t.Revision, id)
+	}
+}
+
+func (s *StorageSuite) TestWithLock_NotFound() {
+	called := false
+	err := s.storage.WithLock("tic-missing", func() error {
+		called = true
+		return nil
+	})
+	require.ErrorIs(s.T(), err, ErrNotFound)
+	require.False(s.T(), called)
+}
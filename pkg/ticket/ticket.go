@@ -0,0 +1,231 @@
+// Package ticket is the public Go API for tk ticket repositories. It lets
+// other Go programs read and change tickets the same way the tk CLI does,
+// including revision checks, file locking, the event journal, the id_prefixes
+// and policies of the repository's config file, without shelling out to tk.
+//
+//	repo, err := ticket.Open("")
+//	if err != nil {
+//		return err
+//	}
+//	t, err := repo.Create(&ticket.Ticket{Title: "Rotate keys", Type: ticket.TypeChore})
+package ticket
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/radutopala/ticket/internal/config"
+	"github.com/radutopala/ticket/internal/domain"
+	"github.com/radutopala/ticket/internal/storage"
+)
+
+// Ticket is a single ticket as stored in a markdown file.
+type Ticket = domain.Ticket
+
+// Note is a timestamped note appended to a ticket.
+type Note = domain.Note
+
+// Status is a ticket's workflow status.
+type Status = domain.Status
+
+// Type is a ticket's kind of work.
+type Type = domain.Type
+
+// Ticket statuses.
+const (
+	StatusOpen       = domain.StatusOpen
+	StatusInProgress = domain.StatusInProgress
+	StatusClosed     = domain.StatusClosed
+)
+
+// Ticket types.
+const (
+	TypeTask    = domain.TypeTask
+	TypeBug     = domain.TypeBug
+	TypeFeature = domain.TypeFeature
+	TypeEpic    = domain.TypeEpic
+	TypeChore   = domain.TypeChore
+)
+
+// Errors returned by Repo methods, for use with errors.Is.
+var (
+	ErrNotFound       = storage.ErrNotFound
+	ErrAlreadyClaimed = storage.ErrAlreadyClaimed
+	ErrConflict       = storage.ErrConflict
+	ErrLocked         = storage.ErrLocked
+	// ErrPolicy is returned for tickets that break their type's policy in the
+	// config file. Unlike the CLI, the API has no --force to override it.
+	ErrPolicy = errors.New("ticket violates policy")
+)
+
+// Repo is a ticket repository: a .tickets directory of markdown files.
+// A Repo is not safe for concurrent use by multiple goroutines, but
+// concurrent processes are coordinated through file locks and revisions.
+type Repo struct {
+	store *storage.Storage
+	cfg   *config.Config
+}
+
+// Open opens the ticket repository in dir. An empty dir searches the current
+// directory and its parents for a .tickets directory. The config file in the
+// directory is read for its id_prefixes and policies.
+func Open(dir string) (*Repo, error) {
+	if dir == "" {
+		found, err := storage.FindTicketsDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = found
+	}
+	cfg, err := config.LoadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.ValidatePolicies(cfg.Policies); err != nil {
+		return nil, err
+	}
+	return &Repo{store: storage.New(dir), cfg: cfg}, nil
+}
+
+// Dir returns the repository's tickets directory.
+func (r *Repo) Dir() string {
+	return r.store.TicketsDir()
+}
+
+// SetActor sets the identity recorded in the journal and in the
+// last-updated-by field of tickets written through r.
+func (r *Repo) SetActor(actor string) {
+	r.store.SetActor(actor)
+}
+
+// List returns every active (non-archived) ticket.
+func (r *Repo) List() ([]*Ticket, error) {
+	return r.store.List()
+}
+
+// Get returns the ticket whose ID matches id, which may be a unique partial ID.
+func (r *Repo) Get(id string) (*Ticket, error) {
+	resolved, err := r.store.ResolveID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.store.Read(resolved)
+}
+
+// Query returns the active tickets for which match returns true.
+func (r *Repo) Query(match func(*Ticket) bool) ([]*Ticket, error) {
+	tickets, err := r.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Ticket
+	for _, t := range tickets {
+		if match(t) {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// Create writes a new ticket. An empty ID is generated with the prefix
+// configured for the ticket's type, an empty status defaults to open, and a
+// zero Created time defaults to now. Tickets breaking their type's policy are
+// refused with ErrPolicy.
+func (r *Repo) Create(t *Ticket) (*Ticket, error) {
+	if t.ID == "" {
+		prefix, err := r.cfg.IDPrefix(t.Type)
+		if err != nil {
+			return nil, err
+		}
+		id, err := storage.GenerateID(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate ID: %w", err)
+		}
+		t.ID = id
+	} else if r.store.Exists(t.ID) {
+		return nil, fmt.Errorf("ticket %s already exists", t.ID)
+	}
+	if t.Status == "" {
+		t.Status = StatusOpen
+	}
+	if err := r.validate(t); err != nil {
+		return nil, err
+	}
+	if t.Created.IsZero() {
+		t.Created = time.Now().UTC()
+	}
+
+	if err := r.store.EnsureDir(); err != nil {
+		return nil, fmt.Errorf("failed to create tickets directory: %w", err)
+	}
+	if err := r.store.Write(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Update reads the ticket matching id, applies fn and writes the result.
+// A status change made by fn stamps or clears closed-at like the CLI does.
+// If the ticket is written by someone else in the meantime, Update fails with
+// ErrConflict, and if the result breaks its type's policy, with ErrPolicy.
+func (r *Repo) Update(id string, fn func(*Ticket) error) (*Ticket, error) {
+	t, err := r.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	status := t.Status
+	if err := fn(t); err != nil {
+		return nil, err
+	}
+	if t.Status != status {
+		t.SetStatus(t.Status, time.Now().UTC())
+	}
+	if err := r.validate(t); err != nil {
+		return nil, err
+	}
+
+	if err := r.store.Write(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Claim atomically moves an open ticket to in_progress, failing with
+// ErrAlreadyClaimed if it is not open.
+func (r *Repo) Claim(id string) (*Ticket, error) {
+	resolved, err := r.store.ResolveID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.store.AtomicClaim(resolved)
+}
+
+// ClaimLease is like Claim, but the claim expires after lease unless the
+// holder renews it by claiming again. Tickets whose lease expired can be
+// claimed by anyone.
+func (r *Repo) ClaimLease(id string, lease time.Duration) (*Ticket, error) {
+	resolved, err := r.store.ResolveID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.store.AtomicClaimLease(resolved, lease)
+}
+
+// validate rejects tickets with an unknown status or type, or that break
+// their type's policy.
+func (r *Repo) validate(t *Ticket) error {
+	if !t.Status.IsValid() {
+		return fmt.Errorf("invalid status: %s", t.Status)
+	}
+	if t.Type != "" && !t.Type.IsValid() {
+		return fmt.Errorf("invalid type: %s", t.Type)
+	}
+	if violations := config.PolicyViolations(t, r.cfg.Policies); len(violations) > 0 {
+		return fmt.Errorf("%w: %s", ErrPolicy, strings.Join(violations, "; "))
+	}
+	return nil
+}
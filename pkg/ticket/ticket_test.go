@@ -0,0 +1,160 @@
+package ticket
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RepoSuite struct {
+	suite.Suite
+	dir  string
+	repo *Repo
+}
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoSuite))
+}
+
+func (s *RepoSuite) SetupTest() {
+	s.dir = filepath.Join(s.T().TempDir(), ".tickets")
+	repo, err := Open(s.dir)
+	require.NoError(s.T(), err)
+	s.repo = repo
+	s.repo.SetActor("bot")
+}
+
+func (s *RepoSuite) TestCreateAndGet() {
+	created, err := s.repo.Create(&Ticket{Title: "Rotate keys", Type: TypeChore, Priority: 1})
+	require.NoError(s.T(), err)
+	require.NotEmpty(s.T(), created.ID)
+	require.Equal(s.T(), StatusOpen, created.Status)
+	require.False(s.T(), created.Created.IsZero())
+	require.FileExists(s.T(), filepath.Join(s.dir, created.ID+".md"))
+
+	got, err := s.repo.Get(created.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "Rotate keys", got.Title)
+	require.Equal(s.T(), "bot", got.LastUpdatedBy)
+
+	_, err = s.repo.Create(&Ticket{ID: created.ID, Title: "Duplicate"})
+	require.Error(s.T(), err)
+
+	_, err = s.repo.Create(&Ticket{Title: "Bad", Type: "story"})
+	require.Error(s.T(), err)
+}
+
+func (s *RepoSuite) TestCreateUsesConfig() {
+	require.NoError(s.T(), os.MkdirAll(s.dir, 0755))
+	config := "id_prefixes:\n  bug: bug\npolicies:\n  bug:\n    required: [acceptance]\n"
+	require.NoError(s.T(), os.WriteFile(filepath.Join(s.dir, "config.yaml"), []byte(config), 0644))
+	repo, err := Open(s.dir)
+	require.NoError(s.T(), err)
+
+	_, err = repo.Create(&Ticket{Title: "Crash", Type: TypeBug})
+	require.ErrorIs(s.T(), err, ErrPolicy)
+	require.ErrorContains(s.T(), err, "bug tickets require acceptance criteria")
+
+	created, err := repo.Create(&Ticket{Title: "Crash", Type: TypeBug, Acceptance: "- [ ] no crash"})
+	require.NoError(s.T(), err)
+	require.Regexp(s.T(), `^bug-[0-9a-f]{4}$`, created.ID)
+
+	_, err = repo.Update(created.ID, func(t *Ticket) error {
+		t.Acceptance = ""
+		return nil
+	})
+	require.ErrorIs(s.T(), err, ErrPolicy)
+}
+
+func (s *RepoSuite) TestGetNotFound() {
+	_, err := s.repo.Get("tic-none")
+	require.True(s.T(), errors.Is(err, ErrNotFound))
+}
+
+func (s *RepoSuite) TestUpdate() {
+	created, err := s.repo.Create(&Ticket{Title: "Fix login"})
+	require.NoError(s.T(), err)
+
+	updated, err := s.repo.Update(created.ID, func(t *Ticket) error {
+		t.Status = StatusClosed
+		t.Tags = append(t.Tags, "auth")
+		return nil
+	})
+	require.NoError(s.T(), err)
+	require.False(s.T(), updated.ClosedAt.IsZero())
+
+	got, err := s.repo.Get(created.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), StatusClosed, got.Status)
+	require.Equal(s.T(), []string{"auth"}, got.Tags)
+
+	_, err = s.repo.Update(created.ID, func(t *Ticket) error {
+		return errors.New("abort")
+	})
+	require.EqualError(s.T(), err, "abort")
+}
+
+func (s *RepoSuite) TestUpdateConflict() {
+	created, err := s.repo.Create(&Ticket{Title: "Shared"})
+	require.NoError(s.T(), err)
+
+	_, err = s.repo.Update(created.ID, func(t *Ticket) error {
+		// Someone else writes the ticket while we hold our copy
+		_, err := s.repo.Update(t.ID, func(other *Ticket) error {
+			other.Priority = 0
+			return nil
+		})
+		require.NoError(s.T(), err)
+		t.Priority = 4
+		return nil
+	})
+	require.True(s.T(), errors.Is(err, ErrConflict))
+}
+
+func (s *RepoSuite) TestClaim() {
+	created, err := s.repo.Create(&Ticket{Title: "Pick me"})
+	require.NoError(s.T(), err)
+
+	claimed, err := s.repo.Claim(created.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), StatusInProgress, claimed.Status)
+
+	_, err = s.repo.Claim(created.ID)
+	require.True(s.T(), errors.Is(err, ErrAlreadyClaimed))
+}
+
+func (s *RepoSuite) TestListAndQuery() {
+	_, err := s.repo.Create(&Ticket{Title: "Bug one", Type: TypeBug})
+	require.NoError(s.T(), err)
+	_, err = s.repo.Create(&Ticket{Title: "Task one", Type: TypeTask})
+	require.NoError(s.T(), err)
+
+	all, err := s.repo.List()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), all, 2)
+
+	bugs, err := s.repo.Query(func(t *Ticket) bool { return t.Type == TypeBug })
+	require.NoError(s.T(), err)
+	require.Len(s.T(), bugs, 1)
+	require.Equal(s.T(), "Bug one", bugs[0].Title)
+}
+
+func (s *RepoSuite) TestOpenSearchesParents() {
+	root := s.T().TempDir()
+	require.NoError(s.T(), os.Mkdir(filepath.Join(root, ".tickets"), 0755))
+	sub := filepath.Join(root, "a", "b")
+	require.NoError(s.T(), os.MkdirAll(sub, 0755))
+	s.T().Chdir(sub)
+
+	repo, err := Open("")
+	require.NoError(s.T(), err)
+	resolved, err := filepath.EvalSymlinks(repo.Dir())
+	require.NoError(s.T(), err)
+	want, err := filepath.EvalSymlinks(filepath.Join(root, ".tickets"))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), want, resolved)
+}